@@ -0,0 +1,849 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/govspending/v1/spending.proto
+
+// Government spending ledger API. The services mirror the REST API under
+// /api/v1 and share its service layer; errors carry the same codes, as the
+// reason of a google.rpc.ErrorInfo detail.
+//
+// Requests may set the x-request-id metadata key, echoed in the response
+// headers, and write requests an idempotency-key, which makes a retried
+// create return ALREADY_EXISTS instead of creating a duplicate.
+
+package govspendingv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DocumentTypeService_RegisterDocumentType_FullMethodName   = "/govspending.v1.DocumentTypeService/RegisterDocumentType"
+	DocumentTypeService_GetDocumentType_FullMethodName        = "/govspending.v1.DocumentTypeService/GetDocumentType"
+	DocumentTypeService_ListDocumentTypes_FullMethodName      = "/govspending.v1.DocumentTypeService/ListDocumentTypes"
+	DocumentTypeService_DeactivateDocumentType_FullMethodName = "/govspending.v1.DocumentTypeService/DeactivateDocumentType"
+)
+
+// DocumentTypeServiceClient is the client API for DocumentTypeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DocumentTypeServiceClient interface {
+	RegisterDocumentType(ctx context.Context, in *RegisterDocumentTypeRequest, opts ...grpc.CallOption) (*RegisterDocumentTypeResponse, error)
+	GetDocumentType(ctx context.Context, in *GetDocumentTypeRequest, opts ...grpc.CallOption) (*DocumentType, error)
+	ListDocumentTypes(ctx context.Context, in *ListDocumentTypesRequest, opts ...grpc.CallOption) (*ListDocumentTypesResponse, error)
+	DeactivateDocumentType(ctx context.Context, in *DeactivateDocumentTypeRequest, opts ...grpc.CallOption) (*DeactivateDocumentTypeResponse, error)
+}
+
+type documentTypeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDocumentTypeServiceClient(cc grpc.ClientConnInterface) DocumentTypeServiceClient {
+	return &documentTypeServiceClient{cc}
+}
+
+func (c *documentTypeServiceClient) RegisterDocumentType(ctx context.Context, in *RegisterDocumentTypeRequest, opts ...grpc.CallOption) (*RegisterDocumentTypeResponse, error) {
+	out := new(RegisterDocumentTypeResponse)
+	err := c.cc.Invoke(ctx, DocumentTypeService_RegisterDocumentType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentTypeServiceClient) GetDocumentType(ctx context.Context, in *GetDocumentTypeRequest, opts ...grpc.CallOption) (*DocumentType, error) {
+	out := new(DocumentType)
+	err := c.cc.Invoke(ctx, DocumentTypeService_GetDocumentType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentTypeServiceClient) ListDocumentTypes(ctx context.Context, in *ListDocumentTypesRequest, opts ...grpc.CallOption) (*ListDocumentTypesResponse, error) {
+	out := new(ListDocumentTypesResponse)
+	err := c.cc.Invoke(ctx, DocumentTypeService_ListDocumentTypes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentTypeServiceClient) DeactivateDocumentType(ctx context.Context, in *DeactivateDocumentTypeRequest, opts ...grpc.CallOption) (*DeactivateDocumentTypeResponse, error) {
+	out := new(DeactivateDocumentTypeResponse)
+	err := c.cc.Invoke(ctx, DocumentTypeService_DeactivateDocumentType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DocumentTypeServiceServer is the server API for DocumentTypeService service.
+// All implementations must embed UnimplementedDocumentTypeServiceServer
+// for forward compatibility
+type DocumentTypeServiceServer interface {
+	RegisterDocumentType(context.Context, *RegisterDocumentTypeRequest) (*RegisterDocumentTypeResponse, error)
+	GetDocumentType(context.Context, *GetDocumentTypeRequest) (*DocumentType, error)
+	ListDocumentTypes(context.Context, *ListDocumentTypesRequest) (*ListDocumentTypesResponse, error)
+	DeactivateDocumentType(context.Context, *DeactivateDocumentTypeRequest) (*DeactivateDocumentTypeResponse, error)
+	mustEmbedUnimplementedDocumentTypeServiceServer()
+}
+
+// UnimplementedDocumentTypeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDocumentTypeServiceServer struct {
+}
+
+func (UnimplementedDocumentTypeServiceServer) RegisterDocumentType(context.Context, *RegisterDocumentTypeRequest) (*RegisterDocumentTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDocumentType not implemented")
+}
+func (UnimplementedDocumentTypeServiceServer) GetDocumentType(context.Context, *GetDocumentTypeRequest) (*DocumentType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentType not implemented")
+}
+func (UnimplementedDocumentTypeServiceServer) ListDocumentTypes(context.Context, *ListDocumentTypesRequest) (*ListDocumentTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDocumentTypes not implemented")
+}
+func (UnimplementedDocumentTypeServiceServer) DeactivateDocumentType(context.Context, *DeactivateDocumentTypeRequest) (*DeactivateDocumentTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateDocumentType not implemented")
+}
+func (UnimplementedDocumentTypeServiceServer) mustEmbedUnimplementedDocumentTypeServiceServer() {}
+
+// UnsafeDocumentTypeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DocumentTypeServiceServer will
+// result in compilation errors.
+type UnsafeDocumentTypeServiceServer interface {
+	mustEmbedUnimplementedDocumentTypeServiceServer()
+}
+
+func RegisterDocumentTypeServiceServer(s grpc.ServiceRegistrar, srv DocumentTypeServiceServer) {
+	s.RegisterService(&DocumentTypeService_ServiceDesc, srv)
+}
+
+func _DocumentTypeService_RegisterDocumentType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDocumentTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentTypeServiceServer).RegisterDocumentType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentTypeService_RegisterDocumentType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentTypeServiceServer).RegisterDocumentType(ctx, req.(*RegisterDocumentTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentTypeService_GetDocumentType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentTypeServiceServer).GetDocumentType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentTypeService_GetDocumentType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentTypeServiceServer).GetDocumentType(ctx, req.(*GetDocumentTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentTypeService_ListDocumentTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDocumentTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentTypeServiceServer).ListDocumentTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentTypeService_ListDocumentTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentTypeServiceServer).ListDocumentTypes(ctx, req.(*ListDocumentTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentTypeService_DeactivateDocumentType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateDocumentTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentTypeServiceServer).DeactivateDocumentType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentTypeService_DeactivateDocumentType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentTypeServiceServer).DeactivateDocumentType(ctx, req.(*DeactivateDocumentTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DocumentTypeService_ServiceDesc is the grpc.ServiceDesc for DocumentTypeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DocumentTypeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "govspending.v1.DocumentTypeService",
+	HandlerType: (*DocumentTypeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterDocumentType",
+			Handler:    _DocumentTypeService_RegisterDocumentType_Handler,
+		},
+		{
+			MethodName: "GetDocumentType",
+			Handler:    _DocumentTypeService_GetDocumentType_Handler,
+		},
+		{
+			MethodName: "ListDocumentTypes",
+			Handler:    _DocumentTypeService_ListDocumentTypes_Handler,
+		},
+		{
+			MethodName: "DeactivateDocumentType",
+			Handler:    _DocumentTypeService_DeactivateDocumentType_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/govspending/v1/spending.proto",
+}
+
+const (
+	DocumentService_CreateDocument_FullMethodName     = "/govspending.v1.DocumentService/CreateDocument"
+	DocumentService_GetDocument_FullMethodName        = "/govspending.v1.DocumentService/GetDocument"
+	DocumentService_QueryDocuments_FullMethodName     = "/govspending.v1.DocumentService/QueryDocuments"
+	DocumentService_InvalidateDocument_FullMethodName = "/govspending.v1.DocumentService/InvalidateDocument"
+	DocumentService_GetDocumentHistory_FullMethodName = "/govspending.v1.DocumentService/GetDocumentHistory"
+	DocumentService_GetLinkedDocuments_FullMethodName = "/govspending.v1.DocumentService/GetLinkedDocuments"
+)
+
+// DocumentServiceClient is the client API for DocumentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DocumentServiceClient interface {
+	CreateDocument(ctx context.Context, in *CreateDocumentRequest, opts ...grpc.CallOption) (*CreateDocumentResponse, error)
+	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error)
+	QueryDocuments(ctx context.Context, in *QueryDocumentsRequest, opts ...grpc.CallOption) (*QueryDocumentsResponse, error)
+	InvalidateDocument(ctx context.Context, in *InvalidateDocumentRequest, opts ...grpc.CallOption) (*InvalidateDocumentResponse, error)
+	GetDocumentHistory(ctx context.Context, in *GetDocumentHistoryRequest, opts ...grpc.CallOption) (*GetDocumentHistoryResponse, error)
+	GetLinkedDocuments(ctx context.Context, in *GetLinkedDocumentsRequest, opts ...grpc.CallOption) (*LinkedDocuments, error)
+}
+
+type documentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDocumentServiceClient(cc grpc.ClientConnInterface) DocumentServiceClient {
+	return &documentServiceClient{cc}
+}
+
+func (c *documentServiceClient) CreateDocument(ctx context.Context, in *CreateDocumentRequest, opts ...grpc.CallOption) (*CreateDocumentResponse, error) {
+	out := new(CreateDocumentResponse)
+	err := c.cc.Invoke(ctx, DocumentService_CreateDocument_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error) {
+	out := new(Document)
+	err := c.cc.Invoke(ctx, DocumentService_GetDocument_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) QueryDocuments(ctx context.Context, in *QueryDocumentsRequest, opts ...grpc.CallOption) (*QueryDocumentsResponse, error) {
+	out := new(QueryDocumentsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_QueryDocuments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) InvalidateDocument(ctx context.Context, in *InvalidateDocumentRequest, opts ...grpc.CallOption) (*InvalidateDocumentResponse, error) {
+	out := new(InvalidateDocumentResponse)
+	err := c.cc.Invoke(ctx, DocumentService_InvalidateDocument_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) GetDocumentHistory(ctx context.Context, in *GetDocumentHistoryRequest, opts ...grpc.CallOption) (*GetDocumentHistoryResponse, error) {
+	out := new(GetDocumentHistoryResponse)
+	err := c.cc.Invoke(ctx, DocumentService_GetDocumentHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) GetLinkedDocuments(ctx context.Context, in *GetLinkedDocumentsRequest, opts ...grpc.CallOption) (*LinkedDocuments, error) {
+	out := new(LinkedDocuments)
+	err := c.cc.Invoke(ctx, DocumentService_GetLinkedDocuments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DocumentServiceServer is the server API for DocumentService service.
+// All implementations must embed UnimplementedDocumentServiceServer
+// for forward compatibility
+type DocumentServiceServer interface {
+	CreateDocument(context.Context, *CreateDocumentRequest) (*CreateDocumentResponse, error)
+	GetDocument(context.Context, *GetDocumentRequest) (*Document, error)
+	QueryDocuments(context.Context, *QueryDocumentsRequest) (*QueryDocumentsResponse, error)
+	InvalidateDocument(context.Context, *InvalidateDocumentRequest) (*InvalidateDocumentResponse, error)
+	GetDocumentHistory(context.Context, *GetDocumentHistoryRequest) (*GetDocumentHistoryResponse, error)
+	GetLinkedDocuments(context.Context, *GetLinkedDocumentsRequest) (*LinkedDocuments, error)
+	mustEmbedUnimplementedDocumentServiceServer()
+}
+
+// UnimplementedDocumentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDocumentServiceServer struct {
+}
+
+func (UnimplementedDocumentServiceServer) CreateDocument(context.Context, *CreateDocumentRequest) (*CreateDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDocument not implemented")
+}
+func (UnimplementedDocumentServiceServer) GetDocument(context.Context, *GetDocumentRequest) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocument not implemented")
+}
+func (UnimplementedDocumentServiceServer) QueryDocuments(context.Context, *QueryDocumentsRequest) (*QueryDocumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDocuments not implemented")
+}
+func (UnimplementedDocumentServiceServer) InvalidateDocument(context.Context, *InvalidateDocumentRequest) (*InvalidateDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateDocument not implemented")
+}
+func (UnimplementedDocumentServiceServer) GetDocumentHistory(context.Context, *GetDocumentHistoryRequest) (*GetDocumentHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentHistory not implemented")
+}
+func (UnimplementedDocumentServiceServer) GetLinkedDocuments(context.Context, *GetLinkedDocumentsRequest) (*LinkedDocuments, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkedDocuments not implemented")
+}
+func (UnimplementedDocumentServiceServer) mustEmbedUnimplementedDocumentServiceServer() {}
+
+// UnsafeDocumentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DocumentServiceServer will
+// result in compilation errors.
+type UnsafeDocumentServiceServer interface {
+	mustEmbedUnimplementedDocumentServiceServer()
+}
+
+func RegisterDocumentServiceServer(s grpc.ServiceRegistrar, srv DocumentServiceServer) {
+	s.RegisterService(&DocumentService_ServiceDesc, srv)
+}
+
+func _DocumentService_CreateDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).CreateDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_CreateDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).CreateDocument(ctx, req.(*CreateDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).GetDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_GetDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).GetDocument(ctx, req.(*GetDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_QueryDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).QueryDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_QueryDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).QueryDocuments(ctx, req.(*QueryDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_InvalidateDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).InvalidateDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_InvalidateDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).InvalidateDocument(ctx, req.(*InvalidateDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_GetDocumentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).GetDocumentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_GetDocumentHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).GetDocumentHistory(ctx, req.(*GetDocumentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_GetLinkedDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkedDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).GetLinkedDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_GetLinkedDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).GetLinkedDocuments(ctx, req.(*GetLinkedDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DocumentService_ServiceDesc is the grpc.ServiceDesc for DocumentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DocumentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "govspending.v1.DocumentService",
+	HandlerType: (*DocumentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDocument",
+			Handler:    _DocumentService_CreateDocument_Handler,
+		},
+		{
+			MethodName: "GetDocument",
+			Handler:    _DocumentService_GetDocument_Handler,
+		},
+		{
+			MethodName: "QueryDocuments",
+			Handler:    _DocumentService_QueryDocuments_Handler,
+		},
+		{
+			MethodName: "InvalidateDocument",
+			Handler:    _DocumentService_InvalidateDocument_Handler,
+		},
+		{
+			MethodName: "GetDocumentHistory",
+			Handler:    _DocumentService_GetDocumentHistory_Handler,
+		},
+		{
+			MethodName: "GetLinkedDocuments",
+			Handler:    _DocumentService_GetLinkedDocuments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/govspending/v1/spending.proto",
+}
+
+const (
+	TransferService_InitiateTransfer_FullMethodName    = "/govspending.v1.TransferService/InitiateTransfer"
+	TransferService_AcknowledgeTransfer_FullMethodName = "/govspending.v1.TransferService/AcknowledgeTransfer"
+)
+
+// TransferServiceClient is the client API for TransferService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TransferServiceClient interface {
+	// InitiateTransfer creates the outgoing transfer document on from_channel.
+	InitiateTransfer(ctx context.Context, in *InitiateTransferRequest, opts ...grpc.CallOption) (*TransferResult, error)
+	// AcknowledgeTransfer creates the linked document on the receiving channel.
+	AcknowledgeTransfer(ctx context.Context, in *AcknowledgeTransferRequest, opts ...grpc.CallOption) (*TransferResult, error)
+}
+
+type transferServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransferServiceClient(cc grpc.ClientConnInterface) TransferServiceClient {
+	return &transferServiceClient{cc}
+}
+
+func (c *transferServiceClient) InitiateTransfer(ctx context.Context, in *InitiateTransferRequest, opts ...grpc.CallOption) (*TransferResult, error) {
+	out := new(TransferResult)
+	err := c.cc.Invoke(ctx, TransferService_InitiateTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) AcknowledgeTransfer(ctx context.Context, in *AcknowledgeTransferRequest, opts ...grpc.CallOption) (*TransferResult, error) {
+	out := new(TransferResult)
+	err := c.cc.Invoke(ctx, TransferService_AcknowledgeTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransferServiceServer is the server API for TransferService service.
+// All implementations must embed UnimplementedTransferServiceServer
+// for forward compatibility
+type TransferServiceServer interface {
+	// InitiateTransfer creates the outgoing transfer document on from_channel.
+	InitiateTransfer(context.Context, *InitiateTransferRequest) (*TransferResult, error)
+	// AcknowledgeTransfer creates the linked document on the receiving channel.
+	AcknowledgeTransfer(context.Context, *AcknowledgeTransferRequest) (*TransferResult, error)
+	mustEmbedUnimplementedTransferServiceServer()
+}
+
+// UnimplementedTransferServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTransferServiceServer struct {
+}
+
+func (UnimplementedTransferServiceServer) InitiateTransfer(context.Context, *InitiateTransferRequest) (*TransferResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitiateTransfer not implemented")
+}
+func (UnimplementedTransferServiceServer) AcknowledgeTransfer(context.Context, *AcknowledgeTransferRequest) (*TransferResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeTransfer not implemented")
+}
+func (UnimplementedTransferServiceServer) mustEmbedUnimplementedTransferServiceServer() {}
+
+// UnsafeTransferServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransferServiceServer will
+// result in compilation errors.
+type UnsafeTransferServiceServer interface {
+	mustEmbedUnimplementedTransferServiceServer()
+}
+
+func RegisterTransferServiceServer(s grpc.ServiceRegistrar, srv TransferServiceServer) {
+	s.RegisterService(&TransferService_ServiceDesc, srv)
+}
+
+func _TransferService_InitiateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).InitiateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_InitiateTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).InitiateTransfer(ctx, req.(*InitiateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_AcknowledgeTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).AcknowledgeTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_AcknowledgeTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).AcknowledgeTransfer(ctx, req.(*AcknowledgeTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TransferService_ServiceDesc is the grpc.ServiceDesc for TransferService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransferService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "govspending.v1.TransferService",
+	HandlerType: (*TransferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InitiateTransfer",
+			Handler:    _TransferService_InitiateTransfer_Handler,
+		},
+		{
+			MethodName: "AcknowledgeTransfer",
+			Handler:    _TransferService_AcknowledgeTransfer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/govspending/v1/spending.proto",
+}
+
+const (
+	AnchorService_VerifyAnchor_FullMethodName = "/govspending.v1.AnchorService/VerifyAnchor"
+)
+
+// AnchorServiceClient is the client API for AnchorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnchorServiceClient interface {
+	VerifyAnchor(ctx context.Context, in *VerifyAnchorRequest, opts ...grpc.CallOption) (*AnchorVerification, error)
+}
+
+type anchorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnchorServiceClient(cc grpc.ClientConnInterface) AnchorServiceClient {
+	return &anchorServiceClient{cc}
+}
+
+func (c *anchorServiceClient) VerifyAnchor(ctx context.Context, in *VerifyAnchorRequest, opts ...grpc.CallOption) (*AnchorVerification, error) {
+	out := new(AnchorVerification)
+	err := c.cc.Invoke(ctx, AnchorService_VerifyAnchor_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnchorServiceServer is the server API for AnchorService service.
+// All implementations must embed UnimplementedAnchorServiceServer
+// for forward compatibility
+type AnchorServiceServer interface {
+	VerifyAnchor(context.Context, *VerifyAnchorRequest) (*AnchorVerification, error)
+	mustEmbedUnimplementedAnchorServiceServer()
+}
+
+// UnimplementedAnchorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAnchorServiceServer struct {
+}
+
+func (UnimplementedAnchorServiceServer) VerifyAnchor(context.Context, *VerifyAnchorRequest) (*AnchorVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAnchor not implemented")
+}
+func (UnimplementedAnchorServiceServer) mustEmbedUnimplementedAnchorServiceServer() {}
+
+// UnsafeAnchorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnchorServiceServer will
+// result in compilation errors.
+type UnsafeAnchorServiceServer interface {
+	mustEmbedUnimplementedAnchorServiceServer()
+}
+
+func RegisterAnchorServiceServer(s grpc.ServiceRegistrar, srv AnchorServiceServer) {
+	s.RegisterService(&AnchorService_ServiceDesc, srv)
+}
+
+func _AnchorService_VerifyAnchor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAnchorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnchorServiceServer).VerifyAnchor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnchorService_VerifyAnchor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnchorServiceServer).VerifyAnchor(ctx, req.(*VerifyAnchorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnchorService_ServiceDesc is the grpc.ServiceDesc for AnchorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnchorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "govspending.v1.AnchorService",
+	HandlerType: (*AnchorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "VerifyAnchor",
+			Handler:    _AnchorService_VerifyAnchor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/govspending/v1/spending.proto",
+}
+
+const (
+	EventService_StreamEvents_FullMethodName = "/govspending.v1.EventService/StreamEvents"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventServiceClient interface {
+	// StreamEvents streams the chaincode events of committed transactions on a
+	// channel until the client cancels. The stream ends with UNAVAILABLE when
+	// the peer connection is lost or replaced; resume from the block after the
+	// last event received.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (EventService_StreamEventsClient, error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (EventService_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventService_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type eventServiceStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility
+type EventServiceServer interface {
+	// StreamEvents streams the chaincode events of committed transactions on a
+	// channel until the client cancels. The stream ends with UNAVAILABLE when
+	// the peer connection is lost or replaced; resume from the block after the
+	// last event received.
+	StreamEvents(*StreamEventsRequest, EventService_StreamEventsServer) error
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEventServiceServer struct {
+}
+
+func (UnimplementedEventServiceServer) StreamEvents(*StreamEventsRequest, EventService_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).StreamEvents(m, &eventServiceStreamEventsServer{stream})
+}
+
+type EventService_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type eventServiceStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "govspending.v1.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _EventService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/govspending/v1/spending.proto",
+}
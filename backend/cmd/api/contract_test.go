@@ -0,0 +1,484 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/response"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+// The contract tests check that docs/swagger.json is what swag generates from
+// the current annotations, that it documents exactly the routes the router
+// serves, and that the responses handlers write match the documented schemas.
+// Run `swag init -g cmd/api/main.go -o docs` from backend/ when they fail.
+
+const swaggerFile = "../../docs/swagger.json"
+
+func TestSwaggerIsUpToDate(t *testing.T) {
+	out := t.TempDir()
+	// swag resolves packages relative to the working directory, as when run
+	// from backend/.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	err = gen.New().Build(&gen.Config{
+		SearchDir:          "./",
+		MainAPIFile:        "cmd/api/main.go",
+		OverridesFile:      gen.DefaultOverridesFile,
+		PropNamingStrategy: swag.CamelCase,
+		OutputDir:          out,
+		OutputTypes:        []string{"json"},
+		ParseDepth:         100,
+		ParseGoList:        true,
+		LeftTemplateDelim:  "{{",
+		RightTemplateDelim: "}}",
+		CollectionFormat:   "csv",
+		Debugger:           testDebugger{t},
+	})
+	if err != nil {
+		t.Fatalf("generate swagger: %v", err)
+	}
+
+	generated := readJSON(t, filepath.Join(out, "swagger.json"))
+	committed := readJSON(t, "docs/swagger.json")
+	if !reflect.DeepEqual(generated, committed) {
+		t.Fatalf("docs/swagger.json is out of date with the handler annotations:\n%s\nregenerate it with swag init -g cmd/api/main.go -o docs",
+			describeDiff("", committed, generated))
+	}
+}
+
+func TestRoutesMatchSwagger(t *testing.T) {
+	spec := loadSpec(t)
+	router := newTestRouter(t)
+
+	served := make(map[string]string)
+	for _, route := range router.Routes() {
+		served[route.Method+" "+route.Path] = route.Handler
+	}
+
+	documented := make(map[string]bool)
+	for path, operations := range spec.Paths {
+		if !strings.HasPrefix(path, response.V1Prefix+"/") {
+			continue
+		}
+		for method := range operations {
+			documented[strings.ToUpper(method)+" "+ginPath(path)] = true
+		}
+	}
+
+	for key, handler := range served {
+		method, path, _ := strings.Cut(key, " ")
+		rest, ok := strings.CutPrefix(path, response.V1Prefix+"/")
+		if !ok {
+			continue
+		}
+		if !documented[key] {
+			t.Errorf("%s is served but not documented in swagger.json", key)
+		}
+		legacy := method + " " + response.LegacyPrefix + "/" + rest
+		if served[legacy] != handler {
+			t.Errorf("%s has no legacy alias %s", key, legacy)
+		}
+	}
+	for key := range documented {
+		if _, ok := served[key]; !ok {
+			t.Errorf("%s is documented in swagger.json but not served", key)
+		}
+	}
+}
+
+func TestV1OperationsUseEnvelope(t *testing.T) {
+	spec := loadSpec(t)
+
+	for path, operations := range spec.Paths {
+		if !strings.HasPrefix(path, response.V1Prefix+"/") {
+			continue
+		}
+		for method, op := range operations {
+			for status, resp := range op.Responses {
+				name := fmt.Sprintf("%s %s %s", strings.ToUpper(method), path, status)
+				switch {
+				case resp.Schema == nil:
+					t.Errorf("%s: no response schema", name)
+				case resp.Schema.Type == "file":
+				case status >= "400":
+					if resp.Schema.Ref != "#/definitions/models.ErrorEnvelope" {
+						t.Errorf("%s: error response is not models.ErrorEnvelope", name)
+					}
+				case len(resp.Schema.AllOf) == 0 || resp.Schema.AllOf[0].Ref != "#/definitions/models.Envelope":
+					t.Errorf("%s: response is not a models.Envelope", name)
+				}
+			}
+		}
+	}
+}
+
+func TestResponsesMatchSwagger(t *testing.T) {
+	spec := loadSpec(t)
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		operation string
+		status    int
+		code      string
+	}{
+		{
+			name:      "invalid channel",
+			method:    http.MethodGet,
+			path:      "/api/v1/nowhere/documents/doc-1",
+			operation: "/api/v1/{channel}/documents/{docId}",
+			status:    http.StatusBadRequest,
+			code:      "INVALID_CHANNEL",
+		},
+		{
+			name:      "read-only channel",
+			method:    http.MethodPost,
+			path:      "/api/v1/state/documents",
+			body:      `{"documentTypeId":"PAYMENT","title":"Payment"}`,
+			operation: "/api/v1/{channel}/documents",
+			status:    http.StatusForbidden,
+			code:      "WRITE_ACCESS_DENIED",
+		},
+		{
+			name:      "invalid body",
+			method:    http.MethodPost,
+			path:      "/api/v1/union/document-types",
+			body:      `{"name":`,
+			operation: "/api/v1/{channel}/document-types",
+			status:    http.StatusBadRequest,
+			code:      "VALIDATION_FAILED",
+		},
+		{
+			name:      "invalid transfer channel",
+			method:    http.MethodPost,
+			path:      "/api/v1/transfers/initiate",
+			body:      `{"fromChannel":"union","toChannel":"nowhere","toOrg":"StateMSP","documentTypeId":"TRANSFER","title":"Transfer","amount":10}`,
+			operation: "/api/v1/transfers/initiate",
+			status:    http.StatusBadRequest,
+			code:      "INVALID_CHANNEL",
+		},
+		{
+			name:      "list imports",
+			method:    http.MethodGet,
+			path:      "/api/v1/union/imports",
+			operation: "/api/v1/{channel}/imports",
+			status:    http.StatusOK,
+		},
+		{
+			name:      "unknown import",
+			method:    http.MethodGet,
+			path:      "/api/v1/union/imports/missing",
+			operation: "/api/v1/{channel}/imports/{jobId}",
+			status:    http.StatusNotFound,
+			code:      "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, tt.method, tt.path, tt.body)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+
+			op, ok := spec.Paths[tt.operation][strings.ToLower(tt.method)]
+			if !ok {
+				t.Fatalf("%s %s is not documented", tt.method, tt.operation)
+			}
+			resp, ok := op.Responses[fmt.Sprint(rec.Code)]
+			if !ok {
+				t.Fatalf("status %d of %s %s is not documented", rec.Code, tt.method, tt.operation)
+			}
+
+			var body interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			for _, problem := range spec.validate(resp.Schema, body, "$") {
+				t.Error(problem)
+			}
+
+			meta, _ := body.(map[string]interface{})["meta"].(map[string]interface{})
+			if meta["requestId"] != rec.Header().Get("X-Request-ID") {
+				t.Errorf("meta.requestId = %v, want the X-Request-ID header %q", meta["requestId"], rec.Header().Get("X-Request-ID"))
+			}
+			if tt.code != "" {
+				apiErr, _ := body.(map[string]interface{})["error"].(map[string]interface{})
+				if apiErr["code"] != tt.code {
+					t.Errorf("error.code = %v, want %s", apiErr["code"], tt.code)
+				}
+			}
+			if rec.Header().Get("Deprecation") != "" {
+				t.Error("v1 response carries a Deprecation header")
+			}
+		})
+	}
+}
+
+func TestLegacyRoutesKeepShapesAndAreDeprecated(t *testing.T) {
+	router := newTestRouter(t)
+
+	rec := serve(router, http.MethodGet, "/api/union/imports", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var list map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := list["imports"]; !ok || list["total"] != float64(0) {
+		t.Errorf("legacy body = %s, want {imports, total}", rec.Body)
+	}
+
+	if got, want := rec.Header().Get("Deprecation"), fmt.Sprintf("@%d", legacyDeprecatedAt.Unix()); got != want {
+		t.Errorf("Deprecation = %q, want %q", got, want)
+	}
+	if got, want := rec.Header().Get("Sunset"), legacySunset.Format(http.TimeFormat); got != want {
+		t.Errorf("Sunset = %q, want %q", got, want)
+	}
+	if got, want := rec.Header().Get("Link"), `</api/v1/union/imports>; rel="successor-version"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+
+	rec = serve(router, http.MethodGet, "/api/nowhere/imports", "")
+	var errBody map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &errBody); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusBadRequest || errBody["success"] != false || errBody["code"] != "INVALID_CHANNEL" {
+		t.Errorf("legacy error = %d %s, want 400 {success: false, code: INVALID_CHANNEL}", rec.Code, rec.Body)
+	}
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	return newConfiguredRouter(t, func(*config.Config) {})
+}
+
+// newConfiguredRouter is newTestRouter with the configuration adjusted by
+// configure first.
+func newConfiguredRouter(t *testing.T, configure func(*config.Config)) *gin.Engine {
+	t.Helper()
+	readOnly := false
+	cfg := &config.Config{
+		Server: config.ServerConfig{Mode: gin.TestMode},
+		Fabric: config.FabricConfig{
+			NetworkPath:   t.TempDir(),
+			ChaincodeName: "spending",
+			Channels: map[string]config.ChannelConfig{
+				"union": {Name: "union-channel", MspID: "UnionMSP", PeerEndpoint: "localhost:7051"},
+				"state": {Name: "state-channel", MspID: "StateMSP", PeerEndpoint: "localhost:9051", Writable: &readOnly},
+			},
+		},
+	}
+	configure(cfg)
+
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	fabricService := services.NewFabricService(gateway)
+	handler := handlers.NewHandler(fabricService, services.NewImportService(fabricService),
+		services.NewExportService(fabricService, gateway), cfg)
+	return setupRouter(cfg, handler, nil, middleware.NewMemoryRateLimitStore())
+}
+
+func serve(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+func ginPath(swaggerPath string) string {
+	return pathParam.ReplaceAllString(swaggerPath, ":$1")
+}
+
+func readJSON(t *testing.T, path string) interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return v
+}
+
+// describeDiff lists the JSON paths at which two documents differ.
+func describeDiff(path string, want, got interface{}) string {
+	wantMap, wantOK := want.(map[string]interface{})
+	gotMap, gotOK := got.(map[string]interface{})
+	if !wantOK || !gotOK {
+		if reflect.DeepEqual(want, got) {
+			return ""
+		}
+		return fmt.Sprintf("  %s differs\n", path)
+	}
+
+	keys := make(map[string]bool)
+	for k := range wantMap {
+		keys[k] = true
+	}
+	for k := range gotMap {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	for _, k := range sorted {
+		b.WriteString(describeDiff(path+"/"+k, wantMap[k], gotMap[k]))
+	}
+	return b.String()
+}
+
+type testDebugger struct{ t *testing.T }
+
+func (d testDebugger) Printf(format string, args ...interface{}) { d.t.Logf(format, args...) }
+
+// =============================================================================
+// Swagger schema validation
+// =============================================================================
+
+type swaggerSpec struct {
+	Paths       map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions map[string]*schema                     `json:"definitions"`
+}
+
+type swaggerOperation struct {
+	Responses map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"responses"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Items                *schema            `json:"items"`
+	AllOf                []*schema          `json:"allOf"`
+	AdditionalProperties interface{}        `json:"additionalProperties"`
+	Enum                 []interface{}      `json:"enum"`
+}
+
+func loadSpec(t *testing.T) *swaggerSpec {
+	t.Helper()
+	data, err := os.ReadFile(swaggerFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec swaggerSpec
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&spec); err != nil {
+		t.Fatal(err)
+	}
+	return &spec
+}
+
+// resolve follows $ref and merges allOf, later parts overriding properties of
+// earlier ones, as swag does for models.Envelope{data=...}.
+func (s *swaggerSpec) resolve(sch *schema) *schema {
+	if sch.Ref != "" {
+		return s.resolve(s.Definitions[strings.TrimPrefix(sch.Ref, "#/definitions/")])
+	}
+	if len(sch.AllOf) == 0 {
+		return sch
+	}
+	merged := &schema{Type: "object", Properties: make(map[string]*schema)}
+	for _, part := range sch.AllOf {
+		for name, prop := range s.resolve(part).Properties {
+			merged.Properties[name] = prop
+		}
+	}
+	return merged
+}
+
+// validate checks value against the subset of JSON Schema swag emits. Objects
+// may not have properties the schema does not document.
+func (s *swaggerSpec) validate(sch *schema, value interface{}, path string) []string {
+	sch = s.resolve(sch)
+	if value == nil {
+		return nil
+	}
+
+	var problems []string
+	switch sch.Type {
+	case "object", "":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			if sch.Type == "" {
+				return nil
+			}
+			return []string{fmt.Sprintf("%s: got %T, want object", path, value)}
+		}
+		if sch.Properties == nil {
+			return nil
+		}
+		for name, v := range obj {
+			prop, ok := sch.Properties[name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is not documented", path, name))
+				continue
+			}
+			problems = append(problems, s.validate(prop, v, path+"."+name)...)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want array", path, value)}
+		}
+		for i, item := range items {
+			problems = append(problems, s.validate(sch.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: got %T, want string", path, value))
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("%s: got %T, want %s", path, value, sch.Type))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s: got %T, want boolean", path, value))
+		}
+	}
+	return problems
+}
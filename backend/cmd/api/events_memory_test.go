@@ -0,0 +1,84 @@
+//go:build !live
+
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/client"
+	"github.com/gov-spending/backend/pkg/fabric"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+// TestRequestIDReachesTheLedger submits a transaction with a request ID and
+// follows the ID into the Fabric layer's logs and the chaincode's event.
+func TestRequestIDReachesTheLedger(t *testing.T) {
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatalf("start network: %v", err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatalf("start chaincode: %v", err)
+	}
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Mode: gin.TestMode},
+		Fabric: network.Config("union"),
+	}
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	fabricService := services.NewFabricService(gateway)
+	handler := handlers.NewHandler(fabricService, services.NewImportService(fabricService), services.NewExportService(fabricService, gateway), cfg)
+	server := httptest.NewServer(setupRouter(cfg, handler, nil, middleware.NewMemoryRateLimitStore()))
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL)
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	logs := captureLogs(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := uint64(0)
+	events := make(chan *models.ChaincodeEvent, 16)
+	go fabricService.StreamEvents(ctx, "union", &start, nil, func(event *models.ChaincodeEvent) error {
+		events <- event
+		return nil
+	})
+
+	_, err = c.RegisterDocumentType(client.WithRequestID(ctx, "audit-batch-42"), "union", &client.CreateDocumentTypeRequest{
+		ID: "federal-expense", Name: "Federal Expense",
+		Description:    "Direct federal spending",
+		RequiredFields: []string{"category"},
+		OptionalFields: []string{"vendor"},
+	})
+	if err != nil {
+		t.Fatalf("register type: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.ID != "federal-expense" || event.RequestID != "audit-batch-42" {
+			t.Errorf("event = %+v, want federal-expense with requestId audit-batch-42", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("no chaincode event")
+	}
+	if line := logs.find("Transaction endorsed"); line == nil || line["request_id"] != "audit-batch-42" {
+		t.Errorf("endorsement log = %v, want the request_id", line)
+	}
+}
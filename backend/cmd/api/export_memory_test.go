@@ -0,0 +1,115 @@
+//go:build !live
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/client"
+	"github.com/gov-spending/backend/pkg/fabric"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+// TestExportOutlivesTheWriteTimeout streams an export of several pages, each
+// fetched from a slow peer, through a server whose WriteTimeout is shorter
+// than the whole stream.
+func TestExportOutlivesTheWriteTimeout(t *testing.T) {
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatalf("start network: %v", err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatalf("start chaincode: %v", err)
+	}
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Mode: gin.TestMode},
+		Fabric: network.Config("union"),
+	}
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	fabricService := services.NewFabricService(gateway)
+	handler := handlers.NewHandler(fabricService, services.NewImportService(fabricService), services.NewExportService(fabricService, gateway), cfg)
+	server := httptest.NewUnstartedServer(setupRouter(cfg, handler, nil, middleware.NewMemoryRateLimitStore()))
+	server.Config.WriteTimeout = 300 * time.Millisecond
+	server.Start()
+	t.Cleanup(server.Close)
+
+	c, err := client.New(server.URL)
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	ctx := context.Background()
+
+	registerType(ctx, t, c, "union", &client.CreateDocumentTypeRequest{
+		ID: "federal-expense", Name: "Federal Expense",
+		Description:    "Direct federal spending",
+		RequiredFields: []string{"category", "vendor", "contractNumber"},
+		OptionalFields: []string{"invoiceNumber"},
+	})
+
+	// One row more than an export page, so the export takes two.
+	const rows = 201
+	var file strings.Builder
+	file.WriteString("title,amount,category,vendor,contract\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&file, "Item %d,%d.50,supplies,Acme,CT-%03d\n", i, i, i)
+	}
+	job, err := c.StartImport(ctx, "union", "expenses.csv", strings.NewReader(file.String()), &client.ImportOptions{
+		DocumentTypeID: "federal-expense",
+		Mapping: client.ImportMapping{
+			Title:  "title",
+			Amount: "amount",
+			Data:   map[string]string{"category": "category", "vendor": "vendor", "contractNumber": "contract"},
+		},
+		Concurrency: 16,
+	})
+	if err != nil {
+		t.Fatalf("start import: %v", err)
+	}
+	for job.FinishedAt == "" {
+		time.Sleep(50 * time.Millisecond)
+		if job, err = c.GetImport(ctx, "union", job.ID, ""); err != nil {
+			t.Fatalf("get import: %v", err)
+		}
+	}
+	if job.Created != rows {
+		t.Fatalf("import = %s with %d created, want %d created", job.Status, job.Created, rows)
+	}
+
+	// Fetching both pages alone takes longer than the WriteTimeout.
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodEvaluate, Function: "QueryDocuments", Delay: 200 * time.Millisecond})
+
+	export, err := c.ExportDocuments(ctx, "union", client.ExportFormatNDJSON, &client.QueryFilter{DocumentTypeID: "federal-expense"})
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	defer export.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(export)
+	for scanner.Scan() {
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("export stream after %d documents: %v", lines, err)
+	}
+	if lines != rows || export.SHA256() == "" {
+		t.Errorf("export = %d documents with digest %q, want %d and a digest", lines, export.SHA256(), rows)
+	}
+}
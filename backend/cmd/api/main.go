@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"flag"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,9 +20,14 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/graphqlapi"
+	"github.com/gov-spending/backend/internal/grpcapi"
 	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/metrics"
 	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/response"
 	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/internal/tracing"
 	"github.com/gov-spending/backend/pkg/fabric"
 )
 
@@ -29,6 +35,9 @@ import (
 // @version         1.0
 // @description     API for managing government spending documents across Federal, State, and Municipal channels
 // @description     Uses Hyperledger Fabric blockchain for immutable, transparent spending records
+// @description
+// @description     Responses under /api/v1 are wrapped in an envelope: {"data": ..., "meta": {"requestId", "pagination"}} on success and {"error": {"code", "message", "details", "retriable", "context"}, "meta": {...}} on failure.
+// @description     Every route is also served without the version, under /api, with the unwrapped responses of earlier releases. Those routes are deprecated: their responses carry Deprecation, Sunset and Link (rel="successor-version") headers.
 
 // @BasePath  /
 
@@ -47,8 +56,24 @@ import (
 // @tag.name Verification
 // @tag.description Verify cross-channel links
 
+// @tag.name Imports
+// @tag.description Bulk document import from CSV and NDJSON
+
+// @tag.name Exports
+// @tag.description Open-data export of ledger documents with signed manifests
+
+// @tag.name Transactions
+// @tag.description Commit status of submitted transactions
+
+// The unversioned /api routes are deprecated in favour of /api/v1 and will be
+// removed at the sunset date.
+var (
+	legacyDeprecatedAt = time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC)
+)
+
 func main() {
-	configPath := flag.String("config", "", "Path to configuration file")
+	configPath := flag.String("config", "config.yaml", "Path to configuration file; reloaded on change and on SIGHUP")
 	flag.Parse()
 
 	cfg, err := config.Load(*configPath)
@@ -58,6 +83,11 @@ func main() {
 
 	setupLogging(cfg.Logging)
 
+	shutdownTracing, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to set up tracing")
+	}
+
 	log.Info().Msg("Starting Government Spending Blockchain API")
 	log.Info().Str("networkPath", cfg.Fabric.NetworkPath).Msg("Fabric network path")
 
@@ -66,7 +96,10 @@ func main() {
 
 	fabricService := services.NewFabricService(gatewayManager)
 
-	handler := handlers.NewHandler(fabricService, cfg)
+	importService := services.NewImportService(fabricService)
+	exportService := services.NewExportService(fabricService, gatewayManager)
+
+	handler := handlers.NewHandler(fabricService, importService, exportService, cfg)
 
 	if swaggerHost := os.Getenv("SWAGGER_HOST"); swaggerHost != "" {
 		docs.SwaggerInfo.Host = swaggerHost
@@ -74,7 +107,34 @@ func main() {
 		docs.SwaggerInfo.Host = ""
 	}
 
-	router := setupRouter(cfg, handler)
+	var graphqlHandler *graphqlapi.Handler
+	if cfg.Server.GraphQL.Enabled {
+		if graphqlHandler, err = graphqlapi.NewHandler(fabricService, cfg); err != nil {
+			log.Fatal().Err(err).Msg("Failed to build the GraphQL schema")
+		}
+	}
+
+	// Both APIs charge the same rate-limit buckets.
+	rateLimits := middleware.NewMemoryRateLimitStore()
+	router := setupRouter(cfg, handler, graphqlHandler, rateLimits)
+
+	// The gRPC API shares the service layer on its own port.
+	var grpcServer *grpcapi.Server
+	if cfg.Server.GRPC.Port != "" {
+		grpcServer = grpcapi.NewServer(fabricService, cfg, rateLimits)
+	}
+
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	configReloader := &reloader{
+		path:    *configPath,
+		gateway: gatewayManager,
+		handler: handler,
+		grpc:    grpcServer,
+		graphql: graphqlHandler,
+		current: cfg,
+	}
+	go configReloader.watch(watchCtx)
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -91,11 +151,25 @@ func main() {
 		}
 	}()
 
+	if grpcServer != nil {
+		lis, err := net.Listen("tcp", ":"+cfg.Server.GRPC.Port)
+		if err != nil {
+			log.Fatal().Err(err).Str("port", cfg.Server.GRPC.Port).Msg("gRPC server failed to start")
+		}
+		go func() {
+			log.Info().Str("port", cfg.Server.GRPC.Port).Msg("gRPC server starting")
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatal().Err(err).Msg("gRPC server failed")
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopWatching()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -103,6 +177,13 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Error().Err(err).Msg("Server forced to shutdown")
 	}
+	if grpcServer != nil {
+		grpcServer.Shutdown(ctx)
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to flush traces")
+	}
 
 	log.Info().Msg("Server stopped")
 }
@@ -126,55 +207,105 @@ func setupLogging(cfg config.LoggingConfig) {
 	}
 }
 
-func setupRouter(cfg *config.Config, h *handlers.Handler) *gin.Engine {
+// setupRouter builds the HTTP router, rate limited against the buckets in
+// rateLimits. gql is nil when the GraphQL endpoint is disabled.
+func setupRouter(cfg *config.Config, h *handlers.Handler, gql *graphqlapi.Handler, rateLimits middleware.RateLimitStore) *gin.Engine {
 	gin.SetMode(cfg.Server.Mode)
 
 	router := gin.New()
+	// Forwarding headers are only believed from the configured proxies; the
+	// client IP keys rate limits.
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Msg("Invalid server.trusted_proxies")
+	}
 
-	router.Use(middleware.RequestID())
+	// Recovery comes first so that a panic anywhere in the chain is answered;
+	// it reads the request ID set by the middlewares after it.
 	router.Use(middleware.Recovery())
+	router.Use(middleware.Tracing(tracing.ServiceName(cfg.Tracing)))
+	router.Use(middleware.RequestID())
+	router.Use(middleware.FabricAttempts())
 	router.Use(middleware.Logger())
-	router.Use(middleware.CORS())
+	router.Use(middleware.Metrics())
+	router.Use(middleware.CORS(cfg.Server.CORS))
+	router.Use(middleware.Idempotency(middleware.NewMemoryIdempotencyStore(24*time.Hour), handlers.MaxRequestBodySize))
 
 	router.GET("/health", h.HealthCheck)
+	router.GET("/health/live", h.Liveness)
+	router.GET("/health/ready", h.Readiness)
 	router.GET("/config", h.ConfigInfo)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	api := router.Group("/api")
-	{
+	// Both route sets charge the same buckets.
+	rateLimit := middleware.RateLimit(cfg.Server.RateLimit, rateLimits, h.IsValidChannel)
+
+	v1 := router.Group(response.V1Prefix)
+	v1.Use(rateLimit)
+	registerAPIRoutes(v1, h)
 
-		api.POST("/transfers/initiate", h.InitiateTransfer)
+	legacy := router.Group(response.LegacyPrefix)
+	legacy.Use(middleware.Deprecation(response.LegacyPrefix, response.V1Prefix, legacyDeprecatedAt, legacySunset))
+	legacy.Use(rateLimit)
+	registerAPIRoutes(legacy, h)
+
+	if gql != nil {
+		graphqlRoutes := router.Group("/graphql", rateLimit)
+		graphqlRoutes.GET("", gql.Serve)
+		graphqlRoutes.POST("", gql.Serve)
+	}
+
+	return router
+}
 
-		api.POST("/anchors/verify", h.VerifyAnchor)
+// registerAPIRoutes adds the API routes to api, which is either /api/v1 or the
+// deprecated /api.
+func registerAPIRoutes(api *gin.RouterGroup, h *handlers.Handler) {
+	api.POST("/transfers/initiate", h.InitiateTransfer)
 
-		channel := api.Group("/:channel")
+	api.POST("/anchors/verify", h.VerifyAnchor)
+
+	channel := api.Group("/:channel")
+	{
+
+		docTypes := channel.Group("/document-types")
 		{
+			docTypes.POST("", h.RegisterDocumentType)
+			docTypes.GET("", h.ListDocumentTypes)
+			docTypes.GET("/:typeId", h.GetDocumentType)
+			docTypes.GET("/:typeId/indexes", h.GetDocumentTypeIndexes)
+			docTypes.DELETE("/:typeId", h.DeactivateDocumentType)
+		}
 
-			docTypes := channel.Group("/document-types")
-			{
-				docTypes.POST("", h.RegisterDocumentType)
-				docTypes.GET("", h.ListDocumentTypes)
-				docTypes.GET("/:typeId", h.GetDocumentType)
-				docTypes.DELETE("/:typeId", h.DeactivateDocumentType)
-			}
+		docs := channel.Group("/documents")
+		{
+			docs.POST("", h.CreateDocument)
+			docs.GET("", h.QueryDocuments)
+			docs.GET("/:docId", h.GetDocument)
+			docs.GET("/:docId/history", h.GetDocumentHistory)
+			docs.GET("/:docId/linked", h.GetLinkedDocuments)
+			docs.POST("/:docId/invalidate", h.InvalidateDocument)
+		}
 
-			docs := channel.Group("/documents")
-			{
-				docs.POST("", h.CreateDocument)
-				docs.GET("", h.QueryDocuments)
-				docs.GET("/:docId", h.GetDocument)
-				docs.GET("/:docId/history", h.GetDocumentHistory)
-				docs.GET("/:docId/linked", h.GetLinkedDocuments)
-				docs.POST("/:docId/invalidate", h.InvalidateDocument)
-			}
+		transfers := channel.Group("/transfers")
+		{
+			transfers.POST("/acknowledge", h.AcknowledgeTransfer)
+		}
 
-			transfers := channel.Group("/transfers")
-			{
-				transfers.POST("/acknowledge", h.AcknowledgeTransfer)
-			}
+		imports := channel.Group("/imports")
+		{
+			imports.POST("", h.StartImport)
+			imports.GET("", h.ListImports)
+			imports.GET("/:jobId", h.GetImport)
 		}
-	}
 
-	return router
+		exports := channel.Group("/exports")
+		{
+			exports.GET("/documents", h.ExportDocuments)
+			exports.GET("/:exportId/manifest", h.GetExportManifest)
+		}
+
+		channel.GET("/transactions/:txId", h.GetTransactionStatus)
+	}
 }
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/graphqlapi"
+	"github.com/gov-spending/backend/internal/grpcapi"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+// reloadDebounce coalesces the burst of events a single save produces.
+const reloadDebounce = 500 * time.Millisecond
+
+// restartOnlyPrefixes are settings read once at startup. Changes to them are
+// logged but take effect on the next restart.
+var restartOnlyPrefixes = []string{"server.", "logging.", "tracing."}
+
+// reloader applies configuration file changes to the running server.
+type reloader struct {
+	path    string
+	gateway *fabric.GatewayManager
+	handler *handlers.Handler
+	grpc    *grpcapi.Server     // nil when the gRPC API is disabled
+	graphql *graphqlapi.Handler // nil when the GraphQL endpoint is disabled
+
+	mu      sync.Mutex
+	current *config.Config
+}
+
+// reload loads and validates the config file and, if it is valid, logs what
+// changed and applies it. An invalid file is rejected and the configuration
+// in effect is kept.
+func (r *reloader) reload(trigger string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logger := log.With().Str("trigger", trigger).Str("path", r.path).Logger()
+
+	cfg, err := config.Load(r.path)
+	if err != nil {
+		logger.Error().Err(err).Msg("Configuration reload rejected, keeping the current configuration")
+		return
+	}
+
+	changes := config.Diff(r.current, cfg)
+	if len(changes) == 0 {
+		logger.Debug().Msg("Configuration unchanged")
+		return
+	}
+	for _, change := range changes {
+		event := logger.Info()
+		if restartRequired(change.Key) {
+			event = logger.Warn().Bool("restartRequired", true)
+		}
+		event.Str("change", change.String()).Msg("Configuration changed")
+	}
+
+	// The gateway first, so the APIs never accept a channel the gateway does
+	// not know yet.
+	replaced := r.gateway.Reload(cfg)
+	r.handler.Reload(cfg)
+	if r.grpc != nil {
+		r.grpc.Reload(cfg)
+	}
+	if r.graphql != nil {
+		r.graphql.Reload(cfg)
+	}
+	r.current = cfg
+
+	logger.Info().
+		Int("changes", len(changes)).
+		Strs("reconnecting", replaced).
+		Strs("channels", cfg.ValidChannels()).
+		Msg("Configuration reloaded")
+}
+
+func restartRequired(key string) bool {
+	for _, prefix := range restartOnlyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watch reloads the configuration on SIGHUP and when the config file changes,
+// until ctx is done. The file's directory is watched rather than the file, so
+// saves that replace the file and Kubernetes ConfigMap updates, which swap
+// the ..data symlink, are seen too.
+func (r *reloader) watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if watcher, err := newDirWatcher(filepath.Dir(r.path)); err != nil {
+		log.Warn().Err(err).Str("path", r.path).Msg("Cannot watch configuration file, reload with SIGHUP")
+	} else {
+		defer watcher.Close()
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	name := filepath.Clean(r.path)
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload("SIGHUP")
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if filepath.Clean(event.Name) == name || filepath.Base(event.Name) == "..data" {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			log.Warn().Err(err).Msg("Configuration watcher error")
+		case <-debounce:
+			debounce = nil
+			r.reload("file change")
+		}
+	}
+}
+
+func newDirWatcher(dir string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/response"
+)
+
+func TestRateLimitIgnoresForgedForwardingHeaders(t *testing.T) {
+	router := newConfiguredRouter(t, func(cfg *config.Config) {
+		cfg.Server.RateLimit = config.RateLimitConfig{
+			Enabled: true,
+			Read:    config.RateLimitRule{Rate: 0.001, Burst: 2},
+		}
+	})
+
+	// Each request claims another client; none comes from a trusted proxy.
+	forged := []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"}
+	codes := make([]int, len(forged))
+	for i, ip := range forged {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/union/documents/missing", nil)
+		req.RemoteAddr = "192.0.2.10:40000"
+		req.Header.Set("X-Forwarded-For", ip)
+		req.Header.Set("X-Real-IP", ip)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	if codes[0] == http.StatusTooManyRequests || codes[1] == http.StatusTooManyRequests || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("responses = %v, want the third request limited", codes)
+	}
+}
+
+func TestRateLimitTrustsConfiguredProxies(t *testing.T) {
+	router := newConfiguredRouter(t, func(cfg *config.Config) {
+		cfg.Server.TrustedProxies = []string{"192.0.2.0/24"}
+		cfg.Server.RateLimit = config.RateLimitConfig{
+			Enabled: true,
+			Read:    config.RateLimitRule{Rate: 0.001, Burst: 1},
+		}
+	})
+
+	for _, ip := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/union/documents/missing", nil)
+		req.RemoteAddr = "192.0.2.10:40000"
+		req.Header.Set("X-Forwarded-For", ip)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code == http.StatusTooManyRequests {
+			t.Errorf("request for %s behind the proxy = 429, want its own bucket", ip)
+		}
+	}
+}
+
+// logBuffer collects the lines written by the global logger.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// find returns the fields of the first line logged with message.
+func (b *logBuffer) find(message string) map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var fields map[string]interface{}
+		if json.Unmarshal(scanner.Bytes(), &fields) == nil && fields["message"] == message {
+			return fields
+		}
+	}
+	return nil
+}
+
+// captureLogs sends the global logger's output to a buffer for the rest of
+// the test.
+func captureLogs(t *testing.T) *logBuffer {
+	t.Helper()
+	logs := &logBuffer{}
+	saved := log.Logger
+	log.Logger = zerolog.New(logs)
+	t.Cleanup(func() { log.Logger = saved })
+	return logs
+}
+
+func TestRequestIDKeepsWellFormedIDs(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, id := range []string{"audit-batch-42", "0190b1e2-7c3a-7def-8a12-3456789abcde", "lb:01HZX3.req_9"} {
+		logs := captureLogs(t)
+		req := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+		req.Header.Set("X-Request-ID", id)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("X-Request-ID"); got != id {
+			t.Errorf("X-Request-ID for %q = %q, want it kept", id, got)
+		}
+		if line := logs.find("HTTP Request"); line == nil || line["request_id"] != id {
+			t.Errorf("request log for %q = %v, want its request_id", id, line)
+		}
+	}
+}
+
+func TestRequestIDReplacesMalformedIDs(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, id := range []string{"", "bad id", "id\r\nX-Injected: 1", "<script>", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+		req.Header.Set("X-Request-ID", id)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		got := rec.Header().Get("X-Request-ID")
+		if parsed, err := uuid.Parse(got); err != nil || parsed.Version() != 7 {
+			t.Errorf("X-Request-ID for %q = %q, want a UUIDv7", id, got)
+		}
+	}
+}
+
+func TestPanicsAreAnsweredWithTheRequestID(t *testing.T) {
+	router := newTestRouter(t)
+	router.GET(response.V1Prefix+"/panic", func(*gin.Context) { panic("boom") })
+	logs := captureLogs(t)
+
+	req := httptest.NewRequest(http.MethodGet, response.V1Prefix+"/panic", nil)
+	req.Header.Set("X-Request-ID", "audit-7")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body models.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	if rec.Code != http.StatusInternalServerError || body.Error.Code != "INTERNAL_ERROR" {
+		t.Errorf("response = %d %+v, want 500 INTERNAL_ERROR", rec.Code, body.Error)
+	}
+	if rec.Header().Get("X-Request-ID") != "audit-7" || body.Meta.RequestID != "audit-7" {
+		t.Errorf("request ID = %q in the header and %q in the body, want audit-7", rec.Header().Get("X-Request-ID"), body.Meta.RequestID)
+	}
+	if line := logs.find("Panic recovered"); line == nil || line["request_id"] != "audit-7" {
+		t.Errorf("panic log = %v, want the request_id", line)
+	}
+}
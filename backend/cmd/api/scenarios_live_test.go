@@ -0,0 +1,40 @@
+//go:build live
+
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// newScenarioEnv connects to the three running backend instances, by default
+// those docker-compose starts, and waits for none of them: each must already
+// be ready.
+func newScenarioEnv(t *testing.T) *scenarioEnv {
+	t.Helper()
+	instance := func(env, fallback string) *client.Client {
+		url := os.Getenv(env)
+		if url == "" {
+			url = fallback
+		}
+		c, err := client.New(url, client.WithRetry(client.DefaultRetryPolicy), client.WithUserAgent("scenarios"))
+		if err != nil {
+			t.Fatalf("%s: %v", env, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if ready, err := c.Readiness(ctx); err != nil || ready.Status != "ready" {
+			t.Fatalf("backend at %s is not ready (%+v, %v); set %s to its URL", url, ready, err, env)
+		}
+		return c
+	}
+	return &scenarioEnv{
+		union:  instance("GOV_E2E_UNION_URL", "http://localhost:3000"),
+		state:  instance("GOV_E2E_STATE_URL", "http://localhost:3001"),
+		region: instance("GOV_E2E_REGION_URL", "http://localhost:3002"),
+	}
+}
@@ -0,0 +1,57 @@
+//go:build !live
+
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/client"
+	"github.com/gov-spending/backend/pkg/fabric"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+// newScenarioEnv starts the in-memory network and, in front of it, one
+// backend per organization, each with the organization's Admin identity on
+// its own channel.
+func newScenarioEnv(t *testing.T) *scenarioEnv {
+	t.Helper()
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+		fabrictest.Channel{Key: "state", Name: "state-channel", MspID: "StateMSP", Domain: "state.gov.br"},
+		fabrictest.Channel{Key: "region", Name: "region-channel", MspID: "RegionMSP", Domain: "region.gov.br"},
+	)
+	if err != nil {
+		t.Fatalf("start network: %v", err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatalf("start chaincode: %v", err)
+	}
+
+	backend := func(adminChannel string) *client.Client {
+		cfg := &config.Config{
+			Server: config.ServerConfig{Mode: gin.TestMode},
+			Fabric: network.Config(adminChannel),
+		}
+		gateway := fabric.NewGatewayManager(cfg)
+		t.Cleanup(gateway.Close)
+		fabricService := services.NewFabricService(gateway)
+		handler := handlers.NewHandler(fabricService, services.NewImportService(fabricService), services.NewExportService(fabricService, gateway), cfg)
+		server := httptest.NewServer(setupRouter(cfg, handler, nil, middleware.NewMemoryRateLimitStore()))
+		t.Cleanup(server.Close)
+
+		c, err := client.New(server.URL)
+		if err != nil {
+			t.Fatalf("client: %v", err)
+		}
+		return c
+	}
+	return &scenarioEnv{union: backend("union"), state: backend("state"), region: backend("region")}
+}
@@ -0,0 +1,642 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// scenarioEnv is the three backend instances of test-scenarios.sh. Each one
+// administers its own channel and reads the other two.
+type scenarioEnv struct {
+	union, state, region *client.Client
+}
+
+// orgs are the MSP IDs of the channels' organizations.
+var orgs = map[string]string{"union": "UnionMSP", "state": "StateMSP", "region": "RegionMSP"}
+
+// TestScenarios is gov-ledger/scripts/test-scenarios.sh with assertions: the
+// document types, the four transfer directions and their anchors,
+// invalidation with a correction, internal spending and queries. It runs
+// against the in-memory network of pkg/fabric/fabrictest, or against running
+// instances when built with -tags live. A scenario stops the run when it
+// fails, since the later ones build on its documents.
+func TestScenarios(t *testing.T) {
+	env := newScenarioEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	today := time.Now().Format("2006-01-02")
+
+	var federalToState, regionToState transfer
+	var wrongDoc, contractorDoc string
+
+	scenarios := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"check", func(t *testing.T) {
+			for channel, c := range env.instances() {
+				ready, err := c.Readiness(ctx)
+				if err != nil || ready.Status != "ready" {
+					t.Fatalf("%s readiness = %+v, %v", channel, ready, err)
+				}
+				info, err := c.Config(ctx)
+				if err != nil {
+					t.Fatalf("%s config: %v", channel, err)
+				}
+				if !slices.Equal(info.WritableChannels, []string{channel}) || len(info.AllChannels) != 3 {
+					t.Errorf("%s config = %+v, want writes to %s only and reads on every channel", channel, info, channel)
+				}
+			}
+
+			// qscc's answer for an unknown transaction is a 404, not a lookup failure.
+			_, err := env.union.GetTransactionStatus(ctx, "union", "0000000000000000000000000000000000000000000000000000000000000000")
+			wantError(t, err, client.ErrCodeNotFound)
+		}},
+
+		{"types", func(t *testing.T) {
+			registerType(ctx, t, env.union, "union", &client.CreateDocumentTypeRequest{
+				ID: "federal-transfer", Name: "Federal Transfer",
+				Description:    "Transfer of funds from federal to state level",
+				RequiredFields: []string{"destinationState", "program", "legalBasis"},
+				OptionalFields: []string{"observations", "attachments"},
+			})
+			registerType(ctx, t, env.union, "union", &client.CreateDocumentTypeRequest{
+				ID: "federal-expense", Name: "Federal Expense",
+				Description:    "Direct federal spending",
+				RequiredFields: []string{"category", "vendor", "contractNumber"},
+				OptionalFields: []string{"invoiceNumber", "deliveryDate"},
+			})
+			registerType(ctx, t, env.state, "state", &client.CreateDocumentTypeRequest{
+				ID: "state-receipt", Name: "State Receipt",
+				Description:    "Receipt of funds from federal level",
+				RequiredFields: []string{"sourceOrg", "program", "receiptDate"},
+				OptionalFields: []string{"observations"},
+			})
+			registerType(ctx, t, env.state, "state", &client.CreateDocumentTypeRequest{
+				ID: "state-transfer", Name: "State Transfer",
+				Description:    "Transfer of funds from state to municipalities",
+				RequiredFields: []string{"destinationMunicipality", "program"},
+				OptionalFields: []string{"observations"},
+			})
+			registerType(ctx, t, env.region, "region", &client.CreateDocumentTypeRequest{
+				ID: "municipal-receipt", Name: "Municipal Receipt",
+				Description:    "Receipt of funds from state level",
+				RequiredFields: []string{"sourceOrg", "program", "receiptDate"},
+				OptionalFields: []string{"observations"},
+			})
+			registerType(ctx, t, env.region, "region", &client.CreateDocumentTypeRequest{
+				ID: "municipal-expense", Name: "Municipal Expense",
+				Description:    "Municipal spending",
+				RequiredFields: []string{"category", "vendor", "purpose"},
+				OptionalFields: []string{"invoiceNumber"},
+			})
+
+			// Async writes point at the status route of the API version they used.
+			submitted, err := env.region.RegisterDocumentTypeAsync(ctx, "region", &client.CreateDocumentTypeRequest{
+				ID: "municipal-notice", Name: "Municipal Notice",
+				RequiredFields: []string{"subject"},
+			})
+			switch {
+			case client.ErrorCodeOf(err) == client.ErrCodeAlreadyExists:
+			case err != nil:
+				t.Fatalf("register municipal-notice: %v", err)
+			default:
+				if want := "/api/v1/region/transactions/" + submitted.TxID; submitted.StatusURL != want {
+					t.Errorf("statusUrl = %q, want %q", submitted.StatusURL, want)
+				}
+				if _, err := env.region.WaitForTransaction(ctx, submitted, 50*time.Millisecond); err != nil {
+					t.Errorf("wait for municipal-notice: %v", err)
+				}
+			}
+
+			// Every instance reads every channel, but only writes its own.
+			types, err := env.region.ListDocumentTypes(ctx, "union")
+			if err != nil || !slices.ContainsFunc(types, func(dt *client.DocumentType) bool { return dt.ID == "federal-transfer" }) {
+				t.Errorf("union types read by the region instance = %v, %v", types, err)
+			}
+			_, err = env.union.RegisterDocumentType(ctx, "state", &client.CreateDocumentTypeRequest{ID: "union-on-state", Name: "Not allowed"})
+			wantError(t, err, client.ErrCodeWriteAccessDenied)
+		}},
+
+		{"federal-state", func(t *testing.T) {
+			federalToState = checkTransfer(ctx, t, env, env.union, env.state, &client.InitiateTransferRequest{
+				FromChannel: "union", ToChannel: "state", ToOrg: "StateMSP",
+				DocumentTypeID: "federal-transfer",
+				Title:          "Transfer to São Paulo - Education Program",
+				Description:    "Annual transfer for state education program",
+				Amount:         10000000, Currency: "BRL",
+				Data: map[string]interface{}{"destinationState": "São Paulo", "program": "FUNDEB", "legalBasis": "Lei 14.113/2020"},
+			}, &client.AcknowledgeTransferRequest{
+				DocumentTypeID: "state-receipt",
+				Title:          "Receipt from Union - Education Program",
+				Description:    "Acknowledgment of FUNDEB transfer receipt",
+				Data:           map[string]interface{}{"sourceOrg": "UnionMSP", "program": "FUNDEB", "receiptDate": today},
+			})
+		}},
+
+		{"state-region", func(t *testing.T) {
+			checkTransfer(ctx, t, env, env.state, env.region, &client.InitiateTransferRequest{
+				FromChannel: "state", ToChannel: "region", ToOrg: "RegionMSP",
+				DocumentTypeID: "state-transfer",
+				Title:          "Transfer to Campinas Region - Health Program",
+				Description:    "Quarterly transfer for regional health services",
+				Amount:         1000000, Currency: "BRL",
+				Data: map[string]interface{}{"destinationMunicipality": "Campinas", "program": "SUS Regional"},
+			}, &client.AcknowledgeTransferRequest{
+				DocumentTypeID: "municipal-receipt",
+				Title:          "Receipt from State - Health Program",
+				Description:    "Acknowledgment of regional health transfer",
+				Data:           map[string]interface{}{"sourceOrg": "StateMSP", "program": "SUS Regional", "receiptDate": today},
+			})
+		}},
+
+		{"region-state", func(t *testing.T) {
+			regionToState = checkTransfer(ctx, t, env, env.region, env.state, &client.InitiateTransferRequest{
+				FromChannel: "region", ToChannel: "state", ToOrg: "StateMSP",
+				DocumentTypeID: "municipal-expense",
+				Title:          "Municipal Health Spending Report - Q1",
+				Description:    "Report of health program expenditures to State",
+				Amount:         250000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"category": "Health Services", "vendor": "Multiple Vendors",
+					"purpose": "Primary care services and equipment", "reportingPeriod": "Q1 2025",
+				},
+			}, &client.AcknowledgeTransferRequest{
+				DocumentTypeID: "state-receipt",
+				Title:          "Municipal Report Received - Health Q1",
+				Description:    "State acknowledgment of municipal health spending report",
+				Data:           map[string]interface{}{"sourceOrg": "RegionMSP", "program": "Health Services", "receiptDate": today},
+			})
+		}},
+
+		{"state-federal", func(t *testing.T) {
+			checkTransfer(ctx, t, env, env.state, env.union, &client.InitiateTransferRequest{
+				FromChannel: "state", ToChannel: "union", ToOrg: "UnionMSP",
+				DocumentTypeID: "state-transfer",
+				Title:          "State Education Spending Report - FUNDEB",
+				Description:    "Consolidated report of FUNDEB program expenditures",
+				Amount:         2500000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"destinationMunicipality": "Federal Government", "program": "FUNDEB Accountability Report",
+					"reportingPeriod": "Semester 1 2025", "municipalities": 50, "studentsServed": 125000,
+				},
+			}, &client.AcknowledgeTransferRequest{
+				DocumentTypeID: "federal-expense",
+				Title:          "Acknowledgment - State FUNDEB Report",
+				Description:    "Federal acknowledgment of state education spending",
+				Data: map[string]interface{}{
+					"category": "Education Accountability", "vendor": "State of São Paulo",
+					"contractNumber": "FUNDEB-2025", "reportingState": "São Paulo",
+				},
+			})
+		}},
+
+		{"invalidate", func(t *testing.T) {
+			wrongDoc = createDocument(ctx, t, env.union, "union", &client.CreateDocumentRequest{
+				DocumentTypeID: "federal-expense",
+				Title:          "Equipment Purchase - Ministry of Health",
+				Description:    "Purchase of medical equipment",
+				Amount:         500000, Currency: "BRL",
+				Data: map[string]interface{}{"category": "Medical Equipment", "vendor": "MedTech Solutions", "contractNumber": "CT-2024-001"},
+			}).ID
+			correction := createDocument(ctx, t, env.union, "union", &client.CreateDocumentRequest{
+				DocumentTypeID: "federal-expense",
+				Title:          "Equipment Purchase - Ministry of Health (CORRECTED)",
+				Description:    "Purchase of medical equipment - Corrected amount",
+				Amount:         550000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"category": "Medical Equipment", "vendor": "MedTech Solutions", "contractNumber": "CT-2024-001",
+					"correction": true, "corrects": wrongDoc,
+				},
+			})
+
+			_, err := env.union.InvalidateDocument(ctx, "union", wrongDoc, &client.InvalidateDocumentRequest{Reason: "Wrong correction", CorrectionDocID: "no-such-document"})
+			wantError(t, err, client.ErrCodeNotFound)
+			_, err = env.state.InvalidateDocument(ctx, "union", wrongDoc, &client.InvalidateDocumentRequest{Reason: "Not the state's document"})
+			wantError(t, err, client.ErrCodeWriteAccessDenied)
+
+			reason := "Incorrect amount. Correct amount is R$ 550,000.00"
+			if _, err := env.union.InvalidateDocument(ctx, "union", wrongDoc, &client.InvalidateDocumentRequest{Reason: reason, CorrectionDocID: correction.ID}); err != nil {
+				t.Fatalf("invalidate: %v", err)
+			}
+			doc := getDocument(ctx, t, env.union, "union", wrongDoc)
+			if doc.Status != client.StatusInvalidated || doc.InvalidReason != reason || doc.CorrectedByDoc != correction.ID ||
+				doc.InvalidatedAt == "" || doc.InvalidatedBy == "" || len(doc.History) != 2 {
+				t.Errorf("invalidated document = %+v", doc)
+			}
+			if doc.Amount != 500000 || doc.ContentHash != createdHash(t, doc) {
+				t.Errorf("invalidation changed the document's content: amount %v, hash %s", doc.Amount, doc.ContentHash)
+			}
+
+			_, err = env.union.InvalidateDocument(ctx, "union", wrongDoc, &client.InvalidateDocumentRequest{Reason: "again"})
+			wantError(t, err, client.ErrCodeInvalidState)
+
+			history, err := env.union.GetDocumentHistory(ctx, "union", wrongDoc)
+			if err != nil {
+				t.Fatalf("history: %v", err)
+			}
+			if len(history) != 2 {
+				t.Fatalf("history has %d entries, want 2", len(history))
+			}
+			for i, want := range []client.DocumentStatus{client.StatusActive, client.StatusInvalidated} {
+				entry := history[i]
+				if entry.TxID != doc.History[i] || entry.Timestamp.IsZero() || entry.IsDelete || entry.Document.Status != want {
+					t.Errorf("history[%d] = %s at %s, status %s; want tx %s, status %s",
+						i, entry.TxID, entry.Timestamp, entry.Document.Status, doc.History[i], want)
+				}
+				if entry.Document.ContentHash != doc.ContentHash {
+					t.Errorf("history[%d] content hash = %s, want %s", i, entry.Document.ContentHash, doc.ContentHash)
+				}
+			}
+		}},
+
+		{"internal", func(t *testing.T) {
+			contractor := createDocument(ctx, t, env.union, "union", &client.CreateDocumentRequest{
+				DocumentTypeID: "federal-expense",
+				Title:          "Software Development - Tech Solutions Ltda",
+				Description:    "Payment for citizen portal development - Phase 1",
+				Amount:         250000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"category": "IT Services", "vendor": "Tech Solutions Ltda", "contractNumber": "CT-2025-042",
+					"serviceDescription": "Custom citizen portal development", "invoiceNumber": "INV-2025-001",
+					"taxId": "12.345.678/0001-90", "deliveryDate": "2025-01-15",
+				},
+			})
+			contractorDoc = contractor.ID
+			if contractor.LinkedDocID != "" || contractor.LinkedChannel != "" || contractor.LinkedDocHash != "" || contractor.LinkedDirection != "" {
+				t.Errorf("internal expense has a link: %+v", contractor)
+			}
+			linked, err := env.union.GetLinkedDocuments(ctx, "union", contractor.ID)
+			if err != nil || linked.LinkedDocument != nil || linked.LinkVerified {
+				t.Errorf("linked documents of an internal expense = %+v, %v", linked, err)
+			}
+
+			// An internal expense anchors nothing: verifying it against a
+			// transfer acknowledgment reports every mismatch but the amount.
+			verification, err := env.union.VerifyAnchor(ctx, &client.VerifyAnchorRequest{
+				SourceChannel: "union", SourceDocID: contractor.ID,
+				TargetChannel: "state", TargetDocID: federalToState.ack,
+			})
+			if err != nil {
+				t.Fatalf("verify anchor: %v", err)
+			}
+			if verification.IsValid || verification.Status != "MISMATCH" || verification.HashMatch || verification.IDMatch ||
+				!verification.ChannelMatch || len(verification.MismatchReason) != 3 {
+				t.Errorf("unrelated anchor = %+v, want MISMATCH on hash, ID and amount", verification)
+			}
+
+			equipment := createDocument(ctx, t, env.state, "state", &client.CreateDocumentRequest{
+				DocumentTypeID: "state-transfer",
+				Title:          "Medical Equipment - MRI Scanner",
+				Description:    "MRI Scanner purchase for Hospital Regional",
+				Amount:         850000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"destinationMunicipality": "N/A - Equipment Purchase", "program": "Health Infrastructure",
+					"vendor": "MedEquip Brazil", "equipmentType": "MRI Scanner", "model": "Siemens Magnetom Vida",
+					"quantity": 1, "warrantyYears": 5, "installationDate": "2025-02-01",
+				},
+			})
+			createDocument(ctx, t, env.region, "region", &client.CreateDocumentRequest{
+				DocumentTypeID: "municipal-expense",
+				Title:          "IT Consulting - Cloud Migration",
+				Description:    "Consulting services for municipal systems cloud migration",
+				Amount:         75000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"category": "Consulting Services", "vendor": "CloudTech Consultoria",
+					"purpose": "Cloud migration planning and execution", "projectName": "Municipal Cloud Migration",
+					"duration": "3 months", "deliverables": "Architecture design, Migration plan, Staff training",
+				},
+			})
+			utility := createDocument(ctx, t, env.union, "union", &client.CreateDocumentRequest{
+				DocumentTypeID: "federal-expense",
+				Title:          "Electricity - Government Complex",
+				Description:    "Monthly electricity bill for federal government complex",
+				Amount:         125000, Currency: "BRL",
+				Data: map[string]interface{}{
+					"category": "Utilities", "vendor": "Eletrobras", "contractNumber": "UTIL-2025-E001",
+					"utilityType": "Electricity", "accountNumber": "ACC-12345", "billingPeriod": "2025-01",
+					"consumption": "150000 kWh",
+				},
+			})
+
+			_, err = env.region.CreateDocument(ctx, "region", &client.CreateDocumentRequest{
+				DocumentTypeID: "municipal-expense", Title: "No vendor", Amount: 10,
+				Data: map[string]interface{}{"category": "Consulting Services", "purpose": "Missing the vendor"},
+			})
+			wantError(t, err, client.ErrCodeValidationFailed)
+			_, err = env.region.CreateDocument(ctx, "region", &client.CreateDocumentRequest{DocumentTypeID: "federal-expense", Title: "Union type", Amount: 10})
+			wantError(t, err, client.ErrCodeInvalidDocumentType)
+
+			expenses := queryAll(ctx, t, env.union, "union", &client.QueryFilter{DocumentTypeID: "federal-expense", CountMode: "exact"})
+			if !containsIDs(expenses.docs, contractor.ID, utility.ID, wrongDoc) || expenses.total == nil || *expenses.total != len(expenses.docs) {
+				t.Errorf("federal expenses: %d documents, total %v", len(expenses.docs), expenses.total)
+			}
+			for _, doc := range expenses.docs {
+				if doc.DocumentTypeID != "federal-expense" {
+					t.Errorf("document %s of type %s in the federal-expense query", doc.ID, doc.DocumentTypeID)
+				}
+			}
+
+			for channel, c := range map[string]*client.Client{"union": env.union, "state": env.state} {
+				highValue := queryAll(ctx, t, c, channel, &client.QueryFilter{MinAmount: 200000, SortBy: "amount", CountMode: "exact"})
+				if highValue.total == nil || *highValue.total != len(highValue.docs) {
+					t.Errorf("%s: total %v for %d documents", channel, highValue.total, len(highValue.docs))
+				}
+				for i, doc := range highValue.docs {
+					if doc.Amount < 200000 || (i > 0 && doc.Amount > highValue.docs[i-1].Amount) {
+						t.Errorf("%s: document %d amount %v, want >= 200000 in descending order", channel, i, doc.Amount)
+					}
+				}
+				if channel == "union" && (!containsIDs(highValue.docs, contractor.ID) || containsIDs(highValue.docs, utility.ID)) {
+					t.Errorf("union high-value expenses include the contractor but not the utility bill")
+				}
+				if channel == "state" && !containsIDs(highValue.docs, equipment.ID) {
+					t.Errorf("state high-value expenses miss the equipment purchase %s", equipment.ID)
+				}
+			}
+
+			outgoing := queryAll(ctx, t, env.union, "union", &client.QueryFilter{LinkedDirection: "OUTGOING"})
+			if !containsIDs(outgoing.docs, federalToState.source) || containsIDs(outgoing.docs, contractor.ID) {
+				t.Errorf("union outgoing documents miss the federal transfer %s or include the contractor", federalToState.source)
+			}
+		}},
+
+		{"query", func(t *testing.T) {
+			active := queryAll(ctx, t, env.union, "union", &client.QueryFilter{Status: client.StatusActive})
+			if containsIDs(active.docs, wrongDoc) || !containsIDs(active.docs, contractorDoc) {
+				t.Errorf("active union documents include the invalidated %s or miss %s", wrongDoc, contractorDoc)
+			}
+			for _, doc := range active.docs {
+				if doc.Status != client.StatusActive {
+					t.Errorf("document %s is %s", doc.ID, doc.Status)
+				}
+			}
+
+			incoming := queryAll(ctx, t, env.state, "state", &client.QueryFilter{LinkedDirection: "INCOMING"})
+			if !containsIDs(incoming.docs, federalToState.ack, regionToState.ack) {
+				t.Errorf("state incoming documents miss the acknowledgments %s and %s", federalToState.ack, regionToState.ack)
+			}
+
+			byVendor := queryAll(ctx, t, env.union, "union", &client.QueryFilter{
+				DocumentTypeID: "federal-expense",
+				Conditions:     []client.FieldCondition{{Field: "data.vendor", Op: "eq", Value: "Tech Solutions Ltda"}},
+			})
+			if !containsIDs(byVendor.docs, contractorDoc) {
+				t.Errorf("data.vendor query misses %s", contractorDoc)
+			}
+			_, err := env.union.QueryDocuments(ctx, "union", &client.QueryFilter{
+				DocumentTypeID: "federal-expense",
+				Conditions:     []client.FieldCondition{{Field: "data.undeclared", Op: "eq", Value: "x"}},
+			})
+			wantError(t, err, client.ErrCodeInvalidQuery)
+
+			// Bookmarks page through the channel without repeating a document.
+			all := queryAll(ctx, t, env.union, "union", &client.QueryFilter{})
+			paged := queryAll(ctx, t, env.union, "union", &client.QueryFilter{PageSize: 2})
+			if paged.pages < 3 || len(paged.docs) != len(all.docs) {
+				t.Errorf("2 per page: %d documents in %d pages, want the %d documents in at least 3", len(paged.docs), paged.pages, len(all.docs))
+			}
+			seen := make(map[string]bool)
+			for _, doc := range paged.docs {
+				if seen[doc.ID] {
+					t.Errorf("document %s returned twice", doc.ID)
+				}
+				seen[doc.ID] = true
+			}
+		}},
+
+		{"errors", func(t *testing.T) {
+			// Contract errors reach clients as the API code and status they map
+			// to, with the contract's own code kept in the context.
+			_, err := env.union.GetDocument(ctx, "union", "no-such-document")
+			wantChaincodeError(t, err, "NOT_FOUND", client.ErrCodeNotFound, http.StatusNotFound)
+
+			_, err = env.union.QueryDocuments(ctx, "union", &client.QueryFilter{
+				DocumentTypeID: "federal-expense",
+				Conditions:     []client.FieldCondition{{Field: "data.$where", Op: "eq", Value: "1"}},
+			})
+			wantChaincodeError(t, err, "INVALID_QUERY", client.ErrCodeInvalidQuery, http.StatusBadRequest)
+
+			_, err = env.region.CreateDocument(ctx, "region", &client.CreateDocumentRequest{
+				DocumentTypeID: "municipal-expense", Title: "No vendor", Amount: 10,
+				Data: map[string]interface{}{"category": "Consulting Services", "purpose": "Missing the vendor"},
+			})
+			wantChaincodeError(t, err, "VALIDATION_FAILED", client.ErrCodeValidationFailed, http.StatusBadRequest)
+
+			_, err = env.region.CreateDocument(ctx, "region", &client.CreateDocumentRequest{DocumentTypeID: "federal-expense", Title: "Union type", Amount: 10})
+			wantChaincodeError(t, err, "INVALID_DOCUMENT_TYPE", client.ErrCodeInvalidDocumentType, http.StatusBadRequest)
+
+			_, err = env.union.InvalidateDocument(ctx, "union", wrongDoc, &client.InvalidateDocumentRequest{Reason: "again"})
+			wantChaincodeError(t, err, "INVALID_STATE", client.ErrCodeInvalidState, http.StatusConflict)
+
+			_, err = env.union.RegisterDocumentType(ctx, "union", &client.CreateDocumentTypeRequest{
+				ID: "federal-expense", Name: "Federal Expense", RequiredFields: []string{"somethingElse"},
+			})
+			wantChaincodeError(t, err, "ALREADY_EXISTS", client.ErrCodeAlreadyExists, http.StatusConflict)
+		}},
+	}
+
+	for _, scenario := range scenarios {
+		if !t.Run(scenario.name, scenario.run) {
+			return
+		}
+	}
+}
+
+func (env *scenarioEnv) instances() map[string]*client.Client {
+	return map[string]*client.Client{"union": env.union, "state": env.state, "region": env.region}
+}
+
+// transfer is the pair of documents of a completed transfer.
+type transfer struct {
+	source, ack string
+}
+
+// checkTransfer initiates a transfer from one instance, acknowledges it on
+// another and checks both documents, their link and the anchor.
+func checkTransfer(ctx context.Context, t *testing.T, env *scenarioEnv, from, to *client.Client,
+	initiate *client.InitiateTransferRequest, ack *client.AcknowledgeTransferRequest) transfer {
+	t.Helper()
+	fromChannel, toChannel := initiate.FromChannel, initiate.ToChannel
+
+	initiated, err := from.InitiateTransfer(ctx, initiate)
+	if err != nil {
+		t.Fatalf("initiate: %v", err)
+	}
+	source := getDocument(ctx, t, from, fromChannel, initiated.ID)
+	if initiated.Channel != fromChannel || initiated.ContentHash != source.ContentHash || source.ContentHash != createdHash(t, source) {
+		t.Errorf("initiated %+v, source content hash %s", initiated, source.ContentHash)
+	}
+	if source.Status != client.StatusActive || source.OrganizationID != orgs[fromChannel] || source.Amount != initiate.Amount ||
+		source.LinkedDirection != "OUTGOING" || source.LinkedChannel != toChannel || source.LinkedDocID != "" {
+		t.Errorf("source document = %+v", source)
+	}
+	if source.Data["transferType"] != "OUTGOING" || source.Data["targetOrg"] != initiate.ToOrg || source.Data["targetChannel"] != toChannel {
+		t.Errorf("source transfer data = %v", source.Data)
+	}
+
+	// The target organization cannot acknowledge through the source's instance.
+	ack.SourceDocID, ack.SourceChannel = source.ID, fromChannel
+	_, err = from.AcknowledgeTransfer(ctx, toChannel, ack)
+	wantError(t, err, client.ErrCodeWriteAccessDenied)
+
+	acked, err := to.AcknowledgeTransfer(ctx, toChannel, ack)
+	if err != nil {
+		t.Fatalf("acknowledge: %v", err)
+	}
+	if acked.Channel != toChannel || acked.LinkedDocID != source.ID || acked.LinkedDocChannel != fromChannel || acked.LinkedDocHash != source.ContentHash {
+		t.Errorf("acknowledged %+v, want a link to %s/%s with hash %s", acked, fromChannel, source.ID, source.ContentHash)
+	}
+	target := getDocument(ctx, t, to, toChannel, acked.ID)
+	if target.OrganizationID != orgs[toChannel] || target.Amount != source.Amount || target.Currency != source.Currency ||
+		target.LinkedDirection != "INCOMING" || target.LinkedDocID != source.ID || target.LinkedChannel != fromChannel ||
+		target.LinkedDocHash != source.ContentHash {
+		t.Errorf("acknowledgment document = %+v", target)
+	}
+	if target.ContentHash != acked.ContentHash || target.ContentHash != createdHash(t, target) || target.Data["sourceContentHash"] != source.ContentHash {
+		t.Errorf("acknowledgment content hash %s (returned %s), data %v", target.ContentHash, acked.ContentHash, target.Data)
+	}
+
+	// The source now links back to the acknowledgment.
+	source = getDocument(ctx, t, from, fromChannel, source.ID)
+	if source.LinkedDocID != target.ID || source.LinkedChannel != toChannel || source.LinkedDocHash != target.ContentHash || len(source.History) != 2 {
+		t.Errorf("source after acknowledgment = %+v", source)
+	}
+
+	// Any instance verifies the anchor, in both directions.
+	for _, anchor := range []*client.VerifyAnchorRequest{
+		{SourceChannel: fromChannel, SourceDocID: source.ID, TargetChannel: toChannel, TargetDocID: target.ID},
+		{SourceChannel: toChannel, SourceDocID: target.ID, TargetChannel: fromChannel, TargetDocID: source.ID},
+	} {
+		verification, err := env.union.VerifyAnchor(ctx, anchor)
+		if err != nil {
+			t.Fatalf("verify anchor %+v: %v", anchor, err)
+		}
+		if !verification.IsValid || verification.Status != "VERIFIED" || !verification.HashMatch || !verification.IDMatch ||
+			!verification.ChannelMatch || !verification.AmountMatch || verification.TargetLinkedHash != verification.SourceContentHash {
+			t.Errorf("anchor %s/%s -> %s/%s = %+v", anchor.SourceChannel, anchor.SourceDocID, anchor.TargetChannel, anchor.TargetDocID, verification)
+		}
+	}
+
+	for _, view := range []struct {
+		c              *client.Client
+		channel, docID string
+		linkedID       string
+	}{{to, toChannel, target.ID, source.ID}, {from, fromChannel, source.ID, target.ID}} {
+		linked, err := view.c.GetLinkedDocuments(ctx, view.channel, view.docID)
+		if err != nil {
+			t.Fatalf("linked documents of %s: %v", view.docID, err)
+		}
+		if !linked.LinkVerified || linked.LinkedDocument == nil || linked.LinkedDocument.ID != view.linkedID {
+			t.Errorf("linked documents of %s/%s = %+v", view.channel, view.docID, linked)
+		}
+	}
+	return transfer{source: source.ID, ack: target.ID}
+}
+
+// registerType registers a document type, or checks the definition of one a
+// previous run against the same network registered.
+func registerType(ctx context.Context, t *testing.T, c *client.Client, channel string, req *client.CreateDocumentTypeRequest) {
+	t.Helper()
+	if _, err := c.RegisterDocumentType(ctx, channel, req); err != nil && client.ErrorCodeOf(err) != client.ErrCodeAlreadyExists {
+		t.Fatalf("register %s: %v", req.ID, err)
+	}
+	dt, err := c.GetDocumentType(ctx, channel, req.ID)
+	if err != nil {
+		t.Fatalf("get %s: %v", req.ID, err)
+	}
+	if !dt.IsActive || dt.OrganizationID != orgs[channel] || dt.Name != req.Name ||
+		!slices.Equal(dt.RequiredFields, req.RequiredFields) || !slices.Equal(dt.OptionalFields, req.OptionalFields) {
+		t.Errorf("document type %s = %+v", req.ID, dt)
+	}
+}
+
+// createDocument creates a document and reads it back, checking what the
+// contract recorded.
+func createDocument(ctx context.Context, t *testing.T, c *client.Client, channel string, req *client.CreateDocumentRequest) *client.Document {
+	t.Helper()
+	created, err := c.CreateDocument(ctx, channel, req)
+	if err != nil {
+		t.Fatalf("create %q: %v", req.Title, err)
+	}
+	doc := getDocument(ctx, t, c, channel, created.ID)
+	if doc.Status != client.StatusActive || doc.OrganizationID != orgs[channel] || doc.DocumentTypeID != req.DocumentTypeID ||
+		doc.Title != req.Title || doc.Amount != req.Amount || doc.ContentHash != createdHash(t, doc) || len(doc.History) != 1 {
+		t.Errorf("created document = %+v", doc)
+	}
+	return doc
+}
+
+func getDocument(ctx context.Context, t *testing.T, c *client.Client, channel, docID string) *client.Document {
+	t.Helper()
+	doc, err := c.GetDocument(ctx, channel, docID)
+	if err != nil {
+		t.Fatalf("get %s/%s: %v", channel, docID, err)
+	}
+	return doc
+}
+
+// createdHash recomputes the content hash the contract gave the document.
+func createdHash(t *testing.T, doc *client.Document) string {
+	t.Helper()
+	hash, err := client.ContentHash(doc.Data)
+	if err != nil {
+		t.Fatalf("hash %s: %v", doc.ID, err)
+	}
+	return hash
+}
+
+type queryResult struct {
+	docs  []*client.Document
+	pages int
+	total *int
+}
+
+// queryAll follows every page of a query.
+func queryAll(ctx context.Context, t *testing.T, c *client.Client, channel string, filter *client.QueryFilter) queryResult {
+	t.Helper()
+	var result queryResult
+	for page, err := range c.DocumentPages(ctx, channel, filter) {
+		if err != nil {
+			t.Fatalf("query %s %+v: %v", channel, filter, err)
+		}
+		if result.pages == 0 {
+			result.total = page.Pagination.Total
+		}
+		result.pages++
+		result.docs = append(result.docs, page.Documents...)
+	}
+	return result
+}
+
+func containsIDs(docs []*client.Document, ids ...string) bool {
+	for _, id := range ids {
+		if !slices.ContainsFunc(docs, func(doc *client.Document) bool { return doc.ID == id }) {
+			return false
+		}
+	}
+	return true
+}
+
+// wantChaincodeError checks that err is the API's translation of the contract
+// error chaincodeCode.
+func wantChaincodeError(t *testing.T, err error, chaincodeCode string, code client.ErrorCode, status int) {
+	t.Helper()
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != code || apiErr.StatusCode != status || apiErr.Context["chaincodeCode"] != chaincodeCode {
+		t.Errorf("error = %+v, want %s with status %d from the contract's %s", err, code, status, chaincodeCode)
+	}
+}
+
+func wantError(t *testing.T, err error, code client.ErrorCode) {
+	t.Helper()
+	if client.ErrorCodeOf(err) != code {
+		t.Errorf("error = %v, want %s", err, code)
+	}
+}
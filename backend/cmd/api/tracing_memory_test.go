@@ -0,0 +1,152 @@
+//go:build !live
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/handlers"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/fabric"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+// TestTracingFollowsARequestIntoFabric records the spans of requests that
+// continue a caller's trace, and checks that the Fabric calls made for them
+// are traced as children of the request, down to the peer's gRPC calls.
+func TestTracingFollowsARequestIntoFabric(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	savedProvider, savedPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(savedProvider)
+		otel.SetTextMapPropagator(savedPropagator)
+	})
+
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatalf("start network: %v", err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatalf("start chaincode: %v", err)
+	}
+	cfg := &config.Config{
+		Server: config.ServerConfig{Mode: gin.TestMode},
+		Fabric: network.Config("union"),
+	}
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	fabricService := services.NewFabricService(gateway)
+	handler := handlers.NewHandler(fabricService, services.NewImportService(fabricService), services.NewExportService(fabricService, gateway), cfg)
+	router := setupRouter(cfg, handler, nil, middleware.NewMemoryRateLimitStore())
+
+	// The caller's trace and parent span, as a traceparent header carries them.
+	const (
+		callerTrace  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		callerParent = "00f067aa0ba902b7"
+	)
+	request := func(method, path, body, requestID string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", "00-"+callerTrace+"-"+callerParent+"-01")
+		req.Header.Set("X-Request-ID", requestID)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := request(http.MethodPost, "/api/v1/union/document-types",
+		`{"id": "federal-expense", "name": "Federal Expense", "requiredFields": ["category"], "optionalFields": ["vendor"]}`,
+		"audit-batch-42"); code != http.StatusCreated {
+		t.Fatalf("register type = %d, want 201", code)
+	}
+	if code := request(http.MethodGet, "/api/v1/union/documents/no-such-document", "", "audit-batch-43"); code != http.StatusNotFound {
+		t.Fatalf("get missing document = %d, want 404", code)
+	}
+
+	spans := recorder.Ended()
+	for _, tc := range []struct {
+		requestID string
+		fabric    string
+		grpc      []string
+		errorCode string
+	}{
+		{"audit-batch-42", "fabric.submit RegisterDocumentType", []string{"gateway.Gateway/Endorse", "gateway.Gateway/Submit", "gateway.Gateway/CommitStatus"}, ""},
+		{"audit-batch-43", "fabric.evaluate GetDocument", []string{"gateway.Gateway/Evaluate"}, "NOT_FOUND"},
+	} {
+		server := findSpan(spans, func(s sdktrace.ReadOnlySpan) bool {
+			return s.SpanKind() == trace.SpanKindServer && spanAttribute(s, "http.request_id") == tc.requestID
+		})
+		if server == nil {
+			t.Errorf("no server span with http.request_id %s among %d spans", tc.requestID, len(spans))
+			continue
+		}
+		if server.SpanContext().TraceID().String() != callerTrace || server.Parent().SpanID().String() != callerParent || !server.Parent().IsRemote() {
+			t.Errorf("%s: server span is in trace %s under %s, want the caller's %s under %s",
+				tc.requestID, server.SpanContext().TraceID(), server.Parent().SpanID(), callerTrace, callerParent)
+		}
+
+		call := findSpan(spans, func(s sdktrace.ReadOnlySpan) bool {
+			return s.Name() == tc.fabric && s.Parent().SpanID() == server.SpanContext().SpanID()
+		})
+		if call == nil {
+			t.Errorf("%s: no %s span under the server span", tc.requestID, tc.fabric)
+			continue
+		}
+		if spanAttribute(call, "fabric.channel") != "union-channel" {
+			t.Errorf("%s: fabric.channel = %q", tc.fabric, spanAttribute(call, "fabric.channel"))
+		}
+		if tc.errorCode == "" && (call.Status().Code == codes.Error || spanAttribute(call, "fabric.tx_id") == "") {
+			t.Errorf("%s: status %v, tx %q; want a successful call with its transaction ID", tc.fabric, call.Status(), spanAttribute(call, "fabric.tx_id"))
+		}
+		if tc.errorCode != "" && (call.Status().Code != codes.Error || spanAttribute(call, "error.code") != tc.errorCode) {
+			t.Errorf("%s: status %v, error.code %q; want a failed call with %s", tc.fabric, call.Status(), spanAttribute(call, "error.code"), tc.errorCode)
+		}
+
+		for _, method := range tc.grpc {
+			peerCall := findSpan(spans, func(s sdktrace.ReadOnlySpan) bool {
+				return s.Name() == method && s.Parent().SpanID() == call.SpanContext().SpanID()
+			})
+			if peerCall == nil {
+				t.Errorf("%s: no %s span under %s", tc.requestID, method, tc.fabric)
+			}
+		}
+	}
+}
+
+func findSpan(spans []sdktrace.ReadOnlySpan, match func(sdktrace.ReadOnlySpan) bool) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if match(span) {
+			return span
+		}
+	}
+	return nil
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
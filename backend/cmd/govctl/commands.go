@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// =============================================================================
+// Document Types
+// =============================================================================
+
+func typesList(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	if _, err := parseArgs(fs, args, 0, "channel"); err != nil {
+		return err
+	}
+
+	types, err := cl.client.ListDocumentTypes(ctx, *channel)
+	if err != nil {
+		return err
+	}
+	return cl.print(types, documentTypeTable(types...))
+}
+
+func typesGet(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	ids, err := parseArgs(fs, args, 1, "channel")
+	if err != nil {
+		return err
+	}
+
+	dt, err := cl.client.GetDocumentType(ctx, *channel, ids[0])
+	if err != nil {
+		return err
+	}
+	return cl.print(dt, documentTypeTable(dt))
+}
+
+func typesRegister(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	file := fs.String("f", "", "JSON or YAML file with one document type or a list (- for stdin)")
+	if _, err := parseArgs(fs, args, 0, "channel", "f"); err != nil {
+		return err
+	}
+
+	var reqs []*client.CreateDocumentTypeRequest
+	if err := readRequests(*file, &reqs); err != nil {
+		return err
+	}
+	var created []*client.ResourceID
+	for _, req := range reqs {
+		id, err := cl.client.RegisterDocumentType(writeContext(ctx), *channel, req)
+		if err != nil {
+			return fmt.Errorf("register %s: %w", req.ID, err)
+		}
+		created = append(created, id)
+	}
+	return cl.print(created, idTable(created))
+}
+
+// =============================================================================
+// Documents
+// =============================================================================
+
+func docsCreate(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	file := fs.String("f", "", "JSON or YAML file with one document or a list (- for stdin)")
+	if _, err := parseArgs(fs, args, 0, "channel", "f"); err != nil {
+		return err
+	}
+
+	var reqs []*client.CreateDocumentRequest
+	if err := readRequests(*file, &reqs); err != nil {
+		return err
+	}
+	var created []*client.ResourceID
+	for i, req := range reqs {
+		id, err := cl.client.CreateDocument(writeContext(ctx), *channel, req)
+		if err != nil {
+			return fmt.Errorf("create document %d (%s): %w", i+1, req.Title, err)
+		}
+		created = append(created, id)
+	}
+	return cl.print(created, idTable(created))
+}
+
+func docsGet(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	ids, err := parseArgs(fs, args, 1, "channel")
+	if err != nil {
+		return err
+	}
+
+	doc, err := cl.client.GetDocument(ctx, *channel, ids[0])
+	if err != nil {
+		return err
+	}
+	return cl.print(doc, documentTable(doc))
+}
+
+func docsQuery(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	var filter client.QueryFilter
+	fs.StringVar(&filter.OrganizationID, "org", "", "Organization ID")
+	fs.StringVar(&filter.DocumentTypeID, "type", "", "Document type ID")
+	status := fs.String("status", "", "Status: ACTIVE or INVALIDATED")
+	fs.StringVar(&filter.FromDate, "from", "", "Created on or after (ISO 8601)")
+	fs.StringVar(&filter.ToDate, "to", "", "Created on or before (ISO 8601)")
+	fs.Float64Var(&filter.MinAmount, "min", 0, "Minimum amount")
+	fs.Float64Var(&filter.MaxAmount, "max", 0, "Maximum amount")
+	linked := fs.String("linked", "", "Only documents with (true) or without (false) a cross-channel link")
+	fs.StringVar(&filter.LinkedDirection, "direction", "", "Link direction: OUTGOING or INCOMING")
+	fs.StringVar(&filter.SortBy, "sort", "", "Sort field: createdAt, updatedAt, amount or title")
+	fs.StringVar(&filter.SortOrder, "order", "", "Sort direction: asc or desc")
+	fs.Func("where", "Field condition field:op:value, repeatable (e.g. data.municipality:in:Campinas|Santos)", func(v string) error {
+		filter.Where = append(filter.Where, v)
+		return nil
+	})
+	fs.IntVar(&filter.PageSize, "page-size", 0, "Documents per request (server default when 0)")
+	limit := fs.Int("limit", 0, "Stop after this many documents (0 for all)")
+	if _, err := parseArgs(fs, args, 0, "channel"); err != nil {
+		return err
+	}
+	filter.Status = client.DocumentStatus(*status)
+	if *linked != "" {
+		hasLinked, err := strconv.ParseBool(*linked)
+		if err != nil {
+			return usagef("-linked must be true or false")
+		}
+		filter.HasLinkedDoc = &hasLinked
+	}
+
+	docs := []*client.Document{}
+	for doc, err := range cl.client.Documents(ctx, *channel, &filter) {
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		if *limit > 0 && len(docs) == *limit {
+			break
+		}
+	}
+	return cl.print(docs, documentTable(docs...))
+}
+
+func docsHistory(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	ids, err := parseArgs(fs, args, 1, "channel")
+	if err != nil {
+		return err
+	}
+
+	history, err := cl.client.GetDocumentHistory(ctx, *channel, ids[0])
+	if err != nil {
+		return err
+	}
+	return cl.print(history, historyTable(history))
+}
+
+func docsLinked(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	ids, err := parseArgs(fs, args, 1, "channel")
+	if err != nil {
+		return err
+	}
+
+	linked, err := cl.client.GetLinkedDocuments(ctx, *channel, ids[0])
+	if err != nil {
+		return err
+	}
+	verified := strconv.FormatBool(linked.LinkVerified)
+	t := table{header: append([]string{"ROLE", "CHANNEL", "VERIFIED"}, documentHeader...)}
+	for _, side := range []struct {
+		role string
+		doc  *client.Document
+	}{{"document", linked.Document}, {"linked", linked.LinkedDocument}} {
+		if side.doc != nil {
+			t.rows = append(t.rows, append([]string{side.role, side.doc.ChannelID, verified}, documentRow(side.doc)...))
+		}
+	}
+	return cl.print(linked, t)
+}
+
+func docsInvalidate(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	var req client.InvalidateDocumentRequest
+	fs.StringVar(&req.Reason, "reason", "", "Why the document is invalid")
+	fs.StringVar(&req.CorrectionDocID, "correction", "", "ID of the document that corrects it")
+	ids, err := parseArgs(fs, args, 1, "channel", "reason")
+	if err != nil {
+		return err
+	}
+
+	result, err := cl.client.InvalidateDocument(writeContext(ctx), *channel, ids[0], &req)
+	if err != nil {
+		return err
+	}
+	return cl.print(result, table{
+		header: []string{"ID", "MESSAGE"},
+		rows:   [][]string{{result.ID, result.Message}},
+	})
+}
+
+// =============================================================================
+// Transfers and Anchors
+// =============================================================================
+
+func transferInitiate(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	file := fs.String("f", "", "JSON or YAML file with the transfer (- for stdin)")
+	if _, err := parseArgs(fs, args, 0, "f"); err != nil {
+		return err
+	}
+
+	var req client.InitiateTransferRequest
+	if err := readFile(*file, &req); err != nil {
+		return err
+	}
+	result, err := cl.client.InitiateTransfer(writeContext(ctx), &req)
+	if err != nil {
+		return err
+	}
+	return cl.print(result, transferTable(result))
+}
+
+func transferAck(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	file := fs.String("f", "", "JSON or YAML file with the acknowledgment (- for stdin)")
+	if _, err := parseArgs(fs, args, 0, "channel", "f"); err != nil {
+		return err
+	}
+
+	var req client.AcknowledgeTransferRequest
+	if err := readFile(*file, &req); err != nil {
+		return err
+	}
+	result, err := cl.client.AcknowledgeTransfer(writeContext(ctx), *channel, &req)
+	if err != nil {
+		return err
+	}
+	return cl.print(result, transferTable(result))
+}
+
+func anchorVerify(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	source := fs.String("source", "", "Source document as CHANNEL/DOC_ID")
+	target := fs.String("target", "", "Target document, holding the anchor, as CHANNEL/DOC_ID")
+	if _, err := parseArgs(fs, args, 0, "source", "target"); err != nil {
+		return err
+	}
+
+	var req client.VerifyAnchorRequest
+	var ok bool
+	if req.SourceChannel, req.SourceDocID, ok = strings.Cut(*source, "/"); !ok {
+		return usagef("-source must be CHANNEL/DOC_ID")
+	}
+	if req.TargetChannel, req.TargetDocID, ok = strings.Cut(*target, "/"); !ok {
+		return usagef("-target must be CHANNEL/DOC_ID")
+	}
+
+	v, err := cl.client.VerifyAnchor(ctx, &req)
+	if err != nil {
+		return err
+	}
+	err = cl.print(v, table{
+		header: []string{"STATUS", "SOURCE", "TARGET", "SOURCE HASH", "ANCHOR", "REASONS"},
+		rows: [][]string{{
+			v.Status, v.SourceChannel + "/" + v.SourceDocID, v.TargetChannel + "/" + v.TargetDocID,
+			v.SourceContentHash, v.TargetLinkedHash, strings.Join(v.MismatchReason, "; "),
+		}},
+	})
+	if err == nil && !v.IsValid {
+		err = errFailed
+	}
+	return err
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// parseArgs parses a command's flags, checks that the required flags are set
+// and that exactly nargs positional arguments were given, and returns them.
+func parseArgs(fs *flag.FlagSet, args []string, nargs int, required ...string) ([]string, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range required {
+		if fs.Lookup(name).Value.String() == "" {
+			return nil, usagef("missing required flag -%s", name)
+		}
+	}
+	if len(positional) != nargs {
+		return nil, usagef("want %d argument(s), got %d", nargs, len(positional))
+	}
+	return positional, nil
+}
+
+func idTable(ids []*client.ResourceID) table {
+	t := table{header: []string{"ID"}}
+	for _, id := range ids {
+		t.rows = append(t.rows, []string{id.ID})
+	}
+	return t
+}
+
+// writeContext gives a write its own idempotency key, so the client can
+// retry it without applying it twice.
+func writeContext(ctx context.Context) context.Context {
+	return client.WithIdempotencyKey(ctx, uuid.NewString())
+}
+
+// readFile decodes a JSON or YAML file into v, rejecting unknown fields.
+// YAML is recognized by the .yaml or .yml extension; "-" reads either from
+// stdin.
+func readFile(path string, v interface{}) error {
+	data, err := readJSON(path)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// readRequests decodes a file holding one request or a list of them into
+// the slice pointed to by v.
+func readRequests(path string, v interface{}) error {
+	data, err := readJSON(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		data = append(append([]byte("["), data...), ']')
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// readJSON reads a file as JSON, converting YAML.
+func readJSON(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" && (path != "-" || json.Valid(data)) {
+		return data, nil
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return converted, nil
+}
@@ -0,0 +1,204 @@
+// Command govctl is a command-line client of the gov-spending REST API for
+// operators and auditors: it registers document types, creates and queries
+// documents, runs cross-channel transfers, verifies anchors, dumps history
+// and recomputes content hashes locally.
+//
+//	govctl -server http://localhost:3001 docs query -channel state -min 200000 -o csv
+//	govctl verify -f saved-document.json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// cli holds what every command needs.
+type cli struct {
+	cmd    command
+	client *client.Client
+	output string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, cl *cli, args []string) error
+}
+
+var commands = []command{
+	{"types list", "-channel CH", "List document types", typesList},
+	{"types get", "-channel CH TYPE_ID", "Show a document type", typesGet},
+	{"types register", "-channel CH -f FILE", "Register document types from a JSON or YAML file", typesRegister},
+	{"docs create", "-channel CH -f FILE", "Create documents from a JSON or YAML file", docsCreate},
+	{"docs get", "-channel CH DOC_ID", "Show a document", docsGet},
+	{"docs query", "-channel CH [filters]", "Query documents, following every page", docsQuery},
+	{"docs history", "-channel CH DOC_ID", "Dump every version of a document", docsHistory},
+	{"docs linked", "-channel CH DOC_ID", "Show a document and its cross-channel link", docsLinked},
+	{"docs invalidate", "-channel CH -reason TEXT [-correction DOC_ID] DOC_ID", "Invalidate a document", docsInvalidate},
+	{"transfer initiate", "-f FILE", "Initiate a cross-channel transfer", transferInitiate},
+	{"transfer ack", "-channel CH -f FILE", "Acknowledge a transfer on the target channel", transferAck},
+	{"anchor verify", "-source CH/DOC_ID -target CH/DOC_ID", "Verify the anchor of a cross-channel link", anchorVerify},
+	{"verify", "[-channel CH] (DOC_ID | -f FILE) [-offline]", "Recompute a document's content hash and compare it with the ledger", verifyDocument},
+}
+
+var (
+	// errFailed ends a command that already reported why it failed, such
+	// as a verification mismatch.
+	errFailed = errors.New("failed")
+	// errBadFlags ends a command whose flags the flag package rejected and
+	// reported.
+	errBadFlags = errors.New("bad flags")
+)
+
+// usageError is a command line that cannot be run.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command line and returns the exit code: 0 on success, 1
+// when the command failed and 2 on usage errors.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("govctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() { printUsage(stderr, global) }
+	server := global.String("server", envOr("GOVCTL_SERVER", "http://localhost:3000"), "API base URL (env GOVCTL_SERVER)")
+	output := global.String("o", "table", "Output format: table, json or csv")
+	timeout := global.Duration("timeout", 2*time.Minute, "Timeout of the whole command")
+	requestID := global.String("request-id", "", "X-Request-ID sent with every request, to find them in the server logs")
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+
+	cmd, cmdArgs, ok := findCommand(global.Args())
+	if !ok {
+		printUsage(stderr, global)
+		return 2
+	}
+	switch *output {
+	case "table", "json", "csv":
+	default:
+		fmt.Fprintf(stderr, "govctl: unknown output format %q\n", *output)
+		return 2
+	}
+
+	c, err := client.New(*server, client.WithRetry(client.DefaultRetryPolicy), client.WithUserAgent("govctl"))
+	if err != nil {
+		fmt.Fprintf(stderr, "govctl: %v\n", err)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	if *requestID != "" {
+		ctx = client.WithRequestID(ctx, *requestID)
+	}
+
+	cl := &cli{cmd: cmd, client: c, output: *output, stdout: stdout, stderr: stderr}
+	err = cmd.run(ctx, cl, cmdArgs)
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "govctl %s: %v\nusage: govctl %s %s\n", cmd.name, err, cmd.name, cmd.args)
+		return 2
+	case errors.Is(err, errBadFlags):
+		return 2
+	case errors.Is(err, errFailed):
+		return 1
+	default:
+		fmt.Fprintf(stderr, "govctl %s: %v\n", cmd.name, err)
+		return 1
+	}
+}
+
+// findCommand matches the longest command name at the start of args.
+func findCommand(args []string) (command, []string, bool) {
+	for _, n := range []int{2, 1} {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		for _, cmd := range commands {
+			if cmd.name == name {
+				return cmd, args[n:], true
+			}
+		}
+	}
+	return command{}, nil, false
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintf(w, "usage: govctl [global flags] <command> [flags] [args]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-18s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\nRun govctl <command> -h for the flags of a command.\n\nGlobal flags:\n")
+	global.PrintDefaults()
+}
+
+// newFlags creates the flag set of the command being run.
+func (cl *cli) newFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("govctl "+cl.cmd.name, flag.ContinueOnError)
+	fs.SetOutput(cl.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(cl.stderr, "usage: govctl %s %s\n\n%s.\n\nFlags:\n", cl.cmd.name, cl.cmd.args, cl.cmd.summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// channelFlag registers -channel, defaulting to $GOVCTL_CHANNEL.
+func channelFlag(fs *flag.FlagSet) *string {
+	return fs.String("channel", os.Getenv("GOVCTL_CHANNEL"), "Channel key: union, state or region (env GOVCTL_CHANNEL)")
+}
+
+// parseFlags parses flags placed before or after the positional arguments,
+// which it returns.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err == flag.ErrHelp {
+			return nil, err
+		} else if err != nil {
+			return nil, errBadFlags
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// sha256 of {"a":1,"b":"x"}, the chaincode's encoding of the test data.
+const testDataHash = "ecf9e98ec0641e23113ff3ce8bdc78d0ddd249886517fd4a7f68cc83d4e65667"
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.Envelope{Data: data})
+}
+
+func runCLI(t *testing.T, server *httptest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"-server", server.URL}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifyComparesSavedDocumentWithLedger(t *testing.T) {
+	ledger := models.Document{
+		ID:          "DOC-1",
+		ChannelID:   "state-channel",
+		Data:        map[string]interface{}{"b": "x", "a": 1},
+		ContentHash: testDataHash,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /config", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"channelDetails": map[string]interface{}{"state": map[string]interface{}{"channelName": "state-channel"}},
+		})
+	})
+	mux.HandleFunc("GET /api/v1/state/documents/DOC-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, ledger)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	saved, _ := json.Marshal(models.Envelope{Data: ledger, Meta: models.Meta{RequestID: "r"}})
+	code, out, stderr := runCLI(t, server, "-o", "json", "verify", "-f", writeFile(t, "doc.json", string(saved)))
+	if code != 0 || !strings.Contains(out, `"status": "VERIFIED"`) || !strings.Contains(out, `"ledgerHash": "`+testDataHash) {
+		t.Errorf("saved copy: exit %d\n%s%s", code, out, stderr)
+	}
+
+	ledger.Data["a"] = 2
+	tampered, _ := json.Marshal(ledger)
+	code, out, stderr = runCLI(t, server, "-o", "csv", "verify", "-f", writeFile(t, "doc.json", string(tampered)))
+	if code != 1 || !strings.Contains(out, "MISMATCH") || !strings.Contains(out, "document's contentHash; data does not hash to the ledger's") {
+		t.Errorf("tampered copy: exit %d\n%s%s", code, out, stderr)
+	}
+}
+
+func TestQueryFollowsPagesAndPrintsCSV(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/documents", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("minAmount") != "200000" || q.Get("where") != "data.year:gte:2024" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		doc := models.Document{ID: "A", Title: "Grant, phase 1", Amount: 250000, Status: models.StatusActive}
+		pagination := &models.Pagination{Count: 1, HasMore: true, NextBookmark: "b1"}
+		if q.Get("bookmark") == "b1" {
+			doc = models.Document{ID: "B", Title: "Grant", Amount: 300000, LinkedDocID: "S-1", LinkedChannel: "state-channel", LinkedDirection: "OUTGOING"}
+			pagination = &models.Pagination{Count: 1}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(models.Envelope{Data: []models.Document{doc}, Meta: models.Meta{Pagination: pagination}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	code, out, stderr := runCLI(t, server, "-o", "csv", "docs", "query", "-channel", "union", "-min", "200000", "-where", "data.year:gte:2024")
+	want := "ID,TYPE,STATUS,TITLE,AMOUNT,CURRENCY,LINK,CREATED\n" +
+		"A,,ACTIVE,\"Grant, phase 1\",250000.00,,,\n" +
+		"B,,,Grant,300000.00,,OUTGOING state-channel/S-1,\n"
+	if code != 0 || out != want {
+		t.Errorf("exit %d, output:\n%s\nwant:\n%s%s", code, out, want, stderr)
+	}
+}
+
+func TestCreateReadsYAMLList(t *testing.T) {
+	var created []models.CreateDocumentRequest
+	var keys []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/region/documents", func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateDocumentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		created = append(created, req)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		writeJSON(w, http.StatusCreated, models.ResourceID{ID: req.ID})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	file := writeFile(t, "docs.yaml", `
+- id: R-1
+  documentTypeId: municipal-expense
+  title: Paving
+  amount: 1200.5
+  data: {street: Main, lots: 3}
+- id: R-2
+  documentTypeId: municipal-expense
+  title: Lighting
+`)
+	code, out, stderr := runCLI(t, server, "docs", "create", "-channel", "region", "-f", file)
+	if code != 0 || len(created) != 2 {
+		t.Fatalf("exit %d, %d created\n%s%s", code, len(created), out, stderr)
+	}
+	if created[0].Amount != 1200.5 || created[0].Data["lots"] != float64(3) || created[1].Title != "Lighting" {
+		t.Errorf("created = %+v", created)
+	}
+	if keys[0] == "" || keys[0] == keys[1] {
+		t.Errorf("Idempotency-Key = %q, want one key per document", keys)
+	}
+
+	code, _, stderr = runCLI(t, server, "docs", "create", "-channel", "region", "-f", writeFile(t, "bad.yaml", "titel: typo\n"))
+	if code != 1 || !strings.Contains(stderr, `unknown field "titel"`) {
+		t.Errorf("unknown field: exit %d, %s", code, stderr)
+	}
+	if code, _, _ := runCLI(t, server, "docs", "create", "-f", file); code != 2 {
+		t.Errorf("missing -channel: exit %d, want 2", code)
+	}
+}
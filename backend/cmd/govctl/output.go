@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// table is the tabular form of a result, printed by the table and csv
+// outputs.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// print writes v as JSON, or t as an aligned table or CSV.
+func (cl *cli) print(v interface{}, t table) error {
+	switch cl.output {
+	case "json":
+		enc := json.NewEncoder(cl.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "csv":
+		w := csv.NewWriter(cl.stdout)
+		w.Write(t.header)
+		w.WriteAll(t.rows)
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(cl.stdout, 0, 0, 2, ' ', 0)
+		w.Write([]byte(strings.Join(t.header, "\t") + "\n"))
+		for _, row := range t.rows {
+			w.Write([]byte(strings.Join(sanitize(row), "\t") + "\n"))
+		}
+		return w.Flush()
+	}
+}
+
+// sanitize keeps free-text cells on one table line.
+func sanitize(row []string) []string {
+	clean := make([]string, len(row))
+	for i, cell := range row {
+		clean[i] = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(cell)
+	}
+	return clean
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+var documentHeader = []string{"ID", "TYPE", "STATUS", "TITLE", "AMOUNT", "CURRENCY", "LINK", "CREATED"}
+
+func documentRow(doc *client.Document) []string {
+	link := ""
+	if doc.LinkedDocID != "" {
+		link = doc.LinkedDirection + " " + doc.LinkedChannel + "/" + doc.LinkedDocID
+	}
+	return []string{
+		doc.ID, doc.DocumentTypeID, string(doc.Status), doc.Title,
+		formatAmount(doc.Amount), doc.Currency, link, doc.CreatedAt,
+	}
+}
+
+func documentTable(docs ...*client.Document) table {
+	t := table{header: documentHeader}
+	for _, doc := range docs {
+		t.rows = append(t.rows, documentRow(doc))
+	}
+	return t
+}
+
+func documentTypeTable(types ...*client.DocumentType) table {
+	t := table{header: []string{"ID", "NAME", "ORGANIZATION", "ACTIVE", "REQUIRED", "OPTIONAL"}}
+	for _, dt := range types {
+		t.rows = append(t.rows, []string{
+			dt.ID, dt.Name, dt.OrganizationID, strconv.FormatBool(dt.IsActive),
+			strings.Join(dt.RequiredFields, ","), strings.Join(dt.OptionalFields, ","),
+		})
+	}
+	return t
+}
+
+func historyTable(entries []*client.HistoryEntry) table {
+	t := table{header: []string{"TX", "TIMESTAMP", "DELETED", "STATUS", "TITLE", "AMOUNT", "CONTENT HASH"}}
+	for _, entry := range entries {
+		row := []string{entry.TxID, entry.Timestamp.Format(time.RFC3339), strconv.FormatBool(entry.IsDelete), "", "", "", ""}
+		if doc := entry.Document; doc != nil {
+			row[3], row[4], row[5], row[6] = string(doc.Status), doc.Title, formatAmount(doc.Amount), doc.ContentHash
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t
+}
+
+func transferTable(result *client.TransferResult) table {
+	linked := ""
+	if result.LinkedDocID != "" {
+		linked = result.LinkedDocChannel + "/" + result.LinkedDocID
+	}
+	return table{
+		header: []string{"ID", "CHANNEL", "CONTENT HASH", "LINKED", "LINKED HASH"},
+		rows:   [][]string{{result.ID, result.Channel, result.ContentHash, linked, result.LinkedDocHash}},
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gov-spending/backend/pkg/client"
+)
+
+// verification reports whether a document's data still hashes to the
+// ContentHash the chaincode computed when it was created.
+type verification struct {
+	DocumentID      string   `json:"documentId"`
+	Channel         string   `json:"channel,omitempty"`
+	ComputedHash    string   `json:"computedHash"`
+	DocumentHash    string   `json:"documentHash"`
+	LedgerHash      string   `json:"ledgerHash,omitempty"`
+	Status          string   `json:"status"`
+	MismatchReasons []string `json:"mismatchReasons,omitempty"`
+}
+
+// verifyDocument recomputes ContentHash from a document's data. The document
+// is read from the ledger by ID, or from a saved JSON file: the output of
+// docs get -o json, or an /api/v1 response. A saved document is also
+// compared with the ledger's copy unless -offline is given; its channel is
+// found from the document's channelId when -channel is not set.
+func verifyDocument(ctx context.Context, cl *cli, args []string) error {
+	fs := cl.newFlags()
+	channel := channelFlag(fs)
+	file := fs.String("f", "", "Saved document JSON (- for stdin) instead of a DOC_ID")
+	offline := fs.Bool("offline", false, "Only check the saved document, without contacting the API")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var doc *client.Document
+	switch {
+	case *file != "" && len(positional) == 0:
+		if doc, err = readDocument(*file); err != nil {
+			return err
+		}
+	case *file == "" && len(positional) == 1 && *channel != "":
+		if doc, err = cl.client.GetDocument(ctx, *channel, positional[0]); err != nil {
+			return err
+		}
+		*offline = true // the ledger's copy is the document checked
+	default:
+		return usagef("give -channel and a DOC_ID, or -f FILE")
+	}
+
+	computed, err := client.ContentHash(doc.Data)
+	if err != nil {
+		return fmt.Errorf("hash document data: %w", err)
+	}
+	v := verification{
+		DocumentID:   doc.ID,
+		Channel:      *channel,
+		ComputedHash: computed,
+		DocumentHash: doc.ContentHash,
+	}
+	if computed != doc.ContentHash {
+		v.MismatchReasons = append(v.MismatchReasons, "data does not hash to the document's contentHash")
+	}
+
+	if !*offline {
+		if v.Channel == "" {
+			if v.Channel, err = channelKey(ctx, cl.client, doc.ChannelID); err != nil {
+				return err
+			}
+		}
+		ledger, err := cl.client.GetDocument(ctx, v.Channel, doc.ID)
+		if err != nil {
+			return fmt.Errorf("read ledger copy: %w", err)
+		}
+		v.LedgerHash = ledger.ContentHash
+		if computed != ledger.ContentHash {
+			v.MismatchReasons = append(v.MismatchReasons, "data does not hash to the ledger's contentHash")
+		}
+	}
+
+	v.Status = "VERIFIED"
+	if len(v.MismatchReasons) > 0 {
+		v.Status = "MISMATCH"
+	}
+	err = cl.print(v, table{
+		header: []string{"STATUS", "DOCUMENT", "COMPUTED HASH", "DOCUMENT HASH", "LEDGER HASH", "REASONS"},
+		rows: [][]string{{
+			v.Status, v.DocumentID, v.ComputedHash, v.DocumentHash, v.LedgerHash, strings.Join(v.MismatchReasons, "; "),
+		}},
+	})
+	if err == nil && v.Status != "VERIFIED" {
+		err = errFailed
+	}
+	return err
+}
+
+// readDocument reads a saved document, unwrapping an /api/v1 envelope.
+func readDocument(path string) (*client.Document, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A document has data too: only an object with meta and without id is an
+	// envelope.
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, hasMeta := fields["meta"]; hasMeta && fields["id"] == nil && fields["data"] != nil {
+		data = fields["data"]
+	}
+	var doc client.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.ID == "" {
+		return nil, fmt.Errorf("%s: not a document: no id", path)
+	}
+	return &doc, nil
+}
+
+// channelKey maps a Fabric channel name, as in Document.ChannelID, to the
+// channel key the API routes use.
+func channelKey(ctx context.Context, c *client.Client, channelName string) (string, error) {
+	info, err := c.Config(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolve channel %s: %w", channelName, err)
+	}
+	for key, detail := range info.ChannelDetails {
+		if detail.ChannelName == channelName {
+			return key, nil
+		}
+	}
+	return "", usagef("channel %q is not served by this instance; set -channel", channelName)
+}
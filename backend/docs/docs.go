@@ -15,7 +15,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/anchors/verify": {
+        "/api/v1/anchors/verify": {
             "post": {
                 "description": "Verify that two documents are properly linked across channels using cryptographic hashes",
                 "consumes": [
@@ -37,25 +37,43 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.VerifyAnchorRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AnchorVerification"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AnchorVerification"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/transfers/initiate": {
+        "/api/v1/transfers/initiate": {
             "post": {
                 "description": "Start an inter-government transfer (e.g., Federal → State). Creates document with cryptographic hash.",
                 "consumes": [
@@ -77,31 +95,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.InitiateTransferRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 202 Accepted once submitted instead of waiting for the commit",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.TransferResult"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TransferResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubmittedTransaction"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/document-types": {
+        "/api/v1/{channel}/document-types": {
             "get": {
                 "description": "Get all document types for a channel",
                 "produces": [
@@ -124,10 +190,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.DocumentType"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.DocumentType"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
@@ -160,31 +244,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.CreateDocumentTypeRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 202 Accepted once submitted instead of waiting for the commit",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.IDResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ResourceID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubmittedTransaction"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/document-types/{typeId}": {
+        "/api/v1/{channel}/document-types/{typeId}": {
             "get": {
                 "description": "Get specific document type by ID",
                 "produces": [
@@ -214,13 +346,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DocumentType"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DocumentType"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
@@ -248,27 +398,138 @@ const docTemplate = `{
                         "name": "typeId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 202 Accepted once submitted instead of waiting for the commit",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ActionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubmittedTransaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/document-types/{typeId}/indexes": {
+            "get": {
+                "description": "Derive CouchDB index definitions for the data fields declared by a document type, ready to package under META-INF/statedb/couchdb/indexes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Document Types"
+                ],
+                "summary": "Derive CouchDB indexes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document type ID",
+                        "name": "typeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.CouchDBIndex"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/documents": {
+        "/api/v1/{channel}/documents": {
             "get": {
-                "description": "Search and filter documents with pagination",
+                "description": "Search and filter documents with pagination. meta.pagination.nextBookmark fetches the next page while hasMore is true.",
                 "produces": [
                     "application/json"
                 ],
@@ -346,6 +607,50 @@ const docTemplate = `{
                         "name": "linkedDirection",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "updatedAt",
+                            "amount",
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "estimated"
+                        ],
+                        "type": "string",
+                        "description": "Include total matches (estimated stops at 1000 and sets totalEstimated)",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Field conditions as field:op:value, e.g. data.municipality:in:Campinas|Santos (ops: eq, in, gt, gte, lt, lte, exists)",
+                        "name": "where",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 20,
@@ -364,7 +669,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.QueryResult"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Document"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
@@ -397,31 +723,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.CreateDocumentRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 202 Accepted once submitted instead of waiting for the commit",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Document"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ResourceID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubmittedTransaction"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/documents/{docId}": {
+        "/api/v1/{channel}/documents/{docId}": {
             "get": {
                 "description": "Get specific document by ID with all details",
                 "produces": [
@@ -451,19 +825,37 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Document"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Document"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/documents/{docId}/history": {
+        "/api/v1/{channel}/documents/{docId}/history": {
             "get": {
                 "description": "Get complete transaction history for a document (all versions)",
                 "produces": [
@@ -493,22 +885,40 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.HistoryEntry"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.HistoryEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/documents/{docId}/invalidate": {
+        "/api/v1/{channel}/documents/{docId}/invalidate": {
             "post": {
                 "description": "Mark document as invalid (immutable - never deleted). Used for error correction.",
                 "consumes": [
@@ -544,37 +954,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.InvalidateDocumentRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 202 Accepted once submitted instead of waiting for the commit",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ActionResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SubmittedTransaction"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "403": {
                         "description": "Only creating organization can invalidate",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/documents/{docId}/linked": {
+        "/api/v1/{channel}/documents/{docId}/linked": {
             "get": {
                 "description": "Get document and its cross-channel linked document (if any)",
                 "produces": [
@@ -604,116 +1056,754 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.LinkedDocuments"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.LinkedDocuments"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorEnvelope"
                         }
                     }
                 }
             }
         },
-        "/api/{channel}/transfers/acknowledge": {
-            "post": {
-                "description": "Acknowledge a received cross-channel transfer. Creates linked document on target channel.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/{channel}/exports/documents": {
+            "get": {
+                "description": "Stream every document matching the filter as CSV, NDJSON or Parquet, paging through the ledger without loading the result set into memory. The X-Export-ID header names the export; its signed manifest with the SHA-256 of the file is available once the stream ends, and the digest is also sent as the X-Export-SHA256 trailer. A stream that fails after the status line ends early with an X-Export-Error trailer instead; treat the file as incomplete.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet"
                 ],
                 "tags": [
-                    "Transfers"
+                    "Exports"
                 ],
-                "summary": "Acknowledge transfer",
+                "summary": "Export documents",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Target channel (union, state, region)",
+                        "description": "Channel (union, state, region)",
                         "name": "channel",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Acknowledgment details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AcknowledgeTransferRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.TransferResult"
-                        }
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Filter by organization",
+                        "name": "organizationId",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Check if the API is running",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "status: healthy",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "models.AcknowledgeTransferRequest": {
-            "type": "object",
-            "required": [
-                "documentTypeId",
-                "sourceChannel",
-                "sourceDocId",
-                "title"
-            ],
-            "properties": {
-                "data": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "description": {
-                    "type": "string"
-                },
-                "documentTypeId": {
-                    "type": "string"
-                },
-                "sourceChannel": {
+                    {
+                        "type": "string",
+                        "description": "Filter by document type",
+                        "name": "documentTypeId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ACTIVE",
+                            "INVALIDATED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status (ACTIVE, INVALIDATED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (ISO 8601)",
+                        "name": "fromDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date (ISO 8601)",
+                        "name": "toDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Has linked document",
+                        "name": "hasLinkedDoc",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "OUTGOING",
+                            "INCOMING"
+                        ],
+                        "type": "string",
+                        "description": "Link direction",
+                        "name": "linkedDirection",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "updatedAt",
+                            "amount",
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort field",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "sortOrder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Field conditions as field:op:value",
+                        "name": "where",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/exports/{exportId}/manifest": {
+            "get": {
+                "description": "Get the signed manifest of a finished export, including the SHA-256 of the exported file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Get export manifest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export ID (X-Export-ID header)",
+                        "name": "exportId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ExportManifest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/imports": {
+            "get": {
+                "description": "List recent import jobs on a channel (without row results)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "List import jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ImportJob"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a CSV or NDJSON file whose rows are mapped to a document type. Every row is validated before any is submitted; valid rows are created in the background with bounded concurrency. Rows without a mapped id column get IDs derived from their content, so re-running with the same importKey skips rows that already succeeded. The default importKey is derived from the mapping, so re-running with a corrected file and the same mapping resumes the import.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Start bulk import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV (header row first) or NDJSON file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document type of every row",
+                        "name": "documentTypeId",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON models.ImportMapping from document fields to columns",
+                        "name": "mapping",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format, inferred from the file extension when omitted",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that scopes row IDs; reuse it to resume an import with a corrected file (defaults to a hash of the mapping)",
+                        "name": "importKey",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 4,
+                        "description": "Parallel submissions (max 16)",
+                        "name": "concurrency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate rows",
+                        "name": "dryRun",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/imports/{jobId}": {
+            "get": {
+                "description": "Get progress and per-row results of an import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Get import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "VALID",
+                            "CREATED",
+                            "SKIPPED",
+                            "INVALID",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Only include rows with this status",
+                        "name": "rowStatus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/transactions/{txId}": {
+            "get": {
+                "description": "Report the commit status of a transaction, e.g. one submitted with async=true. Status is PENDING until the transaction is on the ledger, then VALID or the peer's validation code (MVCC_READ_CONFLICT, ENDORSEMENT_POLICY_FAILURE, ...).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get transaction status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fabric transaction ID",
+                        "name": "txId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TransactionStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/{channel}/transfers/acknowledge": {
+            "post": {
+                "description": "Acknowledge a received cross-channel transfer. Creates linked document on target channel. There is no async mode: the source document is then linked to the acknowledgment by its committed content hash, so the request always waits for both commits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Acknowledge transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target channel (union, state, region)",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Acknowledgment details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcknowledgeTransferRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the original response when a request is retried with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TransferResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/config": {
+            "get": {
+                "description": "Show writable channels for this backend instance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Configuration info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the API is running",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "status: healthy",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Report that the process is running and serving HTTP. Does not contact Fabric.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "status: alive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Probe every configured channel: peer connection, identity certificate validity, chaincode definition and a chaincode query, each within a timeout. Returns 503 when a required channel is not ready; optional channels only degrade the status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "context": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "details": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "retriable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AcknowledgeTransferRequest": {
+            "type": "object",
+            "required": [
+                "documentTypeId",
+                "sourceChannel",
+                "sourceDocId",
+                "title"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "description": {
+                    "type": "string"
+                },
+                "documentTypeId": {
+                    "type": "string"
+                },
+                "sourceChannel": {
                     "type": "string"
                 },
                 "sourceDocId": {
@@ -724,22 +1814,38 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ActionResult": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "models.AnchorVerification": {
             "type": "object",
             "properties": {
                 "amountMatch": {
+                    "description": "true if target.amount == source.amount",
                     "type": "boolean"
                 },
                 "channelMatch": {
+                    "description": "true if target.linkedChannel == source.channel",
                     "type": "boolean"
                 },
                 "hashMatch": {
+                    "description": "true if targetLinkedDocHash == sourceContentHash",
                     "type": "boolean"
                 },
                 "idMatch": {
+                    "description": "true if target.linkedDocId == source.id",
                     "type": "boolean"
                 },
                 "isValid": {
+                    "description": "true if all matches are true",
                     "type": "boolean"
                 },
                 "mismatchReason": {
@@ -754,13 +1860,14 @@ const docTemplate = `{
                 "sourceChannel": {
                     "type": "string"
                 },
-                "sourceCurrency": {
+                "sourceContentHash": {
+                    "description": "Hash of the source document's content",
                     "type": "string"
                 },
-                "sourceDocId": {
+                "sourceCurrency": {
                     "type": "string"
                 },
-                "sourceHash": {
+                "sourceDocId": {
                     "type": "string"
                 },
                 "status": {
@@ -773,17 +1880,129 @@ const docTemplate = `{
                 "targetChannel": {
                     "type": "string"
                 },
-                "targetCurrency": {
-                    "type": "string"
+                "targetContentHash": {
+                    "description": "Hash of the target document's content (for reference)",
+                    "type": "string"
+                },
+                "targetCurrency": {
+                    "type": "string"
+                },
+                "targetDocId": {
+                    "type": "string"
+                },
+                "targetLinkedDocHash": {
+                    "description": "The anchor: hash stored in target doc pointing to source",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CertificateDiagnostic": {
+            "type": "object",
+            "properties": {
+                "expectedName": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "peer": {
+                    "type": "string"
+                },
+                "problem": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChannelHealth": {
+            "type": "object",
+            "properties": {
+                "certificateError": {
+                    "$ref": "#/definitions/models.CertificateDiagnostic"
+                },
+                "certificateExpiresAt": {
+                    "type": "string"
+                },
+                "chaincodeSequence": {
+                    "type": "integer"
+                },
+                "chaincodeVersion": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failovers": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CouchDBIndex": {
+            "type": "object",
+            "properties": {
+                "ddoc": {
+                    "type": "string"
+                },
+                "index": {
+                    "$ref": "#/definitions/models.CouchDBIndexFields"
                 },
-                "targetDocId": {
+                "name": {
                     "type": "string"
                 },
-                "targetHash": {
+                "type": {
                     "type": "string"
                 }
             }
         },
+        "models.CouchDBIndexFields": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "models.CreateDocumentRequest": {
             "type": "object",
             "required": [
@@ -861,7 +2080,6 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "createdAt": {
-                    "description": "Audit trail",
                     "type": "string"
                 },
                 "createdBy": {
@@ -896,21 +2114,18 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "invalidatedBy": {
-                    "description": "Invalidation tracking - will be empty strings if not invalidated",
                     "type": "string"
                 },
                 "linkedChannel": {
                     "type": "string"
                 },
                 "linkedDirection": {
-                    "description": "\"OUTGOING\" or \"INCOMING\" or \"\"",
                     "type": "string"
                 },
                 "linkedDocHash": {
                     "type": "string"
                 },
                 "linkedDocId": {
-                    "description": "Cross-channel linking (simple fields instead of complex Anchor struct)\nWill be empty strings if not set",
                     "type": "string"
                 },
                 "organizationId": {
@@ -979,27 +2194,117 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.Envelope": {
             "type": "object",
             "properties": {
-                "code": {
+                "data": {},
+                "meta": {
+                    "$ref": "#/definitions/models.Meta"
+                }
+            }
+        },
+        "models.ErrorEnvelope": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.APIError"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.Meta"
+                }
+            }
+        },
+        "models.ExportFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "ndjson",
+                "parquet"
+            ],
+            "x-enum-varnames": [
+                "ExportFormatCSV",
+                "ExportFormatNDJSON",
+                "ExportFormatParquet"
+            ]
+        },
+        "models.ExportManifest": {
+            "type": "object",
+            "properties": {
+                "byteSize": {
+                    "type": "integer"
+                },
+                "channel": {
                     "type": "string"
                 },
-                "context": {
-                    "type": "object",
-                    "additionalProperties": true
+                "complete": {
+                    "type": "boolean"
                 },
-                "details": {
+                "completedAt": {
                     "type": "string"
                 },
+                "documentCount": {
+                    "type": "integer"
+                },
                 "error": {
                     "type": "string"
                 },
-                "request_id": {
+                "exportId": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "filter": {
+                    "$ref": "#/definitions/models.QueryFilter"
+                },
+                "filterQuery": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/models.ExportFormat"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signatureAlgorithm": {
+                    "type": "string"
+                },
+                "signerCertificate": {
+                    "type": "string"
+                },
+                "signerMspId": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldCondition": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "eq",
+                        "in",
+                        "gt",
+                        "gte",
+                        "lt",
+                        "lte",
+                        "exists"
+                    ]
+                },
+                "value": {},
+                "values": {
+                    "type": "array",
+                    "items": {}
                 }
             }
         },
@@ -1020,17 +2325,145 @@ const docTemplate = `{
                 }
             }
         },
-        "models.IDResponse": {
+        "models.ImportFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "ndjson"
+            ],
+            "x-enum-varnames": [
+                "ImportFormatCSV",
+                "ImportFormatNDJSON"
+            ]
+        },
+        "models.ImportJob": {
             "type": "object",
             "properties": {
-                "id": {
+                "channel": {
                     "type": "string"
                 },
-                "success": {
+                "created": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "documentTypeId": {
+                    "type": "string"
+                },
+                "dryRun": {
                     "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/models.ImportFormat"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "importKey": {
+                    "type": "string"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ImportStatus"
+                },
+                "totalRows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "docId": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ImportRowStatus"
                 }
             }
         },
+        "models.ImportRowStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "VALID",
+                "CREATED",
+                "SKIPPED",
+                "INVALID",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "RowStatusInvalid": "rejected by validation, never submitted",
+                "RowStatusSkipped": "already on the ledger from an earlier run",
+                "RowStatusValid": "dry run only"
+            },
+            "x-enum-descriptions": [
+                "",
+                "dry run only",
+                "",
+                "already on the ledger from an earlier run",
+                "rejected by validation, never submitted",
+                ""
+            ],
+            "x-enum-varnames": [
+                "RowStatusPending",
+                "RowStatusValid",
+                "RowStatusCreated",
+                "RowStatusSkipped",
+                "RowStatusInvalid",
+                "RowStatusFailed"
+            ]
+        },
+        "models.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RUNNING",
+                "COMPLETED",
+                "COMPLETED_WITH_ERRORS",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "ImportStatusPending",
+                "ImportStatusRunning",
+                "ImportStatusCompleted",
+                "ImportStatusCompletedWithErrors",
+                "ImportStatusFailed"
+            ]
+        },
         "models.InitiateTransferRequest": {
             "type": "object",
             "required": [
@@ -1100,32 +2533,180 @@ const docTemplate = `{
                 }
             }
         },
-        "models.QueryResult": {
+        "models.Meta": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Pagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "nextBookmark": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalEstimated": {
+                    "description": "true if an estimated count stopped at 1000 matches: total is then a lower bound",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.QueryFilter": {
             "type": "object",
             "properties": {
                 "bookmark": {
                     "type": "string"
                 },
-                "documents": {
+                "conditions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Document"
+                        "$ref": "#/definitions/models.FieldCondition"
                     }
                 },
-                "total": {
+                "countMode": {
+                    "type": "string",
+                    "enum": [
+                        "exact",
+                        "estimated"
+                    ]
+                },
+                "documentTypeId": {
+                    "type": "string"
+                },
+                "fromDate": {
+                    "type": "string"
+                },
+                "hasLinkedDoc": {
+                    "type": "boolean"
+                },
+                "linkedDirection": {
+                    "type": "string"
+                },
+                "maxAmount": {
+                    "type": "number"
+                },
+                "minAmount": {
+                    "type": "number"
+                },
+                "organizationId": {
+                    "type": "string"
+                },
+                "pageSize": {
                     "type": "integer"
+                },
+                "sortBy": {
+                    "type": "string",
+                    "enum": [
+                        "createdAt",
+                        "updatedAt",
+                        "amount",
+                        "title"
+                    ]
+                },
+                "sortOrder": {
+                    "type": "string",
+                    "enum": [
+                        "asc",
+                        "desc"
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/models.DocumentStatus"
+                },
+                "toDate": {
+                    "type": "string"
                 }
             }
         },
-        "models.SuccessResponse": {
+        "models.ReadinessResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChannelHealth"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResourceID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SubmittedTransaction": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusUrl": {
+                    "description": "Status route under the API version of the write",
                     "type": "string"
                 },
                 "success": {
                     "type": "boolean"
+                },
+                "txId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransactionStatus": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "committed": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submittedAt": {
+                    "type": "string"
+                },
+                "txId": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
                 }
             }
         },
@@ -1199,6 +2780,18 @@ const docTemplate = `{
         {
             "description": "Verify cross-channel links",
             "name": "Verification"
+        },
+        {
+            "description": "Bulk document import from CSV and NDJSON",
+            "name": "Imports"
+        },
+        {
+            "description": "Open-data export of ledger documents with signed manifests",
+            "name": "Exports"
+        },
+        {
+            "description": "Commit status of submitted transactions",
+            "name": "Transactions"
         }
     ]
 }`
@@ -1206,11 +2799,11 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:3000",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Government Spending Blockchain API",
-	Description:      "API for managing government spending documents across Federal, State, and Municipal channels\nUses Hyperledger Fabric blockchain for immutable, transparent spending records",
+	Description:      "API for managing government spending documents across Federal, State, and Municipal channels\nUses Hyperledger Fabric blockchain for immutable, transparent spending records\n\nResponses under /api/v1 are wrapped in an envelope: {\"data\": ..., \"meta\": {\"requestId\", \"pagination\"}} on success and {\"error\": {\"code\", \"message\", \"details\", \"retriable\", \"context\"}, \"meta\": {...}} on failure.\nEvery route is also served without the version, under /api, with the unwrapped responses of earlier releases. Those routes are deprecated: their responses carry Deprecation, Sunset and Link (rel=\"successor-version\") headers.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
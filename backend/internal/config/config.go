@@ -0,0 +1,338 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Server  ServerConfig  `mapstructure:"server"`
+	Fabric  FabricConfig  `mapstructure:"fabric"`
+	Logging LoggingConfig `mapstructure:"logging"`
+	Tracing TracingConfig `mapstructure:"tracing"`
+}
+
+type ServerConfig struct {
+	Port      string          `mapstructure:"port"`
+	Mode      string          `mapstructure:"mode"`
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	CORS      CORSConfig      `mapstructure:"cors"`
+	GRPC      GRPCConfig      `mapstructure:"grpc"`
+	GraphQL   GraphQLConfig   `mapstructure:"graphql"`
+	// TrustedProxies are the IPs and CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are believed when identifying the client. None are
+	// trusted by default.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// GRPCConfig is the gRPC API, served next to the REST API on its own port.
+// An empty port disables it. Reflection lets tools such as grpcurl discover
+// the services without the proto files.
+type GRPCConfig struct {
+	Port       string `mapstructure:"port"`
+	Reflection bool   `mapstructure:"reflection"`
+}
+
+// GraphQLConfig is the read-only GraphQL endpoint at /graphql. Queries deeper
+// than MaxDepth or costlier than MaxCost are rejected before they run; zero
+// selects the defaults (10 and 1000).
+type GraphQLConfig struct {
+	Enabled  bool `mapstructure:"enabled"`
+	MaxDepth int  `mapstructure:"max_depth"`
+	MaxCost  int  `mapstructure:"max_cost"`
+}
+
+// CORSConfig is the cross-origin policy. Origins may be "*" (any origin,
+// without credentials) or contain one "*" wildcard, e.g. "https://*.gov.br".
+type CORSConfig struct {
+	AllowedOrigins   []string      `mapstructure:"allowed_origins"`
+	AllowedMethods   []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string      `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string      `mapstructure:"exposed_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// RateLimitConfig sets the per-client token buckets for API reads and writes,
+// optionally per channel.
+type RateLimitConfig struct {
+	Enabled  bool                              `mapstructure:"enabled"`
+	Read     RateLimitRule                     `mapstructure:"read"`
+	Write    RateLimitRule                     `mapstructure:"write"`
+	Channels map[string]ChannelRateLimitConfig `mapstructure:"channels"`
+}
+
+type ChannelRateLimitConfig struct {
+	Read  RateLimitRule `mapstructure:"read"`
+	Write RateLimitRule `mapstructure:"write"`
+}
+
+// RateLimitRule is a token bucket refilled at Rate tokens per second and
+// holding at most Burst tokens.
+type RateLimitRule struct {
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
+type FabricConfig struct {
+	NetworkPath   string                   `mapstructure:"network_path"`
+	ChaincodeName string                   `mapstructure:"chaincode_name"`
+	Channels      map[string]ChannelConfig `mapstructure:"channels"`
+	Retry         RetryConfig              `mapstructure:"retry"`
+	Connection    ConnectionConfig         `mapstructure:"connection"`
+	Health        HealthConfig             `mapstructure:"health"`
+	Timeouts      TimeoutsConfig           `mapstructure:"timeouts"`
+}
+
+// TimeoutsConfig sets the gateway call timeouts, optionally per transaction.
+type TimeoutsConfig struct {
+	TimeoutConfig `mapstructure:",squash"`
+	Operations    map[string]TimeoutConfig `mapstructure:"operations"`
+}
+
+type TimeoutConfig struct {
+	Evaluate     time.Duration `mapstructure:"evaluate"`
+	Endorse      time.Duration `mapstructure:"endorse"`
+	Submit       time.Duration `mapstructure:"submit"`
+	CommitStatus time.Duration `mapstructure:"commit_status"`
+}
+
+type HealthConfig struct {
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type ConnectionConfig struct {
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	FailoverAfter  time.Duration `mapstructure:"failover_after"`
+	DrainTimeout   time.Duration `mapstructure:"drain_timeout"`
+}
+
+type RetryConfig struct {
+	Evaluate   RetryPolicyConfig            `mapstructure:"evaluate"`
+	Submit     RetryPolicyConfig            `mapstructure:"submit"`
+	Operations map[string]RetryPolicyConfig `mapstructure:"operations"`
+}
+
+type RetryPolicyConfig struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	Budget         time.Duration `mapstructure:"budget"`
+}
+
+type ChannelConfig struct {
+	Name          string         `mapstructure:"name"`
+	MspID         string         `mapstructure:"msp_id"`
+	PeerEndpoint  string         `mapstructure:"peer_endpoint"`
+	PeerHostAlias string         `mapstructure:"peer_host_alias"`
+	CryptoPath    string         `mapstructure:"crypto_path"`
+	TLSEnabled    *bool          `mapstructure:"tls_enabled"`
+	TLS           TLSConfig      `mapstructure:"tls"`
+	UserName      string         `mapstructure:"user_name"`
+	Writable      *bool          `mapstructure:"writable"`
+	Peers         []PeerConfig   `mapstructure:"peers"`
+	Optional      bool           `mapstructure:"optional"`
+	Timeouts      TimeoutsConfig `mapstructure:"timeouts"`
+}
+
+// TLSConfig sets the files used to secure the channel's peer connections.
+// Relative paths are resolved against fabric.network_path.
+type TLSConfig struct {
+	// CACert is a PEM bundle of the CAs peer certificates are checked
+	// against. By default each peer's crypto-config tls/ca.crt is used.
+	CACert string `mapstructure:"ca_cert"`
+	// ClientCert and ClientKey are presented to peers that require mutual TLS.
+	ClientCert string `mapstructure:"client_cert"`
+	ClientKey  string `mapstructure:"client_key"`
+}
+
+type PeerConfig struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	HostAlias string `mapstructure:"host_alias"`
+}
+
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	ServiceName string  `mapstructure:"service_name"`
+}
+
+type LoggingConfig struct {
+	Level  string `mapstructure:"level"`
+	Format string `mapstructure:"format"`
+}
+
+// EnvPrefix prefixes the environment variables that override configuration
+// keys: fabric.channels.union.peer_endpoint is GOV_FABRIC_CHANNELS_UNION_PEER_ENDPOINT.
+// List values are comma-separated.
+const EnvPrefix = "GOV"
+
+// legacyEnv maps keys to the unprefixed variables older deployments set.
+var legacyEnv = map[string]string{
+	"server.port":         "PORT",
+	"server.mode":         "GIN_MODE",
+	"fabric.network_path": "FABRIC_NETWORK_PATH",
+	"logging.level":       "LOG_LEVEL",
+}
+
+// Load reads the configuration file, applies environment overrides and
+// validates the result. path defaults to ./config.yaml.
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName("config")
+		v.AddConfigPath(".")
+	}
+	v.SetDefault("server.port", "3000")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	if err := bindEnv(v); err != nil {
+		return nil, fmt.Errorf("failed to bind environment: %w", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// bindEnv registers every key of the schema with viper. AutomaticEnv only
+// consults the environment for keys viper already knows, so without this a
+// key absent from the file could not be set from the environment. Keys under
+// a map are bound for the entries present in the file.
+func bindEnv(v *viper.Viper) error {
+	keys := schemaKeys("", reflect.TypeOf(Config{}))
+	for channel := range v.GetStringMap("fabric.channels") {
+		keys = append(keys, schemaKeys("fabric.channels."+channel, reflect.TypeOf(ChannelConfig{}))...)
+	}
+
+	for _, key := range keys {
+		envs := []string{envName(key)}
+		if legacy, ok := legacyEnv[key]; ok {
+			envs = append(envs, legacy)
+		}
+		if err := v.BindEnv(append([]string{key}, envs...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// schemaKeys lists the keys of the scalar and list fields of t. Maps and lists
+// of structs have no fixed keys and are skipped.
+func schemaKeys(prefix string, t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if opts == "squash" {
+			keys = append(keys, schemaKeys(prefix, field.Type)...)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			keys = append(keys, schemaKeys(key, field.Type)...)
+		case reflect.Map:
+		case reflect.Slice:
+			if field.Type.Elem().Kind() != reflect.Struct {
+				keys = append(keys, key)
+			}
+		default:
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (c *Config) GetChannelConfig(key string) (ChannelConfig, bool) {
+	ch, ok := c.Fabric.Channels[key]
+	return ch, ok
+}
+
+func (c *Config) ValidChannels() []string {
+	keys := make([]string, 0, len(c.Fabric.Channels))
+	for k := range c.Fabric.Channels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsAdminChannel reports whether this instance may write to the channel.
+// Channels without an explicit writable setting are writable when the
+// instance uses the organization's Admin identity on them.
+func (c *Config) IsAdminChannel(key string) bool {
+	ch, ok := c.Fabric.Channels[key]
+	if !ok {
+		return false
+	}
+	if ch.Writable != nil {
+		return *ch.Writable
+	}
+	return ch.userName() == defaultUserName
+}
+
+func (c *Config) GetWritableChannels() []string {
+	var out []string
+	for _, k := range c.ValidChannels() {
+		if c.IsAdminChannel(k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// UsesTLS reports whether the channel's peers are reached over TLS. It is
+// the default; plaintext must be asked for with tls_enabled: false.
+func (ch ChannelConfig) UsesTLS() bool {
+	return ch.TLSEnabled == nil || *ch.TLSEnabled
+}
+
+// NetworkFile resolves a path from the config against fabric.network_path.
+func (c *Config) NetworkFile(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.Fabric.NetworkPath, path)
+}
+
+const defaultUserName = "Admin"
+
+func (ch ChannelConfig) userName() string {
+	if ch.UserName == "" {
+		return defaultUserName
+	}
+	return ch.UserName
+}
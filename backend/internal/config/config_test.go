@@ -0,0 +1,228 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixtureNetwork creates the crypto material layout the gateway reads, for
+// every organization and user the deployment configs use, and points
+// fabric.network_path at it.
+func fixtureNetwork(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, org := range []string{"union", "state", "region"} {
+		domain := org + ".gov.br"
+		orgPath := filepath.Join(root, "crypto-config", "peerOrganizations", domain)
+		for _, user := range []string{"Admin", "User1"} {
+			for _, dir := range []string{"signcerts", "keystore"} {
+				mkdir(t, filepath.Join(orgPath, "users", user+"@"+domain, "msp", dir))
+			}
+		}
+		for _, peer := range []string{"peer0", "peer1"} {
+			tlsPath := filepath.Join(orgPath, "peers", peer+"."+domain, "tls")
+			mkdir(t, tlsPath)
+			if err := os.WriteFile(filepath.Join(tlsPath, "ca.crt"), []byte("fixture"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	t.Setenv("GOV_FABRIC_NETWORK_PATH", root)
+	return root
+}
+
+func mkdir(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDeploymentConfigs(t *testing.T) {
+	tests := []struct {
+		file     string
+		writable []string
+	}{
+		{"config.yaml", []string{"region", "state", "union"}},
+		{"config-union.yaml", []string{"union"}},
+		{"config-state.yaml", []string{"state"}},
+		{"config-region.yaml", []string{"region"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			fixtureNetwork(t)
+
+			cfg, err := Load(filepath.Join("..", "..", tt.file))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got := cfg.ValidChannels(); !reflect.DeepEqual(got, []string{"region", "state", "union"}) {
+				t.Errorf("ValidChannels = %v", got)
+			}
+			if got := cfg.GetWritableChannels(); !reflect.DeepEqual(got, tt.writable) {
+				t.Errorf("GetWritableChannels = %v, want %v", got, tt.writable)
+			}
+		})
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	fixtureNetwork(t)
+
+	cfg, err := Load(filepath.Join("testdata", "minimal.yaml"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != "3000" {
+		t.Errorf("Server.Port = %q, want default 3000", cfg.Server.Port)
+	}
+	// No writable setting and no user: the Admin identity is used, so the
+	// channel is writable.
+	if !cfg.IsAdminChannel("union") {
+		t.Error("union should be writable with the default Admin identity")
+	}
+	if cfg.IsAdminChannel("federal") {
+		t.Error("unknown channels must not be writable")
+	}
+}
+
+func TestLoadEnvironmentOverrides(t *testing.T) {
+	fixtureNetwork(t)
+
+	t.Setenv("GOV_SERVER_PORT", "8080")
+	t.Setenv("PORT", "9090")
+	t.Setenv("GIN_MODE", "release")
+	t.Setenv("GOV_FABRIC_CHANNELS_UNION_PEER_ENDPOINT", "peer0.union.gov.br:7051")
+	t.Setenv("GOV_FABRIC_CHANNELS_UNION_WRITABLE", "false")
+	t.Setenv("GOV_FABRIC_TIMEOUTS_ENDORSE", "45s")
+	t.Setenv("GOV_SERVER_CORS_ALLOWED_ORIGINS", "https://portal.gov.br,https://*.audit.gov.br")
+	t.Setenv("GOV_TRACING_ENABLED", "true")
+	t.Setenv("GOV_TRACING_SAMPLE_RATIO", "0.25")
+
+	cfg, err := Load(filepath.Join("testdata", "minimal.yaml"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Server.Port != "8080" {
+		t.Errorf("Server.Port = %q, want the GOV_ variable to win over PORT", cfg.Server.Port)
+	}
+	if cfg.Server.Mode != "release" {
+		t.Errorf("Server.Mode = %q, want release from GIN_MODE", cfg.Server.Mode)
+	}
+	if got := cfg.Fabric.Channels["union"].PeerEndpoint; got != "peer0.union.gov.br:7051" {
+		t.Errorf("union PeerEndpoint = %q", got)
+	}
+	if cfg.IsAdminChannel("union") {
+		t.Error("union should be read-only after GOV_FABRIC_CHANNELS_UNION_WRITABLE=false")
+	}
+	if cfg.Fabric.Timeouts.Endorse != 45*time.Second {
+		t.Errorf("Fabric.Timeouts.Endorse = %s", cfg.Fabric.Timeouts.Endorse)
+	}
+	want := []string{"https://portal.gov.br", "https://*.audit.gov.br"}
+	if !reflect.DeepEqual(cfg.Server.CORS.AllowedOrigins, want) {
+		t.Errorf("CORS.AllowedOrigins = %v, want %v", cfg.Server.CORS.AllowedOrigins, want)
+	}
+	if !cfg.Tracing.Enabled || cfg.Tracing.SampleRatio != 0.25 {
+		t.Errorf("Tracing = %+v", cfg.Tracing)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	root := fixtureNetwork(t)
+
+	_, err := Load(filepath.Join("testdata", "invalid.yaml"))
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Load error = %v, want *ValidationError", err)
+	}
+
+	want := []string{
+		`server.port: "http" is not a valid port`,
+		`server.mode: "production" is not one of debug, release, test`,
+		`server.grpc.port: "70000" is not a valid port`,
+		`server.graphql.max_depth: must not be negative`,
+		`server.trusted_proxies[0]: "10.0.0.0/33" is not an IP address or CIDR`,
+		`server.rate_limit.read.rate: must not be negative`,
+		`server.rate_limit.channels.federal: unknown channel`,
+		`fabric.chaincode_name: is required`,
+		`fabric.channels.union.name: channel "union-channel" is already configured as state`,
+		`fabric.channels.union.msp_id: UnionMSP is already used by channel state`,
+		`fabric.channels.state.peer_endpoint: "peer0.state.gov.br:99999" has an invalid port`,
+		`fabric.channels.state.peer_host_alias: is required`,
+		`fabric.channels.state.peers[0].endpoint: ":9051" has no host`,
+		`fabric.channels.state.user_name: ` + filepath.Join(root, "crypto-config", "peerOrganizations", "state.gov.br", "users", "Auditor@state.gov.br", "msp", "signcerts") + ` does not exist`,
+		`fabric.channels.state.tls: client_cert and client_key must be set together`,
+		`fabric.channels.state.tls.client_cert: ` + filepath.Join(root, "tls", "client.crt") + ` does not exist`,
+		`fabric.channels.union.peer_endpoint: "localhost" is not host:port`,
+		`fabric.channels.union.crypto_path: ` + filepath.Join(root, "crypto-config", "peerOrganizations", "missing.gov.br") + ` does not exist`,
+		`fabric.timeouts.evaluate: must not be negative`,
+		`fabric.retry.submit: initial_backoff 5s exceeds max_backoff 1s`,
+		`logging.level: "verbose" is not a log level`,
+		`logging.format: "xml" is not one of json, console`,
+		`tracing.exporter: "zipkin" is not one of otlp, stdout`,
+		`tracing.sample_ratio: 2 is not between 0 and 1`,
+	}
+
+	problems := strings.Join(validationErr.Problems, "\n")
+	for _, problem := range want {
+		if !strings.Contains(problems, problem) {
+			t.Errorf("missing problem %q", problem)
+		}
+	}
+	if t.Failed() {
+		t.Logf("reported problems:\n%s", problems)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join("testdata", "does-not-exist.yaml")); err == nil {
+		t.Fatal("Load of a missing file should fail")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	fixtureNetwork(t)
+
+	old, err := Load(filepath.Join("..", "..", "config.yaml"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	updated, err := Load(filepath.Join("..", "..", "config.yaml"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if changes := Diff(old, updated); len(changes) != 0 {
+		t.Fatalf("Diff of identical configs = %v", changes)
+	}
+
+	union := updated.Fabric.Channels["union"]
+	union.PeerEndpoint = "peer1.union.gov.br:8051"
+	readOnly := false
+	union.Writable = &readOnly
+	updated.Fabric.Channels["union"] = union
+	delete(updated.Fabric.Channels, "region")
+	updated.Fabric.Channels["federal"] = ChannelConfig{Name: "federal-channel"}
+	updated.Fabric.Timeouts.Endorse = 30 * time.Second
+
+	var got []string
+	for _, change := range Diff(old, updated) {
+		got = append(got, change.String())
+	}
+	want := []string{
+		"fabric.channels.federal: added",
+		"fabric.channels.region: removed",
+		"fabric.channels.union.peer_endpoint: localhost:7051 -> peer1.union.gov.br:8051",
+		"fabric.channels.union.writable: true -> false",
+		"fabric.timeouts.endorse: 15s -> 30s",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
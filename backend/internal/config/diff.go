@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Change is a setting that differs between two configurations. Old is empty
+// when a channel or other map entry was added, and New when it was removed.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == "":
+		return c.Key + ": added"
+	case c.New == "":
+		return c.Key + ": removed"
+	default:
+		return fmt.Sprintf("%s: %s -> %s", c.Key, c.Old, c.New)
+	}
+}
+
+// Diff lists the settings that differ between two configurations, keyed as
+// in the config file, e.g. fabric.channels.union.peer_endpoint. Lists are
+// compared as a whole.
+func Diff(old, updated *Config) []Change {
+	var changes []Change
+	diffValue(&changes, "", reflect.ValueOf(*old), reflect.ValueOf(*updated))
+	return changes
+}
+
+func diffValue(changes *[]Change, key string, old, updated reflect.Value) {
+	switch old.Kind() {
+	case reflect.Struct:
+		for i := 0; i < old.NumField(); i++ {
+			field := old.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if opts == "squash" {
+				diffValue(changes, key, old.Field(i), updated.Field(i))
+				continue
+			}
+			if name == "" {
+				continue
+			}
+			diffValue(changes, joinKey(key, name), old.Field(i), updated.Field(i))
+		}
+
+	case reflect.Map:
+		keys := make(map[string]bool)
+		for _, k := range old.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, k := range updated.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, k := range sortedKeys(keys) {
+			mapKey := reflect.ValueOf(k)
+			oldEntry, updatedEntry := old.MapIndex(mapKey), updated.MapIndex(mapKey)
+			switch {
+			case !oldEntry.IsValid():
+				*changes = append(*changes, Change{Key: joinKey(key, k), New: "added"})
+			case !updatedEntry.IsValid():
+				*changes = append(*changes, Change{Key: joinKey(key, k), Old: "removed"})
+			default:
+				diffValue(changes, joinKey(key, k), oldEntry, updatedEntry)
+			}
+		}
+
+	case reflect.Pointer:
+		if old.IsNil() || updated.IsNil() {
+			if old.IsNil() != updated.IsNil() {
+				*changes = append(*changes, Change{Key: key, Old: formatValue(old), New: formatValue(updated)})
+			}
+			return
+		}
+		diffValue(changes, key, old.Elem(), updated.Elem())
+
+	default:
+		if !reflect.DeepEqual(old.Interface(), updated.Interface()) {
+			*changes = append(*changes, Change{Key: key, Old: formatValue(old), New: formatValue(updated)})
+		}
+	}
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "(unset)"
+		}
+		v = v.Elem()
+	}
+	s := fmt.Sprint(v.Interface())
+	if s == "" {
+		return `""`
+	}
+	return s
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,319 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every problem found in a configuration, so an operator
+// can fix them in one pass.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the configuration for missing settings, malformed values and
+// conflicts between channels, and that each channel's crypto material exists
+// under fabric.network_path.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	c.validateServer(v)
+	c.validateFabric(v)
+	c.validateLogging(v)
+	c.validateTracing(v)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func (c *Config) validateServer(v *validator) {
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		v.addf("server.port: %q is not a valid port", c.Server.Port)
+	}
+	if grpcPort := c.Server.GRPC.Port; grpcPort != "" {
+		if port, err := strconv.Atoi(grpcPort); err != nil || port < 1 || port > 65535 {
+			v.addf("server.grpc.port: %q is not a valid port", grpcPort)
+		} else if grpcPort == c.Server.Port {
+			v.addf("server.grpc.port: %q is already server.port", grpcPort)
+		}
+	}
+	if c.Server.GraphQL.MaxDepth < 0 {
+		v.addf("server.graphql.max_depth: must not be negative")
+	}
+	if c.Server.GraphQL.MaxCost < 0 {
+		v.addf("server.graphql.max_cost: must not be negative")
+	}
+	for i, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				v.addf("server.trusted_proxies[%d]: %q is not an IP address or CIDR", i, proxy)
+			}
+		}
+	}
+	switch c.Server.Mode {
+	case "", "debug", "release", "test":
+	default:
+		v.addf("server.mode: %q is not one of debug, release, test", c.Server.Mode)
+	}
+
+	rl := c.Server.RateLimit
+	validateRateLimitRule(v, "server.rate_limit.read", rl.Read)
+	validateRateLimitRule(v, "server.rate_limit.write", rl.Write)
+	for _, key := range sortedKeys(rl.Channels) {
+		if _, ok := c.Fabric.Channels[key]; !ok {
+			v.addf("server.rate_limit.channels.%s: unknown channel", key)
+		}
+		validateRateLimitRule(v, "server.rate_limit.channels."+key+".read", rl.Channels[key].Read)
+		validateRateLimitRule(v, "server.rate_limit.channels."+key+".write", rl.Channels[key].Write)
+	}
+
+	validateNonNegative(v, "server.cors.max_age", c.Server.CORS.MaxAge)
+}
+
+func validateRateLimitRule(v *validator, key string, rule RateLimitRule) {
+	if rule.Rate < 0 {
+		v.addf("%s.rate: must not be negative", key)
+	}
+	if rule.Burst < 0 {
+		v.addf("%s.burst: must not be negative", key)
+	}
+}
+
+func (c *Config) validateFabric(v *validator) {
+	f := c.Fabric
+	if f.NetworkPath == "" {
+		v.addf("fabric.network_path: is required")
+	}
+	if f.ChaincodeName == "" {
+		v.addf("fabric.chaincode_name: is required")
+	}
+	if len(f.Channels) == 0 {
+		v.addf("fabric.channels: at least one channel is required")
+	}
+
+	mspOwners := make(map[string]string)
+	nameOwners := make(map[string]string)
+	for _, key := range c.ValidChannels() {
+		ch := f.Channels[key]
+		prefix := "fabric.channels." + key
+
+		if ch.Name == "" {
+			v.addf("%s.name: is required", prefix)
+		} else if other, dup := nameOwners[ch.Name]; dup {
+			v.addf("%s.name: channel %q is already configured as %s", prefix, ch.Name, other)
+		} else {
+			nameOwners[ch.Name] = key
+		}
+
+		if ch.MspID == "" {
+			v.addf("%s.msp_id: is required", prefix)
+		} else if other, dup := mspOwners[ch.MspID]; dup {
+			v.addf("%s.msp_id: %s is already used by channel %s", prefix, ch.MspID, other)
+		} else {
+			mspOwners[ch.MspID] = key
+		}
+
+		validateEndpoint(v, prefix+".peer_endpoint", ch.PeerEndpoint)
+		if ch.PeerHostAlias == "" {
+			v.addf("%s.peer_host_alias: is required", prefix)
+		}
+		for i, peer := range ch.Peers {
+			validateEndpoint(v, fmt.Sprintf("%s.peers[%d].endpoint", prefix, i), peer.Endpoint)
+			if peer.HostAlias == "" {
+				v.addf("%s.peers[%d].host_alias: is required", prefix, i)
+			}
+		}
+
+		if ch.CryptoPath == "" {
+			v.addf("%s.crypto_path: is required", prefix)
+		} else if f.NetworkPath != "" {
+			c.validateCryptoMaterial(v, prefix, ch)
+		}
+		c.validateTLS(v, prefix, ch)
+
+		validateTimeouts(v, prefix+".timeouts", ch.Timeouts)
+	}
+
+	validateTimeouts(v, "fabric.timeouts", f.Timeouts)
+	validateRetryPolicy(v, "fabric.retry.evaluate", f.Retry.Evaluate)
+	validateRetryPolicy(v, "fabric.retry.submit", f.Retry.Submit)
+	for _, name := range sortedKeys(f.Retry.Operations) {
+		validateRetryPolicy(v, "fabric.retry.operations."+name, f.Retry.Operations[name])
+	}
+	validateNonNegative(v, "fabric.connection.connect_timeout", f.Connection.ConnectTimeout)
+	validateNonNegative(v, "fabric.connection.failover_after", f.Connection.FailoverAfter)
+	validateNonNegative(v, "fabric.connection.drain_timeout", f.Connection.DrainTimeout)
+	validateNonNegative(v, "fabric.health.timeout", f.Health.Timeout)
+}
+
+// validateCryptoMaterial checks the directories the gateway reads the
+// channel's identity and the peers' TLS CA certificates from.
+func (c *Config) validateCryptoMaterial(v *validator, prefix string, ch ChannelConfig) {
+	orgPath := filepath.Join(c.Fabric.NetworkPath, "crypto-config", ch.CryptoPath)
+	if !isDir(orgPath) {
+		v.addf("%s.crypto_path: %s does not exist", prefix, orgPath)
+		return
+	}
+
+	domain := filepath.Base(ch.CryptoPath)
+	mspPath := filepath.Join(orgPath, "users", ch.userName()+"@"+domain, "msp")
+	for _, dir := range []string{"signcerts", "keystore"} {
+		if path := filepath.Join(mspPath, dir); !isDir(path) {
+			v.addf("%s.user_name: %s does not exist", prefix, path)
+		}
+	}
+
+	if !ch.UsesTLS() || ch.TLS.CACert != "" {
+		return
+	}
+	aliases := map[string]string{prefix + ".peer_host_alias": ch.PeerHostAlias}
+	for i, peer := range ch.Peers {
+		aliases[fmt.Sprintf("%s.peers[%d].host_alias", prefix, i)] = peer.HostAlias
+	}
+	for _, key := range sortedKeys(aliases) {
+		if aliases[key] == "" {
+			continue
+		}
+		if path := filepath.Join(orgPath, "peers", aliases[key], "tls", "ca.crt"); !isFile(path) {
+			v.addf("%s: TLS CA certificate %s does not exist", key, path)
+		}
+	}
+}
+
+// validateTLS checks the channel's TLS files. A client certificate needs its
+// key, and neither is used over plaintext.
+func (c *Config) validateTLS(v *validator, prefix string, ch ChannelConfig) {
+	t := ch.TLS
+	if !ch.UsesTLS() {
+		if t.CACert != "" || t.ClientCert != "" || t.ClientKey != "" {
+			v.addf("%s.tls: set but tls_enabled is false", prefix)
+		}
+		return
+	}
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		v.addf("%s.tls: client_cert and client_key must be set together", prefix)
+	}
+	files := map[string]string{"ca_cert": t.CACert, "client_cert": t.ClientCert, "client_key": t.ClientKey}
+	for _, name := range sortedKeys(files) {
+		if files[name] == "" {
+			continue
+		}
+		if path := c.NetworkFile(files[name]); !isFile(path) {
+			v.addf("%s.tls.%s: %s does not exist", prefix, name, path)
+		}
+	}
+}
+
+func validateEndpoint(v *validator, key, endpoint string) {
+	if endpoint == "" {
+		v.addf("%s: is required", key)
+		return
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		v.addf("%s: %q is not host:port", key, endpoint)
+		return
+	}
+	if host == "" {
+		v.addf("%s: %q has no host", key, endpoint)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		v.addf("%s: %q has an invalid port", key, endpoint)
+	}
+}
+
+func validateTimeouts(v *validator, key string, cfg TimeoutsConfig) {
+	validateTimeout(v, key, cfg.TimeoutConfig)
+	for _, name := range sortedKeys(cfg.Operations) {
+		validateTimeout(v, key+".operations."+name, cfg.Operations[name])
+	}
+}
+
+func validateTimeout(v *validator, key string, cfg TimeoutConfig) {
+	validateNonNegative(v, key+".evaluate", cfg.Evaluate)
+	validateNonNegative(v, key+".endorse", cfg.Endorse)
+	validateNonNegative(v, key+".submit", cfg.Submit)
+	validateNonNegative(v, key+".commit_status", cfg.CommitStatus)
+}
+
+func validateRetryPolicy(v *validator, key string, cfg RetryPolicyConfig) {
+	if cfg.MaxAttempts < 0 {
+		v.addf("%s.max_attempts: must not be negative", key)
+	}
+	validateNonNegative(v, key+".initial_backoff", cfg.InitialBackoff)
+	validateNonNegative(v, key+".max_backoff", cfg.MaxBackoff)
+	validateNonNegative(v, key+".budget", cfg.Budget)
+	if cfg.InitialBackoff > 0 && cfg.MaxBackoff > 0 && cfg.InitialBackoff > cfg.MaxBackoff {
+		v.addf("%s: initial_backoff %s exceeds max_backoff %s", key, cfg.InitialBackoff, cfg.MaxBackoff)
+	}
+}
+
+func validateNonNegative(v *validator, key string, d time.Duration) {
+	if d < 0 {
+		v.addf("%s: must not be negative", key)
+	}
+}
+
+func (c *Config) validateLogging(v *validator) {
+	switch strings.ToLower(c.Logging.Level) {
+	case "", "trace", "debug", "info", "warn", "error", "fatal", "panic", "disabled":
+	default:
+		v.addf("logging.level: %q is not a log level", c.Logging.Level)
+	}
+	switch c.Logging.Format {
+	case "", "json", "console":
+	default:
+		v.addf("logging.format: %q is not one of json, console", c.Logging.Format)
+	}
+}
+
+func (c *Config) validateTracing(v *validator) {
+	t := c.Tracing
+	switch t.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		v.addf("tracing.exporter: %q is not one of otlp, stdout", t.Exporter)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		v.addf("tracing.sample_ratio: %g is not between 0 and 1", t.SampleRatio)
+	}
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,10 +1,17 @@
 package errors
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ErrorCode string
@@ -21,6 +28,7 @@ const (
 	ErrCodeAlreadyExists   ErrorCode = "ALREADY_EXISTS"
 	ErrCodeInvalidChannel  ErrorCode = "INVALID_CHANNEL"
 	ErrCodeContractNotFound ErrorCode = "CONTRACT_NOT_FOUND"
+	ErrCodeInvalidState    ErrorCode = "INVALID_STATE"
 
 	// Validation errors (not retriable)
 	ErrCodeValidationFailed    ErrorCode = "VALIDATION_FAILED"
@@ -34,10 +42,12 @@ const (
 	ErrCodeTransactionTimeout ErrorCode = "TRANSACTION_TIMEOUT"
 	ErrCodeEndorsementFailed  ErrorCode = "ENDORSEMENT_FAILED"
 	ErrCodeCommitFailed       ErrorCode = "COMMIT_FAILED"
+	ErrCodeRequestCanceled    ErrorCode = "REQUEST_CANCELED"
 
 	// Authentication and authorization
 	ErrCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
 	ErrCodePermissionDenied   ErrorCode = "PERMISSION_DENIED"
+	ErrCodeWriteAccessDenied  ErrorCode = "WRITE_ACCESS_DENIED"
 	ErrCodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
 	ErrCodeCertificateError   ErrorCode = "CERTIFICATE_ERROR"
 
@@ -55,9 +65,17 @@ const (
 	ErrCodeLinkedDocUnavailable  ErrorCode = "LINKED_DOC_UNAVAILABLE"
 
 	// Query errors
-	ErrCodeQueryFailed   ErrorCode = "QUERY_FAILED"
-	ErrCodeQueryTimeout  ErrorCode = "QUERY_TIMEOUT"
-	ErrCodeInvalidQuery  ErrorCode = "INVALID_QUERY"
+	ErrCodeQueryFailed     ErrorCode = "QUERY_FAILED"
+	ErrCodeQueryTimeout    ErrorCode = "QUERY_TIMEOUT"
+	ErrCodeInvalidQuery    ErrorCode = "INVALID_QUERY"
+	ErrCodeQueryTooComplex ErrorCode = "QUERY_TOO_COMPLEX"
+
+	// Idempotency errors
+	ErrCodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	ErrCodeIdempotencyMismatch   ErrorCode = "IDEMPOTENCY_KEY_MISMATCH"
+
+	// Rate limiting errors
+	ErrCodeRateLimited ErrorCode = "RATE_LIMITED"
 
 	// System errors
 	ErrCodeInternalError  ErrorCode = "INTERNAL_ERROR"
@@ -65,6 +83,10 @@ const (
 	ErrCodeServiceFailure ErrorCode = "SERVICE_FAILURE"
 )
 
+// StatusClientClosedRequest is the non-standard status (introduced by nginx)
+// for requests the client abandoned before the response was written.
+const StatusClientClosedRequest = 499
+
 type AppError struct {
 	Code       ErrorCode              
 	Message    string                 
@@ -129,13 +151,13 @@ func (e *AppError) setDefaults() {
 		e.HTTPStatus = http.StatusNotFound
 		e.Retriable = false
 
-	case ErrCodeAlreadyExists:
+	case ErrCodeAlreadyExists, ErrCodeInvalidState:
 		e.HTTPStatus = http.StatusConflict
 		e.Retriable = false
 
 	case ErrCodeValidationFailed, ErrCodeInvalidInput, ErrCodeInvalidChannel,
 		ErrCodeInvalidDocumentType, ErrCodeInvalidTransfer, ErrCodeInvalidQuery,
-		ErrCodeInvalidAnchor:
+		ErrCodeInvalidAnchor, ErrCodeQueryTooComplex:
 		e.HTTPStatus = http.StatusBadRequest
 		e.Retriable = false
 
@@ -143,7 +165,7 @@ func (e *AppError) setDefaults() {
 		e.HTTPStatus = http.StatusUnauthorized
 		e.Retriable = false
 
-	case ErrCodePermissionDenied:
+	case ErrCodePermissionDenied, ErrCodeWriteAccessDenied:
 		e.HTTPStatus = http.StatusForbidden
 		e.Retriable = false
 
@@ -155,6 +177,10 @@ func (e *AppError) setDefaults() {
 		e.HTTPStatus = http.StatusGatewayTimeout
 		e.Retriable = true
 
+	case ErrCodeRequestCanceled:
+		e.HTTPStatus = StatusClientClosedRequest
+		e.Retriable = true
+
 	case ErrCodeTransactionFailed, ErrCodeEndorsementFailed, ErrCodeCommitFailed:
 		e.HTTPStatus = http.StatusInternalServerError
 		e.Retriable = true
@@ -177,17 +203,231 @@ func (e *AppError) setDefaults() {
 		e.HTTPStatus = http.StatusInternalServerError
 		e.Retriable = true
 
+	case ErrCodeIdempotencyInProgress:
+		e.HTTPStatus = http.StatusConflict
+		e.Retriable = true
+	case ErrCodeIdempotencyMismatch:
+		e.HTTPStatus = http.StatusUnprocessableEntity
+		e.Retriable = false
+
+	case ErrCodeRateLimited:
+		e.HTTPStatus = http.StatusTooManyRequests
+		e.Retriable = true
+
 	default:
 		e.HTTPStatus = http.StatusInternalServerError
 		e.Retriable = false
 	}
 }
 
+// ParseBlockchainError classifies a Fabric error. Structured chaincode errors
+// and gateway status codes are decoded first; message matching is only a
+// fallback for errors that carry neither (older chaincode, raw stub errors).
 func ParseBlockchainError(err error, operation string) *AppError {
 	if err == nil {
 		return nil
 	}
 
+	appErr := parseStructuredError(err, operation)
+	if appErr == nil {
+		appErr = parseErrorMessage(err, operation)
+	}
+	if txID := transactionID(err); txID != "" {
+		appErr.WithContext("txId", txID)
+	}
+	var retried retryAttempts
+	if errors.As(err, &retried) {
+		appErr.WithContext("attempts", retried.RetryAttempts())
+	}
+	return appErr
+}
+
+// retryAttempts is implemented by errors returned after the Fabric client
+// retried an operation.
+type retryAttempts interface {
+	RetryAttempts() int
+}
+
+// commitFailure is implemented by commit errors that carry the peer's
+// transaction validation code.
+type commitFailure interface {
+	error
+	TxID() string
+	ValidationCode() string
+}
+
+// certificateFailure is implemented by errors about a certificate that failed
+// validation, with the diagnostics to report.
+type certificateFailure interface {
+	error
+	CertificateContext() map[string]interface{}
+}
+
+// chaincodeErrorCodes maps the contract's ErrorCode values to API error codes.
+var chaincodeErrorCodes = map[string]ErrorCode{
+	"NOT_FOUND":             ErrCodeNotFound,
+	"ALREADY_EXISTS":        ErrCodeAlreadyExists,
+	"VALIDATION_FAILED":     ErrCodeValidationFailed,
+	"INVALID_DOCUMENT_TYPE": ErrCodeInvalidDocumentType,
+	"INVALID_QUERY":         ErrCodeInvalidQuery,
+	"INVALID_STATE":         ErrCodeInvalidState,
+	"PERMISSION_DENIED":     ErrCodePermissionDenied,
+	"INTERNAL_ERROR":        ErrCodeTransactionFailed,
+}
+
+// ChaincodeError is the JSON payload the contract returns as its error message.
+type ChaincodeError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// DecodeChaincodeError extracts the contract's error payload from the gRPC
+// status details attached to endorsement and evaluation failures. Peers
+// prefix the chaincode message (e.g. "chaincode response 500, {...}"), so the
+// payload is located by its opening brace.
+func DecodeChaincodeError(err error) (*ChaincodeError, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+
+	messages := []string{st.Message()}
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*gateway.ErrorDetail); ok {
+			messages = append(messages, d.Message)
+		}
+	}
+
+	for _, msg := range messages {
+		idx := strings.Index(msg, "{")
+		if idx == -1 {
+			continue
+		}
+		var payload ChaincodeError
+		if err := json.Unmarshal([]byte(msg[idx:]), &payload); err != nil || payload.Code == "" {
+			continue
+		}
+		return &payload, true
+	}
+	return nil, false
+}
+
+func parseStructuredError(err error, operation string) *AppError {
+	if payload, ok := DecodeChaincodeError(err); ok {
+		code, known := chaincodeErrorCodes[payload.Code]
+		if !known {
+			code = ErrCodeTransactionFailed
+		}
+		appErr := NewAppError(code, payload.Message, err).
+			WithContext("chaincodeCode", payload.Code)
+		for key, value := range payload.Fields {
+			appErr.WithContext(key, value)
+		}
+		return appErr
+	}
+
+	validationCode := ""
+	var commitErr *client.CommitError
+	var failure commitFailure
+	if errors.As(err, &commitErr) {
+		validationCode = commitErr.Code.String()
+	} else if errors.As(err, &failure) {
+		validationCode = failure.ValidationCode()
+	}
+	if validationCode != "" {
+		return NewAppError(
+			ErrCodeCommitFailed,
+			fmt.Sprintf("Failed to commit transaction for %s", operation),
+			err,
+		).WithContext("validationCode", validationCode).
+			WithDetails("The transaction was endorsed but could not be committed. There may have been a concurrent update.")
+	}
+
+	var certErr certificateFailure
+	if errors.As(err, &certErr) {
+		appErr := NewAppError(
+			ErrCodeCertificateError,
+			fmt.Sprintf("Certificate error during %s", operation),
+			err,
+		).WithDetails("A certificate used to reach the blockchain network failed validation. See certificateFile and certificateProblem.")
+		for key, value := range certErr.CertificateContext() {
+			appErr.WithContext(key, value)
+		}
+		return appErr
+	}
+
+	if errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled {
+		return NewAppError(
+			ErrCodeRequestCanceled,
+			fmt.Sprintf("Request canceled during %s", operation),
+			err,
+		).WithDetails("The client canceled the request before the blockchain operation completed. A submitted transaction may still commit; check its status before retrying.")
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	switch st.Code() {
+	case codes.Unavailable:
+		return NewAppError(
+			ErrCodeNetworkFailure,
+			fmt.Sprintf("Failed to connect to blockchain network during %s", operation),
+			err,
+		).WithDetails("The blockchain network is currently unreachable. Please try again later.")
+	case codes.DeadlineExceeded:
+		if isQuery(operation) {
+			return NewAppError(
+				ErrCodeQueryTimeout,
+				fmt.Sprintf("Query operation timed out: %s", operation),
+				err,
+			).WithDetails("The query took too long to execute. Try narrowing your search criteria.")
+		}
+		return NewAppError(
+			ErrCodeTransactionTimeout,
+			fmt.Sprintf("Transaction timed out during %s", operation),
+			err,
+		).WithDetails("The blockchain transaction did not complete in time. Please retry.")
+	case codes.PermissionDenied:
+		return NewAppError(
+			ErrCodePermissionDenied,
+			"Permission denied",
+			err,
+		).WithDetails("You do not have permission to perform this operation on the blockchain.")
+	}
+	return nil
+}
+
+// isQuery reports whether the operation is a query, whether named by the
+// services ("query documents") or by chaincode function ("QueryDocuments").
+func isQuery(operation string) bool {
+	return strings.Contains(strings.ToLower(operation), "query")
+}
+
+// transactionID returns the ID of the transaction an error belongs to, if any.
+func transactionID(err error) string {
+	var endorseErr *client.EndorseError
+	var submitErr *client.SubmitError
+	var statusErr *client.CommitStatusError
+	var commitErr *client.CommitError
+	var failure commitFailure
+	switch {
+	case errors.As(err, &endorseErr):
+		return endorseErr.TransactionID
+	case errors.As(err, &submitErr):
+		return submitErr.TransactionID
+	case errors.As(err, &statusErr):
+		return statusErr.TransactionID
+	case errors.As(err, &commitErr):
+		return commitErr.TransactionID
+	case errors.As(err, &failure):
+		return failure.TxID()
+	}
+	return ""
+}
+
+func parseErrorMessage(err error, operation string) *AppError {
 	errMsg := err.Error()
 	errLower := strings.ToLower(errMsg)
 
@@ -203,7 +443,7 @@ func ParseBlockchainError(err error, operation string) *AppError {
 	}
 
 	if strings.Contains(errLower, "timeout") || strings.Contains(errLower, "deadline exceeded") {
-		if strings.Contains(operation, "query") {
+		if isQuery(operation) {
 			return NewAppError(
 				ErrCodeQueryTimeout,
 				fmt.Sprintf("Query operation timed out: %s", operation),
@@ -217,6 +457,15 @@ func ParseBlockchainError(err error, operation string) *AppError {
 		).WithDetails("The blockchain transaction did not complete in time. Please retry.")
 	}
 
+	// Checked first: MVCC messages also mention conflicts and invalidation.
+	if strings.Contains(errLower, "mvcc") {
+		return NewAppError(
+			ErrCodeCommitFailed,
+			fmt.Sprintf("Failed to commit transaction for %s", operation),
+			err,
+		).WithDetails("The transaction was endorsed but could not be committed. There may have been a concurrent update.")
+	}
+
 	if strings.Contains(errLower, "not found") ||
 		strings.Contains(errLower, "does not exist") ||
 		strings.Contains(errLower, "no rows") {
@@ -416,3 +665,11 @@ func NewInvalidChannelError(channel string) *AppError {
 	).WithContext("channel", channel).
 		WithDetails("Valid channels are: union, state, region")
 }
+
+func NewWriteAccessDeniedError(channel string) *AppError {
+	return NewAppError(
+		ErrCodeWriteAccessDenied,
+		"Write access denied: this instance does not have admin privileges on channel "+channel,
+		errors.New("write access denied"),
+	).WithContext("channel", channel)
+}
@@ -0,0 +1,232 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// endorseFailure is a gateway endorsement error whose chaincode message is
+// carried by a peer's error detail rather than the status message.
+func endorseFailure(t *testing.T, peerMessage string) error {
+	t.Helper()
+	st, err := status.New(codes.Aborted, "failed to endorse transaction, see attached details for more info").
+		WithDetails(&gateway.ErrorDetail{Address: "peer0.union.gov.br:7051", MspId: "UnionMSP", Message: peerMessage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st.Err()
+}
+
+func TestDecodeChaincodeError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *ChaincodeError
+	}{
+		{
+			name: "status message",
+			err:  status.Error(codes.Unknown, `chaincode response 500, {"code":"NOT_FOUND","message":"document d1 not found","fields":{"id":"d1"}}`),
+			want: &ChaincodeError{Code: "NOT_FOUND", Message: "document d1 not found", Fields: map[string]string{"id": "d1"}},
+		},
+		{
+			name: "error detail",
+			err:  endorseFailure(t, `chaincode response 500, {"code":"ALREADY_EXISTS","message":"document d1 already exists"}`),
+			want: &ChaincodeError{Code: "ALREADY_EXISTS", Message: "document d1 already exists"},
+		},
+		{name: "plain text", err: status.Error(codes.Unknown, "chaincode response 500, document not found")},
+		{name: "JSON without a code", err: status.Error(codes.Unknown, `chaincode response 500, {"message":"boom"}`)},
+		{name: "malformed JSON", err: status.Error(codes.Unknown, `chaincode response 500, {"code":`)},
+		{name: "not a gRPC status", err: fmt.Errorf(`{"code":"NOT_FOUND","message":"x"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DecodeChaincodeError(tt.err)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("decoded %+v, want nothing", got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("nothing decoded")
+			}
+			if got.Code != tt.want.Code || got.Message != tt.want.Message || fmt.Sprint(got.Fields) != fmt.Sprint(tt.want.Fields) {
+				t.Errorf("decoded %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBlockchainError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		operation string
+		code      ErrorCode
+		status    int
+		retriable bool
+		context   map[string]interface{}
+	}{
+		{
+			name:      "contract error",
+			err:       status.Error(codes.Unknown, `chaincode response 500, {"code":"NOT_FOUND","message":"document d1 not found","fields":{"resource":"document","id":"d1"}}`),
+			operation: "get document",
+			code:      ErrCodeNotFound,
+			status:    http.StatusNotFound,
+			context:   map[string]interface{}{"chaincodeCode": "NOT_FOUND", "resource": "document", "id": "d1"},
+		},
+		{
+			name:      "contract error in an endorsement detail",
+			err:       endorseFailure(t, `chaincode response 500, {"code":"VALIDATION_FAILED","message":"missing required field: vendor","fields":{"field":"vendor"}}`),
+			operation: "create document",
+			code:      ErrCodeValidationFailed,
+			status:    http.StatusBadRequest,
+			context:   map[string]interface{}{"chaincodeCode": "VALIDATION_FAILED", "field": "vendor"},
+		},
+		{
+			name:      "contract internal error",
+			err:       status.Error(codes.Unknown, `chaincode response 500, {"code":"INTERNAL_ERROR","message":"failed to read state"}`),
+			operation: "get document",
+			code:      ErrCodeTransactionFailed,
+			status:    http.StatusInternalServerError,
+			retriable: true,
+		},
+		{
+			name:      "unknown contract code",
+			err:       status.Error(codes.Unknown, `chaincode response 500, {"code":"QUOTA_EXCEEDED","message":"too many documents"}`),
+			operation: "create document",
+			code:      ErrCodeTransactionFailed,
+			status:    http.StatusInternalServerError,
+			retriable: true,
+			context:   map[string]interface{}{"chaincodeCode": "QUOTA_EXCEEDED"},
+		},
+		{
+			name:      "peer unavailable",
+			err:       status.Error(codes.Unavailable, "connection error"),
+			operation: "create document",
+			code:      ErrCodeNetworkFailure,
+			status:    http.StatusServiceUnavailable,
+			retriable: true,
+		},
+		{
+			name:      "query deadline",
+			err:       status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			operation: "query documents",
+			code:      ErrCodeQueryTimeout,
+			status:    http.StatusGatewayTimeout,
+			retriable: true,
+		},
+		{
+			name:      "query deadline by function name",
+			err:       status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			operation: "QueryDocuments",
+			code:      ErrCodeQueryTimeout,
+			status:    http.StatusGatewayTimeout,
+			retriable: true,
+		},
+		{
+			name:      "query timeout message by function name",
+			err:       stderrors.New("evaluate call to endorser returned error: timeout expired"),
+			operation: "QueryDocuments",
+			code:      ErrCodeQueryTimeout,
+			status:    http.StatusGatewayTimeout,
+			retriable: true,
+		},
+		{
+			name:      "submit deadline",
+			err:       status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			operation: "create document",
+			code:      ErrCodeTransactionTimeout,
+			status:    http.StatusGatewayTimeout,
+			retriable: true,
+		},
+		{
+			name:      "context deadline",
+			err:       fmt.Errorf("evaluate: %w", context.DeadlineExceeded),
+			operation: "create document",
+			code:      ErrCodeTransactionTimeout,
+			status:    http.StatusGatewayTimeout,
+			retriable: true,
+		},
+		{
+			name:      "context canceled",
+			err:       fmt.Errorf("submit: %w", context.Canceled),
+			operation: "create document",
+			code:      ErrCodeRequestCanceled,
+			status:    StatusClientClosedRequest,
+			retriable: true,
+		},
+		{
+			name:      "gRPC canceled",
+			err:       status.Error(codes.Canceled, "context canceled"),
+			operation: "get document",
+			code:      ErrCodeRequestCanceled,
+			status:    StatusClientClosedRequest,
+			retriable: true,
+		},
+		{
+			name:      "permission denied",
+			err:       status.Error(codes.PermissionDenied, "access denied for channel union"),
+			operation: "create document",
+			code:      ErrCodePermissionDenied,
+			status:    http.StatusForbidden,
+		},
+		{
+			name:      "MVCC conflict",
+			err:       &client.CommitError{TransactionID: "tx-1", Code: peer.TxValidationCode_MVCC_READ_CONFLICT},
+			operation: "update document link",
+			code:      ErrCodeCommitFailed,
+			status:    http.StatusInternalServerError,
+			retriable: true,
+			context:   map[string]interface{}{"validationCode": "MVCC_READ_CONFLICT", "txId": "tx-1"},
+		},
+		{
+			name:      "MVCC conflict in a message",
+			err:       stderrors.New("transaction invalidated with status (MVCC_READ_CONFLICT)"),
+			operation: "update document link",
+			code:      ErrCodeCommitFailed,
+			status:    http.StatusInternalServerError,
+			retriable: true,
+		},
+		{
+			name:      "connection refused",
+			err:       stderrors.New("dial tcp 10.0.0.5:7051: connect: connection refused"),
+			operation: "get contract",
+			code:      ErrCodeNetworkFailure,
+			status:    http.StatusServiceUnavailable,
+			retriable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseBlockchainError(tt.err, tt.operation)
+			if got.Code != tt.code || got.HTTPStatus != tt.status || got.Retriable != tt.retriable {
+				t.Errorf("got %s %d retriable=%v, want %s %d retriable=%v",
+					got.Code, got.HTTPStatus, got.Retriable, tt.code, tt.status, tt.retriable)
+			}
+			for key, want := range tt.context {
+				if got.Context[key] != want {
+					t.Errorf("context[%s] = %v, want %v", key, got.Context[key], want)
+				}
+			}
+			if !stderrors.Is(got, tt.err) {
+				t.Error("the AppError does not wrap the original error")
+			}
+		})
+	}
+
+	if ParseBlockchainError(nil, "get document") != nil {
+		t.Error("a nil error was classified")
+	}
+}
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo attached to gRPC
+// errors, whose reason is the ErrorCode.
+const ErrorDomain = "gov-spending"
+
+// publicContextFields are the context keys safe to return to API clients.
+var publicContextFields = []string{
+	"channel", "operation", "step", "documentTypeId", "typeId", "chaincodeCode", "field", "resource",
+	"txId", "validationCode", "attempts", "certificateFile", "certificatePeer", "certificateProblem",
+	"certificateExpiresAt", "expectedName", "certificateNames", "depth", "maxDepth", "cost", "maxCost",
+}
+
+// PublicContext returns the context entries that may be returned to clients.
+func (e *AppError) PublicContext() map[string]interface{} {
+	ctx := make(map[string]interface{})
+	for _, field := range publicContextFields {
+		if val, exists := e.Context[field]; exists {
+			ctx[field] = val
+		}
+	}
+	return ctx
+}
+
+// grpcCodes maps error codes to gRPC status codes. Codes not listed fall back
+// on their HTTP status.
+var grpcCodes = map[ErrorCode]codes.Code{
+	ErrCodeNetworkFailure:        codes.Unavailable,
+	ErrCodeConnectionTimeout:     codes.Unavailable,
+	ErrCodeChannelUnavailable:    codes.Unavailable,
+	ErrCodeGatewayFailure:        codes.Unavailable,
+	ErrCodeLinkedDocUnavailable:  codes.Unavailable,
+	ErrCodeNotFound:              codes.NotFound,
+	ErrCodeSourceDocNotFound:     codes.NotFound,
+	ErrCodeContractNotFound:      codes.NotFound,
+	ErrCodeAlreadyExists:         codes.AlreadyExists,
+	ErrCodeInvalidState:          codes.FailedPrecondition,
+	ErrCodeUnauthorized:          codes.Unauthenticated,
+	ErrCodeInvalidCredentials:    codes.Unauthenticated,
+	ErrCodePermissionDenied:      codes.PermissionDenied,
+	ErrCodeWriteAccessDenied:     codes.PermissionDenied,
+	ErrCodeCertificateError:      codes.FailedPrecondition,
+	ErrCodeTransactionTimeout:    codes.DeadlineExceeded,
+	ErrCodeQueryTimeout:          codes.DeadlineExceeded,
+	ErrCodeRequestCanceled:       codes.Canceled,
+	ErrCodeTransactionFailed:     codes.Aborted,
+	ErrCodeEndorsementFailed:     codes.Aborted,
+	ErrCodeCommitFailed:          codes.Aborted,
+	ErrCodeDataCorruption:        codes.DataLoss,
+	ErrCodeIdempotencyInProgress: codes.Aborted,
+	ErrCodeIdempotencyMismatch:   codes.FailedPrecondition,
+	ErrCodeRateLimited:           codes.ResourceExhausted,
+}
+
+// GRPCCode returns the gRPC status code for an error code.
+func GRPCCode(code ErrorCode, httpStatus int) codes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+	switch {
+	case httpStatus == 400:
+		return codes.InvalidArgument
+	case httpStatus == 403:
+		return codes.PermissionDenied
+	case httpStatus == 404:
+		return codes.NotFound
+	case httpStatus == 409:
+		return codes.Aborted
+	case httpStatus < 500:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCStatus converts an error to the status returned by the gRPC API. An
+// AppError keeps its message, and its code, details, retriability and public
+// context are attached as a google.rpc.ErrorInfo. Other errors are reported
+// as internal without their message.
+func GRPCStatus(err error) *status.Status {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			return status.New(codes.Canceled, "Request canceled")
+		case errors.Is(err, context.DeadlineExceeded):
+			return status.New(codes.DeadlineExceeded, "Request deadline exceeded")
+		}
+		if st, ok := status.FromError(err); ok {
+			return st
+		}
+		return status.New(codes.Internal, "An internal error occurred")
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason:   string(appErr.Code),
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"retriable": fmt.Sprint(appErr.Retriable)},
+	}
+	if appErr.Details != "" {
+		info.Metadata["details"] = appErr.Details
+	}
+	for key, value := range appErr.PublicContext() {
+		info.Metadata[key] = fmt.Sprint(value)
+	}
+
+	st := status.New(GRPCCode(appErr.Code, appErr.HTTPStatus), appErr.Message)
+	if detailed, err := st.WithDetails(info); err == nil {
+		return detailed
+	}
+	return st
+}
@@ -0,0 +1,288 @@
+// Package graphqlapi serves a read-only GraphQL endpoint for navigating
+// documents across channels: from a document to its type, its correction,
+// its history and the document it is linked to, with the link's anchor
+// verified on the way. It shares the service layer and error codes of the
+// REST and gRPC APIs.
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/gov-spending/backend/internal/config"
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/services"
+)
+
+// Handler serves GraphQL requests over HTTP.
+type Handler struct {
+	fabricService *services.FabricService
+	schema        graphql.Schema
+	maxDepth      int
+	maxCost       int
+	channels      atomic.Pointer[map[string]bool]
+}
+
+// request is a GraphQL request, from a JSON body or the query string.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// loaders are the per-request batching loaders.
+type loaders struct {
+	documents     *Loader[ledgerKey, *models.Document]
+	documentTypes *Loader[ledgerKey, *models.DocumentType]
+}
+
+type loadersKey struct{}
+
+// NewHandler creates the handler with the limits of cfg.Server.GraphQL.
+func NewHandler(fabricService *services.FabricService, cfg *config.Config) (*Handler, error) {
+	h := &Handler{
+		fabricService: fabricService,
+		maxDepth:      cfg.Server.GraphQL.MaxDepth,
+		maxCost:       cfg.Server.GraphQL.MaxCost,
+	}
+	if h.maxDepth == 0 {
+		h.maxDepth = DefaultMaxDepth
+	}
+	if h.maxCost == 0 {
+		h.maxCost = DefaultMaxCost
+	}
+	h.Reload(cfg)
+
+	schema, err := h.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	h.schema = schema
+	return h, nil
+}
+
+// Reload switches the handler to a new channel configuration. The limits
+// are read once at startup.
+func (h *Handler) Reload(cfg *config.Config) {
+	valid := make(map[string]bool)
+	for _, ch := range cfg.ValidChannels() {
+		valid[ch] = true
+	}
+	h.channels.Store(&valid)
+}
+
+func (h *Handler) validateChannel(channel string) error {
+	if !(*h.channels.Load())[channel] {
+		return apperrors.NewInvalidChannelError(channel)
+	}
+	return nil
+}
+
+// Serve handles GET and POST /graphql. Requests that do not parse, do not
+// validate or exceed the depth or cost limit are rejected with 400 before
+// anything is read from the ledger. Errors of individual fields are
+// reported next to the data with 200, as GraphQL clients expect.
+func (h *Handler) Serve(c *gin.Context) {
+	req, err := bindRequest(c)
+	if err != nil {
+		h.reject(c, err)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		h.reject(c, err)
+		return
+	}
+	if result := graphql.ValidateDocument(&h.schema, doc, nil); !result.IsValid {
+		c.JSON(http.StatusBadRequest, &graphql.Result{Errors: h.formatErrors(c, result.Errors, apperrors.ErrCodeInvalidQuery)})
+		return
+	}
+	operation, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		h.reject(c, err)
+		return
+	}
+
+	depth, cost := complexity(&h.schema, doc, operation, req.Variables)
+	if depth > h.maxDepth {
+		h.reject(c, apperrors.NewAppError(apperrors.ErrCodeQueryTooComplex, "Query is too deep", nil).
+			WithContext("depth", depth).WithContext("maxDepth", h.maxDepth))
+		return
+	}
+	if cost > h.maxCost {
+		h.reject(c, apperrors.NewAppError(apperrors.ErrCodeQueryTooComplex, "Query is too costly", nil).
+			WithContext("cost", cost).WithContext("maxCost", h.maxCost).
+			WithDetails("Request fewer documents with first, or fewer nested relations"))
+		return
+	}
+
+	ctx := context.WithValue(c.Request.Context(), loadersKey{}, h.newLoaders())
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	result.Errors = h.formatErrors(c, result.Errors, apperrors.ErrCodeInternalError)
+	c.JSON(http.StatusOK, result)
+}
+
+func bindRequest(c *gin.Context) (*request, error) {
+	req := &request{}
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if vars := c.Query("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return nil, apperrors.NewValidationError("Invalid variables: " + err.Error())
+			}
+		}
+	} else if err := c.ShouldBindJSON(req); err != nil {
+		return nil, apperrors.NewValidationError("Invalid request body: " + err.Error())
+	}
+
+	if req.Query == "" {
+		return nil, apperrors.NewValidationError("query is required").WithContext("field", "query")
+	}
+	return req, nil
+}
+
+// selectOperation returns the operation to run, which must be a query.
+func selectOperation(doc *ast.Document, name string) (*ast.OperationDefinition, error) {
+	var selected *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" && selected != nil {
+			return nil, apperrors.NewValidationError("operationName is required when the document has several operations").
+				WithContext("field", "operationName")
+		}
+		if name == "" || (operation.Name != nil && operation.Name.Value == name) {
+			selected = operation
+		}
+	}
+	if selected == nil {
+		return nil, apperrors.NewValidationError("Unknown operation "+name).WithContext("field", "operationName")
+	}
+	if selected.Operation != ast.OperationTypeQuery {
+		return nil, apperrors.NewAppError(apperrors.ErrCodeInvalidQuery, "Only queries are supported: the GraphQL endpoint is read-only", nil)
+	}
+	return selected, nil
+}
+
+func (h *Handler) newLoaders() *loaders {
+	return &loaders{
+		documents: NewLoader(func(ctx context.Context, key ledgerKey) (*models.Document, error) {
+			if err := h.validateChannel(key.channel); err != nil {
+				return nil, err
+			}
+			return h.fabricService.GetDocument(ctx, key.channel, key.id)
+		}),
+		documentTypes: NewLoader(func(ctx context.Context, key ledgerKey) (*models.DocumentType, error) {
+			if err := h.validateChannel(key.channel); err != nil {
+				return nil, err
+			}
+			return h.fabricService.GetDocumentType(ctx, key.channel, key.id)
+		}),
+	}
+}
+
+func requestLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// reject answers a request that cannot be executed.
+func (h *Handler) reject(c *gin.Context, err error) {
+	formatted := h.formatErrors(c, []gqlerrors.FormattedError{gqlerrors.FormatError(err)}, apperrors.ErrCodeInvalidQuery)
+	c.JSON(http.StatusBadRequest, &graphql.Result{Errors: formatted})
+}
+
+// formatErrors gives errors raised by the service layer the message and
+// code of the REST API, in extensions, and logs them. Errors raised by
+// graphql-go itself are reported with the fallback code.
+func (h *Handler) formatErrors(c *gin.Context, errs []gqlerrors.FormattedError, fallback apperrors.ErrorCode) []gqlerrors.FormattedError {
+	requestID := logging.RequestID(c.Request.Context())
+	for i, formatted := range errs {
+		var appErr *apperrors.AppError
+		if !errors.As(originalError(formatted), &appErr) {
+			formatted.Extensions = map[string]interface{}{
+				"code":      string(fallback),
+				"retriable": false,
+				"requestId": requestID,
+			}
+			errs[i] = formatted
+			continue
+		}
+
+		logEvent := logging.Ctx(c.Request.Context()).Warn().
+			Str("code", string(appErr.Code)).
+			Str("message", appErr.Message).
+			Interface("path", formatted.Path)
+		if appErr.Err != nil {
+			logEvent = logEvent.Err(appErr.Err)
+		}
+		logEvent.Msg("GraphQL request error")
+
+		extensions := map[string]interface{}{
+			"code":      string(appErr.Code),
+			"retriable": appErr.Retriable,
+			"requestId": requestID,
+		}
+		if appErr.Details != "" {
+			extensions["details"] = appErr.Details
+		}
+		if ctx := appErr.PublicContext(); len(ctx) > 0 {
+			extensions["context"] = ctx
+		}
+		formatted.Message = appErr.Message
+		formatted.Extensions = extensions
+		errs[i] = formatted
+	}
+	return errs
+}
+
+// originalError unwraps the error graphql-go wrapped a resolver error in.
+// Errors from thunks are wrapped twice.
+func originalError(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return err
+			}
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return err
+			}
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// errorCode is the code of a service error, INTERNAL_ERROR for any other.
+func errorCode(err error) apperrors.ErrorCode {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return apperrors.ErrCodeInternalError
+}
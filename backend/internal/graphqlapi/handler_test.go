@@ -0,0 +1,193 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+// newTestRouter serves the endpoint without a reachable peer: the requests
+// tested are answered before the service layer.
+func newTestRouter(t *testing.T, limits config.GraphQLConfig) *gin.Engine {
+	t.Helper()
+	cfg := &config.Config{
+		Server: config.ServerConfig{GraphQL: limits},
+		Fabric: config.FabricConfig{
+			NetworkPath:   t.TempDir(),
+			ChaincodeName: "spending",
+			Channels: map[string]config.ChannelConfig{
+				"union": {Name: "union-channel", MspID: "UnionMSP", PeerEndpoint: "localhost:7051"},
+			},
+		},
+	}
+
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	h, err := NewHandler(services.NewFabricService(gateway), cfg)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", h.Serve)
+	router.GET("/graphql", h.Serve)
+	return router
+}
+
+type gqlResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func post(t *testing.T, router http.Handler, query string) (int, gqlResponse) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+func TestRequestsAreRejectedBeforeExecution(t *testing.T) {
+	router := newTestRouter(t, config.GraphQLConfig{MaxDepth: 4, MaxCost: 50})
+
+	tests := []struct {
+		name    string
+		query   string
+		code    string
+		context map[string]interface{}
+	}{
+		{
+			name:  "syntax error",
+			query: `{ document(channel: "union", id: "doc-1") { id `,
+			code:  "INVALID_QUERY",
+		},
+		{
+			name:  "unknown field",
+			query: `{ document(channel: "union", id: "doc-1") { secret } }`,
+			code:  "INVALID_QUERY",
+		},
+		{
+			name:  "mutation",
+			query: `mutation { document(channel: "union", id: "doc-1") { id } }`,
+			code:  "INVALID_QUERY",
+		},
+		{
+			name: "too deep through a fragment",
+			query: `{ document(channel: "union", id: "a") { ...deep } }
+				fragment deep on Document { link { document { link { document { id } } } } }`,
+			code:    "QUERY_TOO_COMPLEX",
+			context: map[string]interface{}{"depth": float64(6), "maxDepth": float64(4)},
+		},
+		{
+			name:    "too costly",
+			query:   `{ documents(channel: "union", first: 30) { items { documentType { name } link { channel } } } }`,
+			code:    "QUERY_TOO_COMPLEX",
+			context: map[string]interface{}{"cost": float64(61), "maxCost": float64(50)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := post(t, router, tt.query)
+			if status != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", status)
+			}
+			if len(resp.Errors) == 0 || resp.Errors[0].Extensions["code"] != tt.code {
+				t.Fatalf("errors = %+v, want code %s", resp.Errors, tt.code)
+			}
+			for key, want := range tt.context {
+				got := resp.Errors[0].Extensions["context"].(map[string]interface{})[key]
+				if got != want {
+					t.Errorf("context %s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIntrospectionIsNotCounted(t *testing.T) {
+	router := newTestRouter(t, config.GraphQLConfig{MaxDepth: 2, MaxCost: 1})
+
+	status, resp := post(t, router, `{ __schema { types { name fields { name type { name ofType { name } } } } } }`)
+	if status != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("status = %d, errors = %+v", status, resp.Errors)
+	}
+}
+
+func TestFieldErrorsCarryTheServiceCode(t *testing.T) {
+	router := newTestRouter(t, config.GraphQLConfig{})
+
+	status, resp := post(t, router, `{ document(channel: "county", id: "doc-1") { id } }`)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", status)
+	}
+	if resp.Data["document"] != nil {
+		t.Errorf("document = %v, want null", resp.Data["document"])
+	}
+	if len(resp.Errors) != 1 {
+		t.Fatalf("errors = %+v, want one", resp.Errors)
+	}
+	ext := resp.Errors[0].Extensions
+	if ext["code"] != "INVALID_CHANNEL" || ext["retriable"] != false {
+		t.Errorf("extensions = %v, want INVALID_CHANNEL, not retriable", ext)
+	}
+	if resp.Errors[0].Message != "Invalid channel" {
+		t.Errorf("message = %q, want the service message", resp.Errors[0].Message)
+	}
+}
+
+func TestLoaderBatchesAndDeduplicates(t *testing.T) {
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	loader := NewLoader(func(_ context.Context, key string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetched[key]++
+		return "value of " + key, nil
+	})
+	loader.Prime("primed", "cached")
+
+	ctx := context.Background()
+	var thunks []func() (string, error)
+	for _, key := range []string{"a", "b", "a", "primed", "c"} {
+		thunks = append(thunks, loader.Load(ctx, key))
+	}
+
+	first, _ := thunks[0]()
+	if first != "value of a" {
+		t.Errorf("a = %q", first)
+	}
+	// The first thunk fetched the whole batch.
+	if len(fetched) != 3 {
+		t.Errorf("fetched after the first thunk = %v, want a, b and c", fetched)
+	}
+	if primed, _ := thunks[3](); primed != "cached" {
+		t.Errorf("primed = %q, want the primed value", primed)
+	}
+	for key, n := range fetched {
+		if n != 1 {
+			t.Errorf("%s fetched %d times", key, n)
+		}
+	}
+}
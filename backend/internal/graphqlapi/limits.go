@@ -0,0 +1,189 @@
+package graphqlapi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// DefaultMaxDepth and DefaultMaxCost apply when the configuration leaves
+	// the limits at zero.
+	DefaultMaxDepth = 10
+	DefaultMaxCost  = 1000
+
+	// assumedListSize is the cost multiplier of lists without a page size,
+	// such as document types and history entries.
+	assumedListSize = 10
+
+	// costCap keeps the arithmetic from overflowing on absurd queries; any
+	// cost at the cap is over every sensible limit.
+	costCap = 1 << 30
+)
+
+// fieldCost is what resolving a field costs. Fields that reach the ledger
+// cost one; a list multiplies the cost of its selections by its size.
+type fieldCost struct {
+	fetch int
+	size  func(args []*ast.Argument, vars map[string]interface{}) int
+}
+
+var fieldCosts = map[string]fieldCost{
+	"Query.document":        {fetch: 1},
+	"Query.documents":       {fetch: 1, size: pageSizeArg},
+	"Query.documentType":    {fetch: 1},
+	"Query.documentTypes":   {fetch: 1, size: assumedSize},
+	"Document.documentType": {fetch: 1},
+	"Document.correctedBy":  {fetch: 1},
+	"Document.link":         {fetch: 1},
+	"Document.history":      {fetch: 1, size: assumedSize},
+}
+
+func assumedSize([]*ast.Argument, map[string]interface{}) int {
+	return assumedListSize
+}
+
+// pageSizeArg is the page size the first argument asks for, as the resolver
+// will clamp it.
+func pageSizeArg(args []*ast.Argument, vars map[string]interface{}) int {
+	for _, arg := range args {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		var first int
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			first, _ = strconv.Atoi(v.Value)
+		case *ast.Variable:
+			if n, ok := vars[v.Name.Value].(float64); ok {
+				first = int(n)
+			} else if n, ok := vars[v.Name.Value].(int); ok {
+				first = n
+			}
+		}
+		return clampPageSize(first)
+	}
+	return defaultPageSize
+}
+
+// complexity walks operation and returns its depth and cost. Introspection
+// fields are not counted. Fragments are measured once each, so reusing them
+// cannot make the walk itself expensive.
+func complexity(schema *graphql.Schema, doc *ast.Document, operation *ast.OperationDefinition, vars map[string]interface{}) (depth, cost int) {
+	a := &analyzer{
+		schema:    schema,
+		vars:      make(map[string]interface{}),
+		fragments: make(map[string]*ast.FragmentDefinition),
+		measured:  make(map[string][2]int),
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			a.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range operation.VariableDefinitions {
+		if value, ok := def.DefaultValue.(*ast.IntValue); ok {
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				a.vars[def.Variable.Name.Value] = n
+			}
+		}
+	}
+	for name, value := range vars {
+		a.vars[name] = value
+	}
+
+	return a.selections(schema.QueryType(), operation.SelectionSet)
+}
+
+type analyzer struct {
+	schema    *graphql.Schema
+	vars      map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	measured  map[string][2]int
+}
+
+func (a *analyzer) selections(parent *graphql.Object, set *ast.SelectionSet) (depth, cost int) {
+	if parent == nil || set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			d, c = a.field(parent, sel)
+		case *ast.InlineFragment:
+			typ := parent
+			if sel.TypeCondition != nil {
+				typ = a.object(sel.TypeCondition.Name.Value)
+			}
+			d, c = a.selections(typ, sel.SelectionSet)
+		case *ast.FragmentSpread:
+			d, c = a.fragment(sel.Name.Value)
+		}
+		depth = max(depth, d)
+		cost = min(cost+c, costCap)
+	}
+	return depth, cost
+}
+
+func (a *analyzer) field(parent *graphql.Object, field *ast.Field) (depth, cost int) {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return 0, 0
+	}
+
+	childDepth, childCost := a.selections(namedObject(def.Type), field.SelectionSet)
+	fc := fieldCosts[parent.Name()+"."+name]
+	size := 1
+	if fc.size != nil {
+		size = fc.size(field.Arguments, a.vars)
+	}
+	cost = fc.fetch
+	if childCost > 0 && size > 0 {
+		cost = min(cost+min(childCost, costCap/size)*size, costCap)
+	}
+	return childDepth + 1, cost
+}
+
+// fragment measures a named fragment once. Validation has already rejected
+// fragment cycles.
+func (a *analyzer) fragment(name string) (depth, cost int) {
+	if m, ok := a.measured[name]; ok {
+		return m[0], m[1]
+	}
+	def, ok := a.fragments[name]
+	if !ok {
+		return 0, 0
+	}
+	depth, cost = a.selections(a.object(def.TypeCondition.Name.Value), def.SelectionSet)
+	a.measured[name] = [2]int{depth, cost}
+	return depth, cost
+}
+
+func (a *analyzer) object(name string) *graphql.Object {
+	obj, _ := a.schema.Type(name).(*graphql.Object)
+	return obj
+}
+
+// namedObject unwraps lists and non-null wrappers down to an object type.
+func namedObject(t graphql.Output) *graphql.Object {
+	for {
+		switch typ := t.(type) {
+		case *graphql.NonNull:
+			t = typ.OfType
+		case *graphql.List:
+			t = typ.OfType
+		case *graphql.Object:
+			return typ
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+)
+
+// maxConcurrentFetches bounds the ledger calls a single batch makes at once.
+const maxConcurrentFetches = 8
+
+// Loader batches the loads of one request. Load only records the key and
+// returns a thunk; graphql-go resolves thunks after the sibling fields, so by
+// the time the first one runs every key requested at that level is pending
+// and is fetched in one concurrent batch. Each key is fetched at most once
+// per request.
+//
+// The chaincode has no multi-get, so a batch is a set of concurrent
+// evaluations rather than a single call.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, key K) (V, error)
+
+	mu      sync.Mutex
+	results map[K]*loadResult[V]
+	pending []K
+}
+
+type loadResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewLoader creates a loader that fetches one key with fetch.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, key K) (V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:   fetch,
+		results: make(map[K]*loadResult[V]),
+	}
+}
+
+// Load queues key and returns a thunk yielding its value.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	result, ok := l.results[key]
+	if !ok {
+		result = &loadResult[V]{done: make(chan struct{})}
+		l.results[key] = result
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.dispatch(ctx)
+		<-result.done
+		return result.value, result.err
+	}
+}
+
+// Prime stores a value fetched by other means, such as a document returned
+// by a query, so loads of its key do not fetch it again.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.results[key]; ok {
+		return
+	}
+	result := &loadResult[V]{done: make(chan struct{}), value: value}
+	close(result.done)
+	l.results[key] = result
+}
+
+// dispatch fetches every pending key and waits for the batch to finish.
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	batch := make([]*loadResult[V], len(keys))
+	for i, key := range keys {
+		batch[i] = l.results[key]
+	}
+	l.mu.Unlock()
+
+	sem := make(chan struct{}, maxConcurrentFetches)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key K, result *loadResult[V]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result.value, result.err = l.fetch(ctx, key)
+			close(result.done)
+		}(key, batch[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,581 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/services"
+)
+
+const (
+	// defaultPageSize matches the REST query default; maxPageSize bounds
+	// what a single documents field may fetch.
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Link verification statuses. VERIFIED and MISMATCH are the statuses of
+// POST /api/v1/anchors/verify.
+const (
+	linkVerified    = "VERIFIED"
+	linkMismatch    = "MISMATCH"
+	linkUnavailable = "UNAVAILABLE"
+)
+
+// documentNode is a document and the key of the channel it was read from.
+// The document's own channelId is the Fabric channel name, which relations
+// cannot be followed with.
+type documentNode struct {
+	Channel  string
+	Document *models.Document
+}
+
+// linkNode is the cross-channel link of a document.
+type linkNode struct {
+	from *documentNode
+}
+
+// linkVerification checks one edge: the linked document is the anchor
+// source and the document holding the link its target.
+type linkVerification struct {
+	Status         string   `json:"status"`
+	HashMatch      bool     `json:"hashMatch"`
+	IDMatch        bool     `json:"idMatch"`
+	ChannelMatch   bool     `json:"channelMatch"`
+	AmountMatch    bool     `json:"amountMatch"`
+	MismatchReason []string `json:"mismatchReasons"`
+	ErrorCode      string   `json:"errorCode"`
+}
+
+type historyNode struct {
+	TxID      string        `json:"txId"`
+	Timestamp string        `json:"timestamp"`
+	IsDelete  bool          `json:"isDelete"`
+	Document  *documentNode `json:"document"`
+}
+
+type pageNode struct {
+	Items      []*documentNode    `json:"items"`
+	Pagination *models.Pagination `json:"pagination"`
+}
+
+// ledgerKey identifies a document or document type across channels.
+type ledgerKey struct {
+	channel string
+	id      string
+}
+
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Any JSON value.",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: parseJSONLiteral,
+})
+
+func parseJSONLiteral(value ast.Value) interface{} {
+	switch v := value.(type) {
+	case *ast.StringValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.IntValue:
+		n, _ := strconv.ParseFloat(v.Value, 64)
+		return n
+	case *ast.FloatValue:
+		n, _ := strconv.ParseFloat(v.Value, 64)
+		return n
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(v.Values))
+		for _, item := range v.Values {
+			list = append(list, parseJSONLiteral(item))
+		}
+		return list
+	case *ast.ObjectValue:
+		obj := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			obj[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return obj
+	}
+	return nil
+}
+
+var documentStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "DocumentStatus",
+	Values: graphql.EnumValueConfigMap{
+		"ACTIVE":      {Value: models.StatusActive},
+		"INVALIDATED": {Value: models.StatusInvalidated},
+	},
+})
+
+var linkStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "LinkStatus",
+	Description: "Outcome of checking a link against the linked document.",
+	Values: graphql.EnumValueConfigMap{
+		linkVerified:    {Value: linkVerified, Description: "The linked document is the anchor this document records."},
+		linkMismatch:    {Value: linkMismatch, Description: "The anchor does not match; see mismatchReasons."},
+		linkUnavailable: {Value: linkUnavailable, Description: "The linked document could not be read; see errorCode."},
+	},
+})
+
+var documentTypeObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DocumentType",
+	Fields: graphql.Fields{
+		"id":             {Type: graphql.NewNonNull(graphql.ID)},
+		"organizationId": {Type: graphql.NewNonNull(graphql.String)},
+		"name":           {Type: graphql.NewNonNull(graphql.String)},
+		"description":    {Type: graphql.NewNonNull(graphql.String)},
+		"requiredFields": {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		"optionalFields": {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+		"createdAt":      {Type: graphql.NewNonNull(graphql.String)},
+		"createdBy":      {Type: graphql.NewNonNull(graphql.String)},
+		"isActive":       {Type: graphql.NewNonNull(graphql.Boolean)},
+	},
+})
+
+var linkVerificationObject = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "LinkVerification",
+	Description: "The anchor check of POST /api/v1/anchors/verify, with the linked document as source.",
+	Fields: graphql.Fields{
+		"status":          {Type: graphql.NewNonNull(linkStatusEnum)},
+		"hashMatch":       {Type: graphql.NewNonNull(graphql.Boolean)},
+		"idMatch":         {Type: graphql.NewNonNull(graphql.Boolean)},
+		"channelMatch":    {Type: graphql.NewNonNull(graphql.Boolean)},
+		"amountMatch":     {Type: graphql.NewNonNull(graphql.Boolean)},
+		"mismatchReasons": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		"errorCode":       {Type: graphql.String, Description: "Error code of the failed read when UNAVAILABLE."},
+	},
+})
+
+var paginationObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Pagination",
+	Fields: graphql.Fields{
+		"count":          {Type: graphql.NewNonNull(graphql.Int)},
+		"nextBookmark":   {Type: graphql.String, Description: "Passed as after to get the next page."},
+		"hasMore":        {Type: graphql.NewNonNull(graphql.Boolean)},
+		"total":          {Type: graphql.Int, Description: "Set when countMode is requested."},
+		"totalEstimated": {Type: graphql.NewNonNull(graphql.Boolean), Description: "True if an estimated count stopped at 1000 matches: total is then a lower bound."},
+	},
+})
+
+var fieldConditionInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "FieldCondition",
+	Description: "A condition on a data field, as in the where parameter of GET /api/v1/{channel}/documents.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"field":  {Type: graphql.NewNonNull(graphql.String)},
+		"op":     {Type: graphql.NewNonNull(graphql.String), Description: "eq, in, gt, gte, lt, lte or exists"},
+		"value":  {Type: jsonScalar},
+		"values": {Type: graphql.NewList(jsonScalar)},
+	},
+})
+
+var documentFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DocumentFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"organizationId":  {Type: graphql.String},
+		"documentTypeId":  {Type: graphql.String},
+		"status":          {Type: documentStatusEnum},
+		"fromDate":        {Type: graphql.String},
+		"toDate":          {Type: graphql.String},
+		"minAmount":       {Type: graphql.Float},
+		"maxAmount":       {Type: graphql.Float},
+		"hasLinkedDoc":    {Type: graphql.Boolean},
+		"linkedDirection": {Type: graphql.String},
+		"sortBy":          {Type: graphql.String, Description: "createdAt, updatedAt, amount or title"},
+		"sortOrder":       {Type: graphql.String, Description: "asc or desc"},
+		"countMode":       {Type: graphql.String, Description: "exact or estimated; an estimated count reads at most 1000 matches"},
+		"conditions":      {Type: graphql.NewList(graphql.NewNonNull(fieldConditionInput))},
+	},
+})
+
+// newSchema builds the schema. Resolvers reach the ledger through the
+// request's loaders.
+func (h *Handler) newSchema() (graphql.Schema, error) {
+	document := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Document",
+		Fields: graphql.Fields{
+			"channel": {
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Key of the channel the document was read from, as in the REST paths.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*documentNode).Channel, nil
+				},
+			},
+			"id":              {Type: graphql.NewNonNull(graphql.ID), Resolve: fromDocument},
+			"channelId":       {Type: graphql.NewNonNull(graphql.String), Description: "Fabric channel name.", Resolve: fromDocument},
+			"documentTypeId":  {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"organizationId":  {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"status":          {Type: graphql.NewNonNull(documentStatusEnum), Resolve: fromDocument},
+			"title":           {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"description":     {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"amount":          {Type: graphql.NewNonNull(graphql.Float), Resolve: fromDocument},
+			"currency":        {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"data":            {Type: jsonScalar, Resolve: fromDocument},
+			"contentHash":     {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"linkedDocId":     {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"linkedChannel":   {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"linkedDocHash":   {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"linkedDirection": {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"invalidatedBy":   {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"invalidatedAt":   {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"invalidReason":   {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"correctedByDoc":  {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"createdAt":       {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"createdBy":       {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"updatedAt":       {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"updatedBy":       {Type: graphql.NewNonNull(graphql.String), Resolve: fromDocument},
+			"documentType": {
+				Type:    documentTypeObject,
+				Resolve: h.resolveDocumentTypeOf,
+			},
+		},
+	})
+
+	link := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DocumentLink",
+		Description: "The cross-channel link recorded by a transfer.",
+		Fields: graphql.Fields{
+			"channel": {
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*linkNode).from.Document.LinkedChannel, nil
+				},
+			},
+			"documentId": {
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*linkNode).from.Document.LinkedDocID, nil
+				},
+			},
+			"direction": {
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*linkNode).from.Document.LinkedDirection, nil
+				},
+			},
+			"anchorHash": {
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Content hash of the linked document as recorded by this one.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*linkNode).from.Document.LinkedDocHash, nil
+				},
+			},
+			"document": {
+				Type:        document,
+				Description: "The linked document, null with an error when it cannot be read.",
+				Resolve:     h.resolveLinkedDocument,
+			},
+			"verification": {
+				Type:    graphql.NewNonNull(linkVerificationObject),
+				Resolve: h.resolveLinkVerification,
+			},
+		},
+	})
+
+	history := graphql.NewObject(graphql.ObjectConfig{
+		Name: "HistoryEntry",
+		Fields: graphql.Fields{
+			"txId":      {Type: graphql.NewNonNull(graphql.String)},
+			"timestamp": {Type: graphql.NewNonNull(graphql.String)},
+			"isDelete":  {Type: graphql.NewNonNull(graphql.Boolean)},
+			"document":  {Type: document},
+		},
+	})
+
+	document.AddFieldConfig("link", &graphql.Field{
+		Type:        link,
+		Description: "Null when the document is not linked.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			node := p.Source.(*documentNode)
+			if node.Document.LinkedDocID == "" {
+				return nil, nil
+			}
+			return &linkNode{from: node}, nil
+		},
+	})
+	document.AddFieldConfig("correctedBy", &graphql.Field{
+		Type:        document,
+		Description: "The correction of an invalidated document.",
+		Resolve:     h.resolveCorrectedBy,
+	})
+	document.AddFieldConfig("history", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(history))),
+		Resolve: h.resolveHistory,
+	})
+
+	page := graphql.NewObject(graphql.ObjectConfig{
+		Name: "DocumentPage",
+		Fields: graphql.Fields{
+			"items":      {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(document)))},
+			"pagination": {Type: graphql.NewNonNull(paginationObject)},
+		},
+	})
+
+	channelArg := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Channel key (union, state, region)"}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"document": {
+				Type: document,
+				Args: graphql.FieldConfigArgument{
+					"channel": channelArg,
+					"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: h.resolveDocument,
+			},
+			"documents": {
+				Type: graphql.NewNonNull(page),
+				Args: graphql.FieldConfigArgument{
+					"channel": channelArg,
+					"filter":  {Type: documentFilterInput},
+					"first":   {Type: graphql.Int, DefaultValue: defaultPageSize, Description: "Page size, at most 100"},
+					"after":   {Type: graphql.String, Description: "nextBookmark of the previous page"},
+				},
+				Resolve: h.resolveDocuments,
+			},
+			"documentType": {
+				Type: documentTypeObject,
+				Args: graphql.FieldConfigArgument{
+					"channel": channelArg,
+					"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: h.resolveDocumentType,
+			},
+			"documentTypes": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(documentTypeObject))),
+				Args: graphql.FieldConfigArgument{
+					"channel":        channelArg,
+					"organizationId": {Type: graphql.String},
+				},
+				Resolve: h.resolveDocumentTypes,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// fromDocument resolves a plain field of the wrapped document.
+func fromDocument(p graphql.ResolveParams) (interface{}, error) {
+	p.Source = p.Source.(*documentNode).Document
+	return graphql.DefaultResolveFn(p)
+}
+
+func (h *Handler) resolveDocument(p graphql.ResolveParams) (interface{}, error) {
+	channel, _ := p.Args["channel"].(string)
+	if err := h.validateChannel(channel); err != nil {
+		return nil, err
+	}
+	id, _ := p.Args["id"].(string)
+	return h.loadDocument(p, ledgerKey{channel: channel, id: id}), nil
+}
+
+func (h *Handler) resolveDocuments(p graphql.ResolveParams) (interface{}, error) {
+	channel, _ := p.Args["channel"].(string)
+	if err := h.validateChannel(channel); err != nil {
+		return nil, err
+	}
+
+	filter := &models.QueryFilter{}
+	if raw, ok := p.Args["filter"].(map[string]interface{}); ok {
+		if err := decodeFilter(raw, filter); err != nil {
+			return nil, err
+		}
+	}
+	first, _ := p.Args["first"].(int)
+	filter.PageSize = clampPageSize(first)
+	filter.Bookmark, _ = p.Args["after"].(string)
+	if err := binding.Validator.ValidateStruct(filter); err != nil {
+		return nil, apperrors.NewValidationError("Invalid filter: " + err.Error())
+	}
+
+	result, err := h.fabricService.QueryDocuments(p.Context, channel, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	loaders := requestLoaders(p.Context)
+	items := make([]*documentNode, 0, len(result.Documents))
+	for _, doc := range result.Documents {
+		loaders.documents.Prime(ledgerKey{channel: channel, id: doc.ID}, doc)
+		items = append(items, &documentNode{Channel: channel, Document: doc})
+	}
+	return &pageNode{
+		Items: items,
+		Pagination: &models.Pagination{
+			Count:          result.Count,
+			NextBookmark:   result.Bookmark,
+			HasMore:        result.Bookmark != "" && result.Count >= filter.PageSize,
+			Total:          result.Total,
+			TotalEstimated: result.TotalEstimated,
+		},
+	}, nil
+}
+
+// decodeFilter reads the filter argument into a QueryFilter through its JSON
+// form, whose names the input fields share.
+func decodeFilter(raw map[string]interface{}, filter *models.QueryFilter) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return apperrors.NewAppError(apperrors.ErrCodeMarshalingFailed, "Failed to encode filter", err)
+	}
+	if err := json.Unmarshal(data, filter); err != nil {
+		return apperrors.NewValidationError("Invalid filter: " + err.Error())
+	}
+	return nil
+}
+
+func clampPageSize(first int) int {
+	switch {
+	case first <= 0:
+		return defaultPageSize
+	case first > maxPageSize:
+		return maxPageSize
+	}
+	return first
+}
+
+func (h *Handler) resolveDocumentType(p graphql.ResolveParams) (interface{}, error) {
+	channel, _ := p.Args["channel"].(string)
+	if err := h.validateChannel(channel); err != nil {
+		return nil, err
+	}
+	id, _ := p.Args["id"].(string)
+	return h.loadDocumentType(p, ledgerKey{channel: channel, id: id}), nil
+}
+
+func (h *Handler) resolveDocumentTypes(p graphql.ResolveParams) (interface{}, error) {
+	channel, _ := p.Args["channel"].(string)
+	if err := h.validateChannel(channel); err != nil {
+		return nil, err
+	}
+	orgID, _ := p.Args["organizationId"].(string)
+
+	result, err := h.fabricService.ListDocumentTypes(p.Context, channel, orgID)
+	if err != nil {
+		return nil, err
+	}
+	loaders := requestLoaders(p.Context)
+	for _, dt := range result {
+		loaders.documentTypes.Prime(ledgerKey{channel: channel, id: dt.ID}, dt)
+	}
+	return result, nil
+}
+
+func (h *Handler) resolveDocumentTypeOf(p graphql.ResolveParams) (interface{}, error) {
+	node := p.Source.(*documentNode)
+	return h.loadDocumentType(p, ledgerKey{channel: node.Channel, id: node.Document.DocumentTypeID}), nil
+}
+
+func (h *Handler) resolveCorrectedBy(p graphql.ResolveParams) (interface{}, error) {
+	node := p.Source.(*documentNode)
+	if node.Document.CorrectedByDoc == "" {
+		return nil, nil
+	}
+	return h.loadDocument(p, ledgerKey{channel: node.Channel, id: node.Document.CorrectedByDoc}), nil
+}
+
+func (h *Handler) resolveLinkedDocument(p graphql.ResolveParams) (interface{}, error) {
+	doc := p.Source.(*linkNode).from.Document
+	return h.loadDocument(p, ledgerKey{channel: doc.LinkedChannel, id: doc.LinkedDocID}), nil
+}
+
+// resolveLinkVerification checks the edge against the linked document. A
+// linked document that cannot be read makes the edge UNAVAILABLE rather
+// than failing the field, so one broken link does not hide the others.
+func (h *Handler) resolveLinkVerification(p graphql.ResolveParams) (interface{}, error) {
+	node := p.Source.(*linkNode).from
+	key := ledgerKey{channel: node.Document.LinkedChannel, id: node.Document.LinkedDocID}
+	load := requestLoaders(p.Context).documents.Load(p.Context, key)
+
+	return func() (interface{}, error) {
+		linked, err := load()
+		if err != nil {
+			return &linkVerification{
+				Status:         linkUnavailable,
+				MismatchReason: []string{},
+				ErrorCode:      string(errorCode(err)),
+			}, nil
+		}
+
+		v := services.CompareAnchor(key.channel, linked, node.Channel, node.Document)
+		status := linkMismatch
+		if v.IsValid {
+			status = linkVerified
+		}
+		reasons := v.MismatchReason
+		if reasons == nil {
+			reasons = []string{}
+		}
+		return &linkVerification{
+			Status:         status,
+			HashMatch:      v.HashMatch,
+			IDMatch:        v.IDMatch,
+			ChannelMatch:   v.ChannelMatch,
+			AmountMatch:    v.AmountMatch,
+			MismatchReason: reasons,
+		}, nil
+	}, nil
+}
+
+func (h *Handler) resolveHistory(p graphql.ResolveParams) (interface{}, error) {
+	node := p.Source.(*documentNode)
+	entries, err := h.fabricService.GetDocumentHistory(p.Context, node.Channel, node.Document.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil, apperrors.NewAppError(apperrors.ErrCodeMarshalingFailed, "Failed to encode document history", err)
+	}
+	var history []models.HistoryEntry
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, apperrors.NewAppError(apperrors.ErrCodeUnmarshalingFailed, "Failed to parse document history", err)
+	}
+
+	result := make([]*historyNode, 0, len(history))
+	for _, entry := range history {
+		item := &historyNode{
+			TxID:      entry.TxID,
+			Timestamp: entry.Timestamp.Format(time.RFC3339Nano),
+			IsDelete:  entry.IsDelete,
+		}
+		if entry.Document != nil {
+			item.Document = &documentNode{Channel: node.Channel, Document: entry.Document}
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// loadDocument returns a thunk resolving to the document's node.
+func (h *Handler) loadDocument(p graphql.ResolveParams, key ledgerKey) func() (interface{}, error) {
+	load := requestLoaders(p.Context).documents.Load(p.Context, key)
+	return func() (interface{}, error) {
+		doc, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return &documentNode{Channel: key.channel, Document: doc}, nil
+	}
+}
+
+func (h *Handler) loadDocumentType(p graphql.ResolveParams, key ledgerKey) func() (interface{}, error) {
+	load := requestLoaders(p.Context).documentTypes.Load(p.Context, key)
+	return func() (interface{}, error) {
+		dt, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return dt, nil
+	}
+}
@@ -0,0 +1,219 @@
+package grpcapi
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// validate applies the binding rules the REST API checks request bodies with.
+func validate(req interface{}) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return apperrors.NewValidationError("Invalid request: " + err.Error())
+	}
+	return nil
+}
+
+// required rejects an empty identifier, which REST takes from the path.
+func required(field, value string) error {
+	if value == "" {
+		return apperrors.NewValidationError(field+" is required").
+			WithContext("field", field)
+	}
+	return nil
+}
+
+func toMap(data *structpb.Struct) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	return data.AsMap()
+}
+
+func toDocumentType(dt *models.DocumentType) *govspendingv1.DocumentType {
+	return &govspendingv1.DocumentType{
+		Id:             dt.ID,
+		OrganizationId: dt.OrganizationID,
+		Name:           dt.Name,
+		Description:    dt.Description,
+		RequiredFields: dt.RequiredFields,
+		OptionalFields: dt.OptionalFields,
+		CreatedAt:      dt.CreatedAt,
+		CreatedBy:      dt.CreatedBy,
+		IsActive:       dt.IsActive,
+	}
+}
+
+func toDocument(doc *models.Document) (*govspendingv1.Document, error) {
+	if doc == nil {
+		return nil, nil
+	}
+	var data *structpb.Struct
+	if doc.Data != nil {
+		var err error
+		if data, err = structpb.NewStruct(doc.Data); err != nil {
+			return nil, apperrors.NewAppError(apperrors.ErrCodeMarshalingFailed, "Failed to encode document data", err).
+				WithContext("docId", doc.ID)
+		}
+	}
+	return &govspendingv1.Document{
+		Id:              doc.ID,
+		DocumentTypeId:  doc.DocumentTypeID,
+		OrganizationId:  doc.OrganizationID,
+		ChannelId:       doc.ChannelID,
+		Status:          string(doc.Status),
+		Title:           doc.Title,
+		Description:     doc.Description,
+		Amount:          doc.Amount,
+		Currency:        doc.Currency,
+		Data:            data,
+		ContentHash:     doc.ContentHash,
+		LinkedDocId:     doc.LinkedDocID,
+		LinkedChannel:   doc.LinkedChannel,
+		LinkedDocHash:   doc.LinkedDocHash,
+		LinkedDirection: doc.LinkedDirection,
+		InvalidatedBy:   doc.InvalidatedBy,
+		InvalidatedAt:   doc.InvalidatedAt,
+		InvalidReason:   doc.InvalidReason,
+		CorrectedByDoc:  doc.CorrectedByDoc,
+		CreatedAt:       doc.CreatedAt,
+		CreatedBy:       doc.CreatedBy,
+		UpdatedAt:       doc.UpdatedAt,
+		UpdatedBy:       doc.UpdatedBy,
+		History:         doc.History,
+	}, nil
+}
+
+func toDocuments(docs []*models.Document) ([]*govspendingv1.Document, error) {
+	result := make([]*govspendingv1.Document, 0, len(docs))
+	for _, doc := range docs {
+		converted, err := toDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// toHistory converts the history entries the service returns as decoded JSON.
+func toHistory(entries []map[string]any) ([]*govspendingv1.HistoryEntry, error) {
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return nil, apperrors.NewAppError(apperrors.ErrCodeMarshalingFailed, "Failed to encode document history", err)
+	}
+	var history []models.HistoryEntry
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, apperrors.NewAppError(apperrors.ErrCodeUnmarshalingFailed, "Failed to parse document history", err)
+	}
+
+	result := make([]*govspendingv1.HistoryEntry, 0, len(history))
+	for _, entry := range history {
+		doc, err := toDocument(entry.Document)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &govspendingv1.HistoryEntry{
+			TxId:      entry.TxID,
+			Timestamp: timestamppb.New(entry.Timestamp),
+			IsDelete:  entry.IsDelete,
+			Document:  doc,
+		})
+	}
+	return result, nil
+}
+
+func toQueryFilter(req *govspendingv1.QueryDocumentsRequest) *models.QueryFilter {
+	filter := &models.QueryFilter{
+		OrganizationID:  req.GetOrganizationId(),
+		DocumentTypeID:  req.GetDocumentTypeId(),
+		Status:          models.DocumentStatus(req.GetStatus()),
+		FromDate:        req.GetFromDate(),
+		ToDate:          req.GetToDate(),
+		MinAmount:       req.GetMinAmount(),
+		MaxAmount:       req.GetMaxAmount(),
+		HasLinkedDoc:    req.HasLinkedDoc,
+		LinkedDirection: req.GetLinkedDirection(),
+		SortBy:          req.GetSortBy(),
+		SortOrder:       req.GetSortOrder(),
+		CountMode:       req.GetCountMode(),
+		PageSize:        int(req.GetPageSize()),
+		Bookmark:        req.GetBookmark(),
+	}
+	for _, cond := range req.GetConditions() {
+		condition := models.FieldCondition{Field: cond.GetField(), Op: cond.GetOp()}
+		if cond.GetValue() != nil {
+			condition.Value = cond.GetValue().AsInterface()
+		}
+		for _, value := range cond.GetValues() {
+			condition.Values = append(condition.Values, value.AsInterface())
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+	return filter
+}
+
+func toPagination(result *models.QueryResult, pageSize int) *govspendingv1.Pagination {
+	pagination := &govspendingv1.Pagination{
+		Count:          int32(result.Count),
+		NextBookmark:   result.Bookmark,
+		HasMore:        result.Bookmark != "" && result.Count >= pageSize,
+		TotalEstimated: result.TotalEstimated,
+	}
+	if result.Total != nil {
+		total := int32(*result.Total)
+		pagination.Total = &total
+	}
+	return pagination
+}
+
+func toTransferResult(result *models.TransferResult) *govspendingv1.TransferResult {
+	return &govspendingv1.TransferResult{
+		Id:               result.ID,
+		ContentHash:      result.ContentHash,
+		Channel:          result.Channel,
+		LinkedDocId:      result.LinkedDocID,
+		LinkedDocHash:    result.LinkedDocHash,
+		LinkedDocChannel: result.LinkedDocChannel,
+	}
+}
+
+func toAnchorVerification(v *models.AnchorVerification) *govspendingv1.AnchorVerification {
+	return &govspendingv1.AnchorVerification{
+		SourceDocId:         v.SourceDocID,
+		SourceChannel:       v.SourceChannel,
+		SourceContentHash:   v.SourceContentHash,
+		SourceAmount:        v.SourceAmount,
+		SourceCurrency:      v.SourceCurrency,
+		TargetDocId:         v.TargetDocID,
+		TargetChannel:       v.TargetChannel,
+		TargetContentHash:   v.TargetContentHash,
+		TargetLinkedDocHash: v.TargetLinkedHash,
+		TargetAmount:        v.TargetAmount,
+		TargetCurrency:      v.TargetCurrency,
+		HashMatch:           v.HashMatch,
+		IdMatch:             v.IDMatch,
+		ChannelMatch:        v.ChannelMatch,
+		AmountMatch:         v.AmountMatch,
+		IsValid:             v.IsValid,
+		Status:              v.Status,
+		MismatchReason:      v.MismatchReason,
+	}
+}
+
+func toEvent(event *models.ChaincodeEvent) *govspendingv1.Event {
+	return &govspendingv1.Event{
+		Channel:     event.Channel,
+		Name:        event.Name,
+		Id:          event.ID,
+		TxId:        event.TxID,
+		RequestId:   event.RequestID,
+		BlockNumber: event.BlockNumber,
+	}
+}
@@ -0,0 +1,154 @@
+package grpcapi
+
+import (
+	"context"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// defaultPageSize matches the REST query default.
+const defaultPageSize = 20
+
+func (s *Server) CreateDocument(ctx context.Context, req *govspendingv1.CreateDocumentRequest) (*govspendingv1.CreateDocumentResponse, error) {
+	if err := s.validateWriteAccess(req.GetChannel()); err != nil {
+		return nil, err
+	}
+
+	createReq := &models.CreateDocumentRequest{
+		ID:             req.GetId(),
+		DocumentTypeID: req.GetDocumentTypeId(),
+		Title:          req.GetTitle(),
+		Description:    req.GetDescription(),
+		Amount:         req.GetAmount(),
+		Currency:       req.GetCurrency(),
+		Data:           toMap(req.GetData()),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	createReq.IdempotencyKey = key
+
+	result, err := s.fabricService.CreateDocument(ctx, req.GetChannel(), createReq)
+	if err != nil {
+		return nil, err
+	}
+	return &govspendingv1.CreateDocumentResponse{Id: result.ID}, nil
+}
+
+func (s *Server) GetDocument(ctx context.Context, req *govspendingv1.GetDocumentRequest) (*govspendingv1.Document, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("doc_id", req.GetDocId()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.GetDocument(ctx, req.GetChannel(), req.GetDocId())
+	if err != nil {
+		return nil, err
+	}
+	return toDocument(result)
+}
+
+func (s *Server) QueryDocuments(ctx context.Context, req *govspendingv1.QueryDocumentsRequest) (*govspendingv1.QueryDocumentsResponse, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+
+	filter := toQueryFilter(req)
+	if err := validate(filter); err != nil {
+		return nil, err
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+
+	result, err := s.fabricService.QueryDocuments(ctx, req.GetChannel(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := toDocuments(result.Documents)
+	if err != nil {
+		return nil, err
+	}
+	return &govspendingv1.QueryDocumentsResponse{
+		Documents:  docs,
+		Pagination: toPagination(result, filter.PageSize),
+	}, nil
+}
+
+func (s *Server) InvalidateDocument(ctx context.Context, req *govspendingv1.InvalidateDocumentRequest) (*govspendingv1.InvalidateDocumentResponse, error) {
+	if err := s.validateWriteAccess(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("doc_id", req.GetDocId()); err != nil {
+		return nil, err
+	}
+
+	invalidateReq := &models.InvalidateDocumentRequest{
+		Reason:          req.GetReason(),
+		CorrectionDocID: req.GetCorrectionDocId(),
+	}
+	if err := validate(invalidateReq); err != nil {
+		return nil, err
+	}
+
+	if err := s.fabricService.InvalidateDocument(ctx, req.GetChannel(), req.GetDocId(), invalidateReq); err != nil {
+		return nil, err
+	}
+	return &govspendingv1.InvalidateDocumentResponse{}, nil
+}
+
+func (s *Server) GetDocumentHistory(ctx context.Context, req *govspendingv1.GetDocumentHistoryRequest) (*govspendingv1.GetDocumentHistoryResponse, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("doc_id", req.GetDocId()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.GetDocumentHistory(ctx, req.GetChannel(), req.GetDocId())
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := toHistory(result)
+	if err != nil {
+		return nil, err
+	}
+	return &govspendingv1.GetDocumentHistoryResponse{Entries: entries}, nil
+}
+
+func (s *Server) GetLinkedDocuments(ctx context.Context, req *govspendingv1.GetLinkedDocumentsRequest) (*govspendingv1.LinkedDocuments, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("doc_id", req.GetDocId()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.GetLinkedDocuments(ctx, req.GetChannel(), req.GetDocId())
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := toDocument(result.Document)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := toDocument(result.LinkedDocument)
+	if err != nil {
+		return nil, err
+	}
+	return &govspendingv1.LinkedDocuments{
+		Document:       doc,
+		LinkedDocument: linked,
+		LinkVerified:   result.LinkVerified,
+	}, nil
+}
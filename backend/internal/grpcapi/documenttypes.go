@@ -0,0 +1,79 @@
+package grpcapi
+
+import (
+	"context"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+func (s *Server) RegisterDocumentType(ctx context.Context, req *govspendingv1.RegisterDocumentTypeRequest) (*govspendingv1.RegisterDocumentTypeResponse, error) {
+	if err := s.validateWriteAccess(req.GetChannel()); err != nil {
+		return nil, err
+	}
+
+	createReq := &models.CreateDocumentTypeRequest{
+		ID:             req.GetId(),
+		Name:           req.GetName(),
+		Description:    req.GetDescription(),
+		RequiredFields: req.GetRequiredFields(),
+		OptionalFields: req.GetOptionalFields(),
+	}
+	if err := validate(createReq); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.RegisterDocumentType(ctx, req.GetChannel(), createReq)
+	if err != nil {
+		return nil, err
+	}
+	return &govspendingv1.RegisterDocumentTypeResponse{Id: result.ID}, nil
+}
+
+func (s *Server) GetDocumentType(ctx context.Context, req *govspendingv1.GetDocumentTypeRequest) (*govspendingv1.DocumentType, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("type_id", req.GetTypeId()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.GetDocumentType(ctx, req.GetChannel(), req.GetTypeId())
+	if err != nil {
+		return nil, err
+	}
+	return toDocumentType(result), nil
+}
+
+func (s *Server) ListDocumentTypes(ctx context.Context, req *govspendingv1.ListDocumentTypesRequest) (*govspendingv1.ListDocumentTypesResponse, error) {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.ListDocumentTypes(ctx, req.GetChannel(), req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &govspendingv1.ListDocumentTypesResponse{
+		DocumentTypes: make([]*govspendingv1.DocumentType, 0, len(result)),
+	}
+	for _, dt := range result {
+		resp.DocumentTypes = append(resp.DocumentTypes, toDocumentType(dt))
+	}
+	return resp, nil
+}
+
+func (s *Server) DeactivateDocumentType(ctx context.Context, req *govspendingv1.DeactivateDocumentTypeRequest) (*govspendingv1.DeactivateDocumentTypeResponse, error) {
+	if err := s.validateWriteAccess(req.GetChannel()); err != nil {
+		return nil, err
+	}
+	if err := required("type_id", req.GetTypeId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.fabricService.DeactivateDocumentType(ctx, req.GetChannel(), req.GetTypeId()); err != nil {
+		return nil, err
+	}
+	return &govspendingv1.DeactivateDocumentTypeResponse{}, nil
+}
@@ -0,0 +1,41 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+func (s *Server) StreamEvents(req *govspendingv1.StreamEventsRequest, stream govspendingv1.EventService_StreamEventsServer) error {
+	if err := s.validateChannel(req.GetChannel()); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := s.fabricService.StreamEvents(ctx, req.GetChannel(), req.StartBlock, req.GetNames(), func(event *models.ChaincodeEvent) error {
+		return stream.Send(toEvent(event))
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-s.stopping:
+		return status.Error(codes.Unavailable, "Server shutting down")
+	default:
+		return stream.Context().Err()
+	}
+}
@@ -0,0 +1,266 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/metrics"
+	"github.com/gov-spending/backend/internal/middleware"
+)
+
+// Metadata keys, the gRPC counterparts of the X-Request-ID and
+// Idempotency-Key headers.
+const (
+	RequestIDKey      = "x-request-id"
+	IdempotencyKeyKey = "idempotency-key"
+)
+
+// unaryInterceptor assigns the call its request ID, recovers panics, turns
+// errors into gRPC statuses and logs and measures the call.
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	requestID := requestIDFromMetadata(ctx, info.FullMethod)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+	ctx = withRequestID(ctx, requestID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Ctx(ctx).Error().
+				Interface("error", r).
+				Str("method", info.FullMethod).
+				Msg("Panic recovered")
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+		finish(ctx, info.FullMethod, false, start, err)
+	}()
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		err = toStatus(ctx, info.FullMethod, err)
+	}
+	return resp, err
+}
+
+// streamInterceptor is unaryInterceptor for server streams.
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	requestID := requestIDFromMetadata(ss.Context(), info.FullMethod)
+	_ = ss.SetHeader(metadata.Pairs(RequestIDKey, requestID))
+	ctx := withRequestID(ss.Context(), requestID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Ctx(ctx).Error().
+				Interface("error", r).
+				Str("method", info.FullMethod).
+				Msg("Panic recovered")
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+		finish(ctx, info.FullMethod, true, start, err)
+	}()
+
+	err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	if err != nil {
+		err = toStatus(ctx, info.FullMethod, err)
+	}
+	return err
+}
+
+// writeMethods are the calls charged to the write limit. Every other call,
+// including anchor verification, is a read.
+var writeMethods = map[string]bool{
+	govspendingv1.DocumentTypeService_RegisterDocumentType_FullMethodName:   true,
+	govspendingv1.DocumentTypeService_DeactivateDocumentType_FullMethodName: true,
+	govspendingv1.DocumentService_CreateDocument_FullMethodName:             true,
+	govspendingv1.DocumentService_InvalidateDocument_FullMethodName:         true,
+	govspendingv1.TransferService_InitiateTransfer_FullMethodName:           true,
+	govspendingv1.TransferService_AcknowledgeTransfer_FullMethodName:        true,
+}
+
+// unaryRateLimit charges each call to the same buckets as the REST API. The
+// RateLimit-* values are returned as header metadata; a rejected call fails
+// with RESOURCE_EXHAUSTED and a retry-after header.
+func unaryRateLimit(limiter *middleware.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, err := takeRateLimit(ctx, limiter, info.FullMethod, req)
+		_ = grpc.SetHeader(ctx, md)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamRateLimit is unaryRateLimit for server streams, which are charged once
+// their request is received.
+func streamRateLimit(limiter *middleware.RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &rateLimitedStream{ServerStream: ss, limiter: limiter, method: info.FullMethod})
+	}
+}
+
+// rateLimitedStream charges the stream when its first message arrives, since
+// the channel is only known from the request.
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter *middleware.RateLimiter
+	method  string
+	charged bool
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.charged {
+		return err
+	}
+	s.charged = true
+	md, err := takeRateLimit(s.Context(), s.limiter, s.method, m)
+	_ = s.SetHeader(md)
+	return err
+}
+
+// takeRateLimit charges a call and returns its rate-limit metadata, with the
+// error to fail the call with when it was rejected. Calls are keyed by the
+// client's IP, and by channel when the request names one.
+func takeRateLimit(ctx context.Context, limiter *middleware.RateLimiter, method string, req interface{}) (metadata.MD, error) {
+	class := middleware.RateLimitRead
+	if writeMethods[method] {
+		class = middleware.RateLimitWrite
+	}
+	var channel string
+	if r, ok := req.(interface{ GetChannel() string }); ok {
+		channel = r.GetChannel()
+	}
+
+	result := limiter.Take(class, channel, "ip:"+peerIP(ctx))
+	md := metadata.New(result.Headers())
+	if result.Allowed {
+		return md, nil
+	}
+	return md, result.Err()
+}
+
+// peerIP returns the IP address of the calling client, or its whole address
+// when it has no port.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// serverStream replaces the context of a stream with the request-scoped one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// requestIDFromMetadata reuses a well-formed inbound x-request-id and
+// generates one otherwise.
+func requestIDFromMetadata(ctx context.Context, method string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(RequestIDKey); len(values) > 0 {
+		if logging.ValidRequestID(values[0]) {
+			return values[0]
+		}
+		logging.Ctx(ctx).Debug().
+			Str("method", method).
+			Int("length", len(values[0])).
+			Msg("Replacing malformed x-request-id")
+	}
+	return logging.NewRequestID()
+}
+
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("rpc.request_id", requestID))
+	return logging.WithRequestID(ctx, requestID)
+}
+
+// idempotencyKey returns the idempotency-key metadata of a write call.
+func idempotencyKey(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(IdempotencyKeyKey)
+	if len(values) == 0 {
+		return "", nil
+	}
+	if !middleware.ValidIdempotencyKey(values[0]) {
+		return "", apperrors.NewValidationError("Invalid idempotency-key metadata").
+			WithDetails("Keys must be 1 to 255 printable ASCII characters")
+	}
+	return values[0], nil
+}
+
+// toStatus converts err to the status returned to the client and logs the
+// failure the way the REST handlers do.
+func toStatus(ctx context.Context, method string, err error) error {
+	st := apperrors.GRPCStatus(err)
+
+	appErr, ok := err.(*apperrors.AppError)
+	if !ok {
+		if _, isStatus := status.FromError(err); !isStatus {
+			logging.Ctx(ctx).Error().
+				Err(err).
+				Str("method", method).
+				Msg("Unstructured error occurred")
+		}
+		return st.Err()
+	}
+
+	logEvent := logging.Ctx(ctx).Error().
+		Str("code", string(appErr.Code)).
+		Str("message", appErr.Message).
+		Str("method", method).
+		Bool("retriable", appErr.Retriable).
+		Str("grpcCode", st.Code().String())
+	if appErr.Err != nil {
+		logEvent = logEvent.Err(appErr.Err)
+	}
+	for key, value := range appErr.Context {
+		logEvent = logEvent.Interface(key, value)
+	}
+	logEvent.Msg("Request failed")
+
+	return st.Err()
+}
+
+func finish(ctx context.Context, method string, stream bool, start time.Time, err error) {
+	latency := time.Since(start)
+	code := status.Code(err)
+
+	logger := logging.Ctx(ctx)
+	logEvent := logger.Info()
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		logEvent = logger.Error()
+	default:
+		logEvent = logger.Warn()
+	}
+	logEvent.
+		Str("method", method).
+		Str("code", code.String()).
+		Dur("latency", latency).
+		Msg("gRPC Request")
+
+	metrics.ObserveGRPCRequest(method, code.String(), stream, latency)
+}
@@ -0,0 +1,139 @@
+// Package grpcapi serves the gRPC API defined in api/govspending/v1. It
+// mirrors the REST API under /api/v1 on its own port and shares its service
+// layer, channel checks and error codes.
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/config"
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/services"
+)
+
+// Server implements the govspending.v1 services.
+type Server struct {
+	govspendingv1.UnimplementedDocumentTypeServiceServer
+	govspendingv1.UnimplementedDocumentServiceServer
+	govspendingv1.UnimplementedTransferServiceServer
+	govspendingv1.UnimplementedAnchorServiceServer
+	govspendingv1.UnimplementedEventServiceServer
+
+	fabricService *services.FabricService
+	channels      atomic.Pointer[channelSet]
+
+	grpc   *grpc.Server
+	health *health.Server
+
+	// stopping is closed on shutdown to end the event streams, which would
+	// otherwise hold a graceful stop until its deadline.
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// channelSet is the configuration requests are checked against. Reload swaps
+// it as a whole, so a request never sees a mix of two configurations.
+type channelSet struct {
+	config *config.Config
+	valid  map[string]bool
+}
+
+// NewServer creates the gRPC server with the API services, the standard
+// health service and, when enabled, server reflection. Calls are rate limited
+// with the REST API's settings, against the buckets in rateLimits.
+func NewServer(fabricService *services.FabricService, cfg *config.Config, rateLimits middleware.RateLimitStore) *Server {
+	s := &Server{
+		fabricService: fabricService,
+		health:        health.NewServer(),
+		stopping:      make(chan struct{}),
+	}
+	s.Reload(cfg)
+
+	unary := []grpc.UnaryServerInterceptor{unaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{streamInterceptor}
+	if limiter := middleware.NewRateLimiter(cfg.Server.RateLimit, rateLimits, s.isValidChannel); limiter != nil {
+		unary = append(unary, unaryRateLimit(limiter))
+		stream = append(stream, streamRateLimit(limiter))
+	}
+
+	s.grpc = grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	govspendingv1.RegisterDocumentTypeServiceServer(s.grpc, s)
+	govspendingv1.RegisterDocumentServiceServer(s.grpc, s)
+	govspendingv1.RegisterTransferServiceServer(s.grpc, s)
+	govspendingv1.RegisterAnchorServiceServer(s.grpc, s)
+	govspendingv1.RegisterEventServiceServer(s.grpc, s)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	if cfg.Server.GRPC.Reflection {
+		reflection.Register(s.grpc)
+	}
+	return s
+}
+
+// Reload switches the server to a new configuration. Calls already past
+// channel validation finish under the configuration they started with.
+func (s *Server) Reload(cfg *config.Config) {
+	valid := make(map[string]bool)
+	for _, ch := range cfg.ValidChannels() {
+		valid[ch] = true
+	}
+	s.channels.Store(&channelSet{config: cfg, valid: valid})
+}
+
+// Serve accepts connections on lis until Shutdown.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown reports NOT_SERVING, ends the event streams and waits for the
+// other calls to finish. Calls still running when ctx is done are canceled.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+	s.stopOnce.Do(func() { close(s.stopping) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpc.Stop()
+	}
+}
+
+func (s *Server) isValidChannel(channel string) bool {
+	return s.channels.Load().valid[channel]
+}
+
+func (s *Server) validateChannel(channel string) error {
+	if !s.isValidChannel(channel) {
+		return apperrors.NewInvalidChannelError(channel)
+	}
+	return nil
+}
+
+func (s *Server) validateWriteAccess(channel string) error {
+	if err := s.validateChannel(channel); err != nil {
+		return err
+	}
+	if !s.channels.Load().config.IsAdminChannel(channel) {
+		return apperrors.NewWriteAccessDeniedError(channel)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/middleware"
+	"github.com/gov-spending/backend/internal/services"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+// newTestConn serves the API on an in-memory listener. Nothing reaches a
+// peer: the calls tested are rejected before the service layer.
+func newTestConn(t *testing.T, rateLimit config.RateLimitConfig) *grpc.ClientConn {
+	t.Helper()
+	readOnly := false
+	cfg := &config.Config{
+		Server: config.ServerConfig{RateLimit: rateLimit},
+		Fabric: config.FabricConfig{
+			NetworkPath:   t.TempDir(),
+			ChaincodeName: "spending",
+			Channels: map[string]config.ChannelConfig{
+				"union": {Name: "union-channel", MspID: "UnionMSP", PeerEndpoint: "localhost:7051"},
+				"state": {Name: "state-channel", MspID: "StateMSP", PeerEndpoint: "localhost:9051", Writable: &readOnly},
+			},
+		},
+	}
+
+	gateway := fabric.NewGatewayManager(cfg)
+	t.Cleanup(gateway.Close)
+	server := NewServer(services.NewFabricService(gateway), cfg, middleware.NewMemoryRateLimitStore())
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestErrorsMapToStatusCodes(t *testing.T) {
+	conn := newTestConn(t, config.RateLimitConfig{})
+	documents := govspendingv1.NewDocumentServiceClient(conn)
+	types := govspendingv1.NewDocumentTypeServiceClient(conn)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{
+			name: "unknown channel",
+			call: func() error {
+				_, err := documents.GetDocument(ctx, &govspendingv1.GetDocumentRequest{Channel: "county", DocId: "doc-1"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_CHANNEL",
+		},
+		{
+			name: "read-only channel",
+			call: func() error {
+				_, err := types.DeactivateDocumentType(ctx, &govspendingv1.DeactivateDocumentTypeRequest{Channel: "state", TypeId: "invoice"})
+				return err
+			},
+			code:   codes.PermissionDenied,
+			reason: "WRITE_ACCESS_DENIED",
+		},
+		{
+			name: "missing required field",
+			call: func() error {
+				_, err := documents.CreateDocument(ctx, &govspendingv1.CreateDocumentRequest{Channel: "union", DocumentTypeId: "invoice"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "VALIDATION_FAILED",
+		},
+		{
+			name: "missing document ID",
+			call: func() error {
+				_, err := documents.GetDocumentHistory(ctx, &govspendingv1.GetDocumentHistoryRequest{Channel: "union"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "VALIDATION_FAILED",
+		},
+		{
+			name: "invalid sort order",
+			call: func() error {
+				_, err := documents.QueryDocuments(ctx, &govspendingv1.QueryDocumentsRequest{Channel: "union", SortOrder: "sideways"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "VALIDATION_FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.code {
+				t.Fatalf("code = %s, want %s (%s)", st.Code(), tt.code, st.Message())
+			}
+			var info *errdetails.ErrorInfo
+			for _, detail := range st.Details() {
+				if d, ok := detail.(*errdetails.ErrorInfo); ok {
+					info = d
+				}
+			}
+			if info == nil {
+				t.Fatalf("status carries no ErrorInfo: %v", st.Details())
+			}
+			if info.GetReason() != tt.reason || info.GetDomain() != "gov-spending" {
+				t.Errorf("ErrorInfo = %s/%s, want %s/gov-spending", info.GetReason(), info.GetDomain(), tt.reason)
+			}
+			if info.GetMetadata()["retriable"] != "false" {
+				t.Errorf("retriable = %q, want false", info.GetMetadata()["retriable"])
+			}
+		})
+	}
+}
+
+func TestRequestIDIsEchoed(t *testing.T) {
+	conn := newTestConn(t, config.RateLimitConfig{})
+	documents := govspendingv1.NewDocumentServiceClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "audit-batch-42")
+	var header metadata.MD
+	_, _ = documents.GetDocument(ctx, &govspendingv1.GetDocumentRequest{Channel: "county", DocId: "doc-1"}, grpc.Header(&header))
+	if got := header.Get(RequestIDKey); len(got) != 1 || got[0] != "audit-batch-42" {
+		t.Errorf("%s = %v, want the inbound ID", RequestIDKey, got)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "bad id")
+	header = nil
+	_, _ = documents.GetDocument(ctx, &govspendingv1.GetDocumentRequest{Channel: "county", DocId: "doc-1"}, grpc.Header(&header))
+	if got := header.Get(RequestIDKey); len(got) != 1 || got[0] == "bad id" || got[0] == "" {
+		t.Errorf("%s = %v, want a generated ID", RequestIDKey, got)
+	}
+}
+
+func TestHealthService(t *testing.T) {
+	conn := newTestConn(t, config.RateLimitConfig{})
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("health check: %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status = %s, want SERVING", resp.GetStatus())
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	// Buckets barely refill during the test.
+	conn := newTestConn(t, config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{Rate: 0.001, Burst: 2},
+		Write:   config.RateLimitRule{Rate: 0.001, Burst: 1},
+	})
+	documents := govspendingv1.NewDocumentServiceClient(conn)
+	events := govspendingv1.NewEventServiceClient(conn)
+	ctx := context.Background()
+
+	getDocument := func(header *metadata.MD) error {
+		_, err := documents.GetDocument(ctx, &govspendingv1.GetDocumentRequest{Channel: "county", DocId: "doc-1"}, grpc.Header(header))
+		return err
+	}
+
+	var header metadata.MD
+	if err := getDocument(&header); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("first read = %v, want it to reach channel validation", err)
+	}
+	if got := header.Get("ratelimit-limit"); len(got) != 1 || got[0] != "2" {
+		t.Errorf("ratelimit-limit = %v, want [2]", got)
+	}
+	if got := header.Get("ratelimit-remaining"); len(got) != 1 || got[0] != "1" {
+		t.Errorf("ratelimit-remaining = %v, want [1]", got)
+	}
+	if got := header.Get("retry-after"); len(got) != 0 {
+		t.Errorf("an allowed call carries retry-after %v", got)
+	}
+
+	_ = getDocument(&metadata.MD{})
+	header = nil
+	st := status.Convert(getDocument(&header))
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("read past the burst = %s, want ResourceExhausted", st.Code())
+	}
+	if got := header.Get("retry-after"); len(got) != 1 || got[0] != "1000" {
+		t.Errorf("retry-after = %v, want [1000]", got)
+	}
+	var info *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = d
+		}
+	}
+	// county is not configured, so it is charged to the channel-less bucket.
+	if _, ok := info.GetMetadata()["channel"]; info.GetReason() != "RATE_LIMITED" || ok || info.GetMetadata()["retriable"] != "true" {
+		t.Errorf("ErrorInfo = %v, want a retriable RATE_LIMITED without a channel", info)
+	}
+
+	// Writes and other channels have buckets of their own.
+	create := func() error {
+		_, err := documents.CreateDocument(ctx, &govspendingv1.CreateDocumentRequest{Channel: "union", DocumentTypeId: "invoice"})
+		return err
+	}
+	if err := create(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("first write = %v, want it to reach request validation", err)
+	}
+	if err := create(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second write = %v, want ResourceExhausted", err)
+	}
+	_, err := documents.GetDocumentHistory(ctx, &govspendingv1.GetDocumentHistoryRequest{Channel: "union"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("read on union = %v, want it past the rate limit", err)
+	}
+
+	// Streams draw on the read bucket of the channel they name.
+	stream, err := events.StreamEvents(ctx, &govspendingv1.StreamEventsRequest{Channel: "county"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("stream on an exhausted channel = %v, want ResourceExhausted", err)
+	}
+	if header, err := stream.Header(); err != nil || len(header.Get("retry-after")) != 1 {
+		t.Errorf("stream header = %v, %v, want retry-after", header, err)
+	}
+}
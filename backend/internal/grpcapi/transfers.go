@@ -0,0 +1,107 @@
+package grpcapi
+
+import (
+	"context"
+
+	govspendingv1 "github.com/gov-spending/backend/api/govspending/v1"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+func (s *Server) InitiateTransfer(ctx context.Context, req *govspendingv1.InitiateTransferRequest) (*govspendingv1.TransferResult, error) {
+	transferReq := &models.InitiateTransferRequest{
+		FromChannel:    req.GetFromChannel(),
+		ToChannel:      req.GetToChannel(),
+		ToOrg:          req.GetToOrg(),
+		DocumentTypeID: req.GetDocumentTypeId(),
+		Title:          req.GetTitle(),
+		Description:    req.GetDescription(),
+		Amount:         req.GetAmount(),
+		Currency:       req.GetCurrency(),
+		Data:           toMap(req.GetData()),
+	}
+	if err := validate(transferReq); err != nil {
+		return nil, err
+	}
+	if err := s.validateChannel(transferReq.FromChannel); err != nil {
+		return nil, err
+	}
+	if err := s.validateChannel(transferReq.ToChannel); err != nil {
+		return nil, err
+	}
+	// Write access is needed on the channel the transfer originates from.
+	if err := s.validateWriteAccess(transferReq.FromChannel); err != nil {
+		return nil, err
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transferReq.IdempotencyKey = key
+
+	result, err := s.fabricService.InitiateTransfer(ctx, transferReq)
+	if err != nil {
+		return nil, err
+	}
+	return toTransferResult(result), nil
+}
+
+func (s *Server) AcknowledgeTransfer(ctx context.Context, req *govspendingv1.AcknowledgeTransferRequest) (*govspendingv1.TransferResult, error) {
+	if err := s.validateWriteAccess(req.GetChannel()); err != nil {
+		return nil, err
+	}
+
+	ackReq := &models.AcknowledgeTransferRequest{
+		SourceDocID:    req.GetSourceDocId(),
+		SourceChannel:  req.GetSourceChannel(),
+		DocumentTypeID: req.GetDocumentTypeId(),
+		Title:          req.GetTitle(),
+		Description:    req.GetDescription(),
+		Data:           toMap(req.GetData()),
+	}
+	if err := validate(ackReq); err != nil {
+		return nil, err
+	}
+	if err := s.validateChannel(ackReq.SourceChannel); err != nil {
+		return nil, err
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ackReq.IdempotencyKey = key
+
+	result, err := s.fabricService.AcknowledgeTransfer(ctx, req.GetChannel(), ackReq)
+	if err != nil {
+		return nil, err
+	}
+	return toTransferResult(result), nil
+}
+
+func (s *Server) VerifyAnchor(ctx context.Context, req *govspendingv1.VerifyAnchorRequest) (*govspendingv1.AnchorVerification, error) {
+	verifyReq := &models.VerifyAnchorRequest{
+		SourceChannel: req.GetSourceChannel(),
+		SourceDocID:   req.GetSourceDocId(),
+		TargetChannel: req.GetTargetChannel(),
+		TargetDocID:   req.GetTargetDocId(),
+	}
+	if err := validate(verifyReq); err != nil {
+		return nil, err
+	}
+	if err := s.validateChannel(verifyReq.SourceChannel); err != nil {
+		return nil, err
+	}
+	if err := s.validateChannel(verifyReq.TargetChannel); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fabricService.VerifyAnchor(ctx,
+		verifyReq.SourceChannel,
+		verifyReq.SourceDocID,
+		verifyReq.TargetChannel,
+		verifyReq.TargetDocID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return toAnchorVerification(result), nil
+}
@@ -1,40 +1,87 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rs/zerolog/log"
 
 	"github.com/gov-spending/backend/internal/config"
 	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/middleware"
 	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/response"
 	"github.com/gov-spending/backend/internal/services"
 )
 
 type Handler struct {
 	fabricService *services.FabricService
-	validChannels map[string]bool
-	config        *config.Config
+	importService *services.ImportService
+	exportService *services.ExportService
+	channels      atomic.Pointer[channelSet]
 }
 
-func NewHandler(fabricService *services.FabricService, cfg *config.Config) *Handler {
-	validChannels := cfg.ValidChannels()
-	channelMap := make(map[string]bool)
-	for _, ch := range validChannels {
-		channelMap[ch] = true
-	}
-	return &Handler{
+// channelSet is the configuration requests are checked against. Reload swaps
+// it as a whole, so a request never sees a mix of two configurations.
+type channelSet struct {
+	config *config.Config
+	valid  map[string]bool
+}
+
+// maxImportFileSize bounds uploaded import files.
+const maxImportFileSize = 32 << 20
+
+// exportPageWriteTimeout bounds writing one page of an export and fetching
+// the next; it replaces the server's WriteTimeout, which an export outlives.
+const exportPageWriteTimeout = 30 * time.Second
+
+// MaxRequestBodySize is the largest body a route accepts: an import file and
+// its form fields.
+const MaxRequestBodySize = maxImportFileSize + 1<<20
+
+func NewHandler(fabricService *services.FabricService, importService *services.ImportService, exportService *services.ExportService, cfg *config.Config) *Handler {
+	h := &Handler{
 		fabricService: fabricService,
-		validChannels: channelMap,
-		config:        cfg,
+		importService: importService,
+		exportService: exportService,
+	}
+	h.Reload(cfg)
+	return h
+}
+
+// Reload switches the handler to a new configuration. Requests already past
+// channel validation finish under the configuration they started with.
+func (h *Handler) Reload(cfg *config.Config) {
+	valid := make(map[string]bool)
+	for _, ch := range cfg.ValidChannels() {
+		valid[ch] = true
 	}
+	h.channels.Store(&channelSet{config: cfg, valid: valid})
+}
+
+func (h *Handler) config() *config.Config {
+	return h.channels.Load().config
+}
+
+// IsValidChannel reports whether channel is configured under the current
+// configuration.
+func (h *Handler) IsValidChannel(channel string) bool {
+	return h.channels.Load().valid[channel]
 }
 
 func (h *Handler) validateChannel(c *gin.Context) (string, bool) {
 	channel := c.Param("channel")
-	if !h.validChannels[channel] {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+	if !h.IsValidChannel(channel) {
+		response.Error(c, http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
 			Error:   "Invalid channel: " + channel,
 			Code:    "INVALID_CHANNEL",
@@ -45,11 +92,11 @@ func (h *Handler) validateChannel(c *gin.Context) (string, bool) {
 }
 
 func (h *Handler) validateWriteAccess(c *gin.Context, channel string) bool {
-	if !h.config.IsAdminChannel(channel) {
-		c.JSON(http.StatusForbidden, models.ErrorResponse{
+	if !h.config().IsAdminChannel(channel) {
+		response.Error(c, http.StatusForbidden, models.ErrorResponse{
 			Success: false,
 			Error:   "Write access denied: this instance does not have admin privileges on channel " + channel,
-			Code:    "WRITE_ACCESS_DENIED",
+			Code:    string(apperrors.ErrCodeWriteAccessDenied),
 		})
 		return false
 	}
@@ -59,12 +106,12 @@ func (h *Handler) validateWriteAccess(c *gin.Context, channel string) bool {
 func (h *Handler) handleError(c *gin.Context, err error) {
 	appErr, ok := err.(*apperrors.AppError)
 	if !ok {
-		log.Error().
+		logging.Ctx(c.Request.Context()).Error().
 			Err(err).
 			Str("path", c.Request.URL.Path).
 			Msg("Unstructured error occurred")
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		response.Error(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Error:   "An internal error occurred",
 			Code:    string(apperrors.ErrCodeInternalError),
@@ -72,7 +119,7 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 		return
 	}
 
-	logEvent := log.Error().
+	logEvent := logging.Ctx(c.Request.Context()).Error().
 		Str("code", string(appErr.Code)).
 		Str("message", appErr.Message).
 		Str("path", c.Request.URL.Path).
@@ -89,35 +136,28 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 
 	logEvent.Msg("Request failed")
 
-	requestID, _ := c.Get("request_id")
+	requestID, _ := c.Get(middleware.RequestIDContextKey)
 
-	response := models.ErrorResponse{
+	body := models.ErrorResponse{
 		Success: false,
 		Error:   appErr.Message,
 		Code:    string(appErr.Code),
 	}
 
 	if appErr.Details != "" {
-		response.Details = appErr.Details
+		body.Details = appErr.Details
 	}
 
 	if reqID, ok := requestID.(string); ok {
-		response.RequestID = reqID
+		body.RequestID = reqID
 	}
 
 	if len(appErr.Context) > 0 {
-		response.Context = make(map[string]any)
-		safeFields := []string{"channel", "operation", "step", "documentTypeId", "typeId"}
-		for _, field := range safeFields {
-			if val, exists := appErr.Context[field]; exists {
-				response.Context[field] = val
-			}
-		}
-		response.Context["retriable"] = appErr.Retriable
+		body.Context = appErr.PublicContext()
+		body.Context["retriable"] = appErr.Retriable
 	}
 
-
-	c.JSON(appErr.HTTPStatus, response)
+	response.Error(c, appErr.HTTPStatus, body)
 }
 
 // HealthCheck godoc
@@ -134,6 +174,47 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	})
 }
 
+// Liveness godoc
+// @Summary      Liveness probe
+// @Description  Report that the process is running and serving HTTP. Does not contact Fabric.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  map[string]string  "status: alive"
+// @Router       /health/live [get]
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "alive",
+		"service": "gov-spending-api",
+	})
+}
+
+// Readiness godoc
+// @Summary      Readiness probe
+// @Description  Probe every configured channel: peer connection, identity certificate validity, chaincode definition and a chaincode query, each within a timeout. Returns 503 when a required channel is not ready; optional channels only degrade the status.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  models.ReadinessResponse
+// @Failure      503  {object}  models.ReadinessResponse
+// @Router       /health/ready [get]
+func (h *Handler) Readiness(c *gin.Context) {
+	channels := h.fabricService.ChannelHealth(c.Request.Context())
+
+	response := models.ReadinessResponse{Status: "ready", Channels: channels}
+	status := http.StatusOK
+	for _, ch := range channels {
+		switch {
+		case ch.Ready:
+		case ch.Required:
+			response.Status = "not_ready"
+			status = http.StatusServiceUnavailable
+		case status == http.StatusOK:
+			response.Status = "degraded"
+		}
+	}
+
+	c.JSON(status, response)
+}
+
 // ConfigInfo godoc
 // @Summary      Configuration info
 // @Description  Show writable channels for this backend instance
@@ -142,15 +223,16 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 // @Success      200  {object}  map[string]interface{}
 // @Router       /config [get]
 func (h *Handler) ConfigInfo(c *gin.Context) {
-	writableChannels := h.config.GetWritableChannels()
-	allChannels := h.config.ValidChannels()
+	current := h.config()
+	writableChannels := current.GetWritableChannels()
+	allChannels := current.ValidChannels()
 
 	channelDetails := make(map[string]interface{})
 	for _, ch := range allChannels {
-		cfg, _ := h.config.GetChannelConfig(ch)
+		cfg, _ := current.GetChannelConfig(ch)
 		channelDetails[ch] = gin.H{
 			"userName":   cfg.UserName,
-			"isAdmin":    h.config.IsAdminChannel(ch),
+			"isAdmin":    current.IsAdminChannel(ch),
 			"channelName": cfg.Name,
 		}
 	}
@@ -174,10 +256,14 @@ func (h *Handler) ConfigInfo(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string                                true  "Channel (union, state, region)"
 // @Param        request  body      models.CreateDocumentTypeRequest      true  "Document type data"
-// @Success      201      {object}  models.IDResponse
-// @Failure      400      {object}  models.ErrorResponse
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/{channel}/document-types [post]
+// @Param        async    query     bool    false  "Return 202 Accepted once submitted instead of waiting for the commit"
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      201      {object}  models.Envelope{data=models.ResourceID}
+// @Success      202      {object}  models.Envelope{data=models.SubmittedTransaction}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/document-types [post]
 func (h *Handler) RegisterDocumentType(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -196,13 +282,27 @@ func (h *Handler) RegisterDocumentType(c *gin.Context) {
 		return
 	}
 
-	result, err := h.fabricService.RegisterDocumentType(channel, &req)
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		submitted, err := h.fabricService.RegisterDocumentTypeAsync(c.Request.Context(), channel, &req)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response.Submitted(c, submitted)
+		return
+	}
+
+	result, err := h.fabricService.RegisterDocumentType(c.Request.Context(), channel, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	response.Legacy(c, http.StatusCreated, models.ResourceID{ID: result.ID}, result)
 }
 
 
@@ -213,9 +313,10 @@ func (h *Handler) RegisterDocumentType(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
 // @Param        typeId   path      string  true  "Document type ID"
-// @Success      200      {object}  models.DocumentType
-// @Failure      404      {object}  models.ErrorResponse
-// @Router       /api/{channel}/document-types/{typeId} [get]
+// @Success      200      {object}  models.Envelope{data=models.DocumentType}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/document-types/{typeId} [get]
 func (h *Handler) GetDocumentType(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -224,13 +325,13 @@ func (h *Handler) GetDocumentType(c *gin.Context) {
 
 	typeID := c.Param("typeId")
 
-	result, err := h.fabricService.GetDocumentType(channel, typeID)
+	result, err := h.fabricService.GetDocumentType(c.Request.Context(), channel, typeID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	response.OK(c, http.StatusOK, result)
 }
 
 // ListDocumentTypes godoc
@@ -239,8 +340,9 @@ func (h *Handler) GetDocumentType(c *gin.Context) {
 // @Tags         Document Types
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
-// @Success      200      {array}   models.DocumentType
-// @Router       /api/{channel}/document-types [get]
+// @Success      200      {object}  models.Envelope{data=[]models.DocumentType}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/document-types [get]
 func (h *Handler) ListDocumentTypes(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -249,18 +351,46 @@ func (h *Handler) ListDocumentTypes(c *gin.Context) {
 
 	orgID := c.Query("organizationId")
 
-	result, err := h.fabricService.ListDocumentTypes(channel, orgID)
+	result, err := h.fabricService.ListDocumentTypes(c.Request.Context(), channel, orgID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response.List(c, http.StatusOK, result, gin.H{
 		"documentTypes": result,
 		"total":         len(result),
 	})
 }
 
+// GetDocumentTypeIndexes godoc
+// @Summary      Derive CouchDB indexes
+// @Description  Derive CouchDB index definitions for the data fields declared by a document type, ready to package under META-INF/statedb/couchdb/indexes
+// @Tags         Document Types
+// @Produce      json
+// @Param        channel  path      string  true  "Channel (union, state, region)"
+// @Param        typeId   path      string  true  "Document type ID"
+// @Success      200      {object}  models.Envelope{data=[]models.CouchDBIndex}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/document-types/{typeId}/indexes [get]
+func (h *Handler) GetDocumentTypeIndexes(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	typeID := c.Param("typeId")
+
+	result, err := h.fabricService.DeriveDocumentTypeIndexes(c.Request.Context(), channel, typeID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.List(c, http.StatusOK, result, result)
+}
+
 // DeactivateDocumentType godoc
 // @Summary      Deactivate document type
 // @Description  Mark document type as inactive (no new documents allowed)
@@ -268,9 +398,13 @@ func (h *Handler) ListDocumentTypes(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
 // @Param        typeId   path      string  true  "Document type ID"
-// @Success      200      {object}  models.SuccessResponse
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/{channel}/document-types/{typeId} [delete]
+// @Param        async    query     bool    false  "Return 202 Accepted once submitted instead of waiting for the commit"
+// @Success      200      {object}  models.Envelope{data=models.ActionResult}
+// @Success      202      {object}  models.Envelope{data=models.SubmittedTransaction}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/document-types/{typeId} [delete]
 func (h *Handler) DeactivateDocumentType(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -284,12 +418,26 @@ func (h *Handler) DeactivateDocumentType(c *gin.Context) {
 
 	typeID := c.Param("typeId")
 
-	if err := h.fabricService.DeactivateDocumentType(channel, typeID); err != nil {
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		submitted, err := h.fabricService.DeactivateDocumentTypeAsync(c.Request.Context(), channel, typeID)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response.Submitted(c, submitted)
+		return
+	}
+
+	if err := h.fabricService.DeactivateDocumentType(c.Request.Context(), channel, typeID); err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse{
+	response.Legacy(c, http.StatusOK, models.ActionResult{ID: typeID, Message: "Document type deactivated"}, models.SuccessResponse{
 		Success: true,
 		Message: "Document type deactivated",
 	})
@@ -303,10 +451,14 @@ func (h *Handler) DeactivateDocumentType(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string                        true  "Channel (union, state, region)"
 // @Param        request  body      models.CreateDocumentRequest  true  "Document data"
-// @Success      201      {object}  models.Document
-// @Failure      400      {object}  models.ErrorResponse
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/{channel}/documents [post]
+// @Param        async    query     bool    false  "Return 202 Accepted once submitted instead of waiting for the commit"
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      201      {object}  models.Envelope{data=models.ResourceID}
+// @Success      202      {object}  models.Envelope{data=models.SubmittedTransaction}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents [post]
 func (h *Handler) CreateDocument(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -325,13 +477,28 @@ func (h *Handler) CreateDocument(c *gin.Context) {
 		return
 	}
 
-	result, err := h.fabricService.CreateDocument(channel, &req)
+	req.IdempotencyKey = c.GetString(middleware.IdempotencyKeyContextKey)
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		submitted, err := h.fabricService.CreateDocumentAsync(c.Request.Context(), channel, &req)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response.Submitted(c, submitted)
+		return
+	}
+
+	result, err := h.fabricService.CreateDocument(c.Request.Context(), channel, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	response.Legacy(c, http.StatusCreated, models.ResourceID{ID: result.ID}, result)
 }
 
 // GetDocument godoc
@@ -341,9 +508,10 @@ func (h *Handler) CreateDocument(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
 // @Param        docId    path      string  true  "Document ID"
-// @Success      200      {object}  models.Document
-// @Failure      404      {object}  models.ErrorResponse
-// @Router       /api/{channel}/documents/{docId} [get]
+// @Success      200      {object}  models.Envelope{data=models.Document}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents/{docId} [get]
 func (h *Handler) GetDocument(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -352,18 +520,18 @@ func (h *Handler) GetDocument(c *gin.Context) {
 
 	docID := c.Param("docId")
 
-	result, err := h.fabricService.GetDocument(channel, docID)
+	result, err := h.fabricService.GetDocument(c.Request.Context(), channel, docID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	response.OK(c, http.StatusOK, result)
 }
 
 // QueryDocuments godoc
 // @Summary      Query documents
-// @Description  Search and filter documents with pagination
+// @Description  Search and filter documents with pagination. meta.pagination.nextBookmark fetches the next page while hasMore is true.
 // @Tags         Documents
 // @Produce      json
 // @Param        channel          path      string  true   "Channel (union, state, region)"
@@ -376,20 +544,23 @@ func (h *Handler) GetDocument(c *gin.Context) {
 // @Param        maxAmount        query     number  false  "Maximum amount"
 // @Param        hasLinkedDoc     query     bool    false  "Has linked document"
 // @Param        linkedDirection  query     string  false  "Link direction"  Enums(OUTGOING, INCOMING)
+// @Param        sortBy           query     string  false  "Sort field"  Enums(createdAt, updatedAt, amount, title)  default(createdAt)
+// @Param        sortOrder        query     string  false  "Sort direction"  Enums(asc, desc)  default(desc)
+// @Param        count            query     string  false  "Include total matches (estimated stops at 1000 and sets totalEstimated)"  Enums(exact, estimated)
+// @Param        where            query     []string  false  "Field conditions as field:op:value, e.g. data.municipality:in:Campinas|Santos (ops: eq, in, gt, gte, lt, lte, exists)"  collectionFormat(multi)
 // @Param        pageSize         query     int     false  "Page size"  default(20)
 // @Param        bookmark         query     string  false  "Pagination bookmark"
-// @Success      200              {object}  models.QueryResult
-// @Router       /api/{channel}/documents [get]
+// @Success      200              {object}  models.Envelope{data=[]models.Document}
+// @Failure      400              {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents [get]
 func (h *Handler) QueryDocuments(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
 		return
 	}
 
-	var filter models.QueryFilter
-	if err := c.ShouldBindQuery(&filter); err != nil {
-		validationErr := apperrors.NewValidationError("Invalid query parameters: " + err.Error())
-		h.handleError(c, validationErr)
+	filter, ok := h.bindQueryFilter(c)
+	if !ok {
 		return
 	}
 
@@ -397,13 +568,19 @@ func (h *Handler) QueryDocuments(c *gin.Context) {
 		filter.PageSize = 20
 	}
 
-	result, err := h.fabricService.QueryDocuments(channel, &filter)
+	result, err := h.fabricService.QueryDocuments(c.Request.Context(), channel, filter)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	response.Page(c, http.StatusOK, result.Documents, &models.Pagination{
+		Count:          result.Count,
+		NextBookmark:   result.Bookmark,
+		HasMore:        result.Bookmark != "" && result.Count >= filter.PageSize,
+		Total:          result.Total,
+		TotalEstimated: result.TotalEstimated,
+	}, result)
 }
 
 
@@ -416,11 +593,14 @@ func (h *Handler) QueryDocuments(c *gin.Context) {
 // @Param        channel  path      string                            true  "Channel (union, state, region)"
 // @Param        docId    path      string                            true  "Document ID"
 // @Param        request  body      models.InvalidateDocumentRequest  true  "Invalidation reason and correction doc"
-// @Success      200      {object}  models.SuccessResponse
-// @Failure      400      {object}  models.ErrorResponse
-// @Failure      403      {object}  models.ErrorResponse  "Only creating organization can invalidate"
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/{channel}/documents/{docId}/invalidate [post]
+// @Param        async    query     bool    false  "Return 202 Accepted once submitted instead of waiting for the commit"
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      200      {object}  models.Envelope{data=models.ActionResult}
+// @Success      202      {object}  models.Envelope{data=models.SubmittedTransaction}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope  "Only creating organization can invalidate"
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents/{docId}/invalidate [post]
 func (h *Handler) InvalidateDocument(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -441,12 +621,26 @@ func (h *Handler) InvalidateDocument(c *gin.Context) {
 		return
 	}
 
-	if err := h.fabricService.InvalidateDocument(channel, docID, &req); err != nil {
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		submitted, err := h.fabricService.InvalidateDocumentAsync(c.Request.Context(), channel, docID, &req)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response.Submitted(c, submitted)
+		return
+	}
+
+	if err := h.fabricService.InvalidateDocument(c.Request.Context(), channel, docID, &req); err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, models.SuccessResponse{
+	response.Legacy(c, http.StatusOK, models.ActionResult{ID: docID, Message: "Document invalidated"}, models.SuccessResponse{
 		Success: true,
 		Message: "Document invalidated",
 	})
@@ -459,9 +653,10 @@ func (h *Handler) InvalidateDocument(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
 // @Param        docId    path      string  true  "Document ID"
-// @Success      200      {array}   models.HistoryEntry
-// @Failure      404      {object}  models.ErrorResponse
-// @Router       /api/{channel}/documents/{docId}/history [get]
+// @Success      200      {object}  models.Envelope{data=[]models.HistoryEntry}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents/{docId}/history [get]
 func (h *Handler) GetDocumentHistory(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -470,13 +665,13 @@ func (h *Handler) GetDocumentHistory(c *gin.Context) {
 
 	docID := c.Param("docId")
 
-	result, err := h.fabricService.GetDocumentHistory(channel, docID)
+	result, err := h.fabricService.GetDocumentHistory(c.Request.Context(), channel, docID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response.List(c, http.StatusOK, result, gin.H{
 		"history": result,
 		"total":   len(result),
 	})
@@ -489,9 +684,10 @@ func (h *Handler) GetDocumentHistory(c *gin.Context) {
 // @Produce      json
 // @Param        channel  path      string  true  "Channel (union, state, region)"
 // @Param        docId    path      string  true  "Document ID"
-// @Success      200      {object}  models.LinkedDocuments
-// @Failure      404      {object}  models.ErrorResponse
-// @Router       /api/{channel}/documents/{docId}/linked [get]
+// @Success      200      {object}  models.Envelope{data=models.LinkedDocuments}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/documents/{docId}/linked [get]
 func (h *Handler) GetLinkedDocuments(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -500,13 +696,13 @@ func (h *Handler) GetLinkedDocuments(c *gin.Context) {
 
 	docID := c.Param("docId")
 
-	result, err := h.fabricService.GetLinkedDocuments(channel, docID)
+	result, err := h.fabricService.GetLinkedDocuments(c.Request.Context(), channel, docID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	response.OK(c, http.StatusOK, result)
 }
 
 // InitiateTransfer godoc
@@ -516,10 +712,14 @@ func (h *Handler) GetLinkedDocuments(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request  body      models.InitiateTransferRequest  true  "Transfer details"
-// @Success      201      {object}  models.TransferResult
-// @Failure      400      {object}  models.ErrorResponse
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/transfers/initiate [post]
+// @Param        async    query     bool    false  "Return 202 Accepted once submitted instead of waiting for the commit"
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      201      {object}  models.Envelope{data=models.TransferResult}
+// @Success      202      {object}  models.Envelope{data=models.SubmittedTransaction}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/transfers/initiate [post]
 func (h *Handler) InitiateTransfer(c *gin.Context) {
 	var req models.InitiateTransferRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -528,49 +728,66 @@ func (h *Handler) InitiateTransfer(c *gin.Context) {
 		return
 	}
 
-	if !h.validChannels[req.FromChannel] {
+	if !h.IsValidChannel(req.FromChannel) {
 		channelErr := apperrors.NewInvalidChannelError(req.FromChannel)
 		h.handleError(c, channelErr)
 		return
 	}
-	if !h.validChannels[req.ToChannel] {
+	if !h.IsValidChannel(req.ToChannel) {
 		channelErr := apperrors.NewInvalidChannelError(req.ToChannel)
 		h.handleError(c, channelErr)
 		return
 	}
 
 	// Check write access on FromChannel (where the transfer originates)
-	if !h.config.IsAdminChannel(req.FromChannel) {
-		c.JSON(http.StatusForbidden, models.ErrorResponse{
+	if !h.config().IsAdminChannel(req.FromChannel) {
+		response.Error(c, http.StatusForbidden, models.ErrorResponse{
 			Success: false,
 			Error:   "Write access denied: this instance does not have admin privileges on channel " + req.FromChannel,
-			Code:    "WRITE_ACCESS_DENIED",
+			Code:    string(apperrors.ErrCodeWriteAccessDenied),
 		})
 		return
 	}
 
-	result, err := h.fabricService.InitiateTransfer(&req)
+	req.IdempotencyKey = c.GetString(middleware.IdempotencyKeyContextKey)
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		submitted, err := h.fabricService.InitiateTransferAsync(c.Request.Context(), &req)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		response.Submitted(c, submitted)
+		return
+	}
+
+	result, err := h.fabricService.InitiateTransfer(c.Request.Context(), &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	response.OK(c, http.StatusCreated, result)
 }
 
 
 // AcknowledgeTransfer godoc
 // @Summary      Acknowledge transfer
-// @Description  Acknowledge a received cross-channel transfer. Creates linked document on target channel.
+// @Description  Acknowledge a received cross-channel transfer. Creates linked document on target channel. There is no async mode: the source document is then linked to the acknowledgment by its committed content hash, so the request always waits for both commits.
 // @Tags         Transfers
 // @Accept       json
 // @Produce      json
 // @Param        channel  path      string                              true  "Target channel (union, state, region)"
 // @Param        request  body      models.AcknowledgeTransferRequest   true  "Acknowledgment details"
-// @Success      201      {object}  models.TransferResult
-// @Failure      400      {object}  models.ErrorResponse
-// @Failure      500      {object}  models.ErrorResponse
-// @Router       /api/{channel}/transfers/acknowledge [post]
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      201      {object}  models.Envelope{data=models.TransferResult}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      403      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/transfers/acknowledge [post]
 func (h *Handler) AcknowledgeTransfer(c *gin.Context) {
 	channel, ok := h.validateChannel(c)
 	if !ok {
@@ -589,19 +806,32 @@ func (h *Handler) AcknowledgeTransfer(c *gin.Context) {
 		return
 	}
 
-	if !h.validChannels[req.SourceChannel] {
+	if !h.IsValidChannel(req.SourceChannel) {
 		channelErr := apperrors.NewInvalidChannelError(req.SourceChannel)
 		h.handleError(c, channelErr)
 		return
 	}
 
-	result, err := h.fabricService.AcknowledgeTransfer(channel, &req)
+	// Linking the source needs the committed acknowledgment, so the request
+	// cannot return before it; refuse async rather than silently ignore it.
+	async, ok := h.bindAsync(c)
+	if !ok {
+		return
+	}
+	if async {
+		h.handleError(c, apperrors.NewValidationError("async is not supported when acknowledging a transfer").
+			WithDetails("The source document is linked to the committed acknowledgment, so acknowledgments always wait for their commits"))
+		return
+	}
+
+	req.IdempotencyKey = c.GetString(middleware.IdempotencyKeyContextKey)
+	result, err := h.fabricService.AcknowledgeTransfer(c.Request.Context(), channel, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, result)
+	response.OK(c, http.StatusCreated, result)
 }
 
 // VerifyAnchor godoc
@@ -611,9 +841,10 @@ func (h *Handler) AcknowledgeTransfer(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request  body      models.VerifyAnchorRequest  true  "Source and target document info"
-// @Success      200      {object}  models.AnchorVerification
-// @Failure      400      {object}  models.ErrorResponse
-// @Router       /api/anchors/verify [post]
+// @Param        Idempotency-Key  header  string  false  "Replays the original response when a request is retried with the same key"
+// @Success      200      {object}  models.Envelope{data=models.AnchorVerification}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Router       /api/v1/anchors/verify [post]
 func (h *Handler) VerifyAnchor(c *gin.Context) {
 	var req models.VerifyAnchorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -623,18 +854,19 @@ func (h *Handler) VerifyAnchor(c *gin.Context) {
 	}
 
 	// Validate channels
-	if !h.validChannels[req.SourceChannel] {
+	if !h.IsValidChannel(req.SourceChannel) {
 		channelErr := apperrors.NewInvalidChannelError(req.SourceChannel)
 		h.handleError(c, channelErr)
 		return
 	}
-	if !h.validChannels[req.TargetChannel] {
+	if !h.IsValidChannel(req.TargetChannel) {
 		channelErr := apperrors.NewInvalidChannelError(req.TargetChannel)
 		h.handleError(c, channelErr)
 		return
 	}
 
 	result, err := h.fabricService.VerifyAnchor(
+		c.Request.Context(),
 		req.SourceChannel,
 		req.SourceDocID,
 		req.TargetChannel,
@@ -645,5 +877,378 @@ func (h *Handler) VerifyAnchor(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
-}
\ No newline at end of file
+	response.OK(c, http.StatusOK, result)
+}
+
+// =============================================================================
+// Bulk Import
+// =============================================================================
+
+// StartImport godoc
+// @Summary      Start bulk import
+// @Description  Upload a CSV or NDJSON file whose rows are mapped to a document type. Every row is validated before any is submitted; valid rows are created in the background with bounded concurrency. Rows without a mapped id column get IDs derived from their content, so re-running with the same importKey skips rows that already succeeded. The default importKey is derived from the mapping, so re-running with a corrected file and the same mapping resumes the import.
+// @Tags         Imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        channel         path      string  true   "Channel (union, state, region)"
+// @Param        file            formData  file    true   "CSV (header row first) or NDJSON file"
+// @Param        documentTypeId  formData  string  true   "Document type of every row"
+// @Param        mapping         formData  string  true   "JSON models.ImportMapping from document fields to columns"
+// @Param        format          formData  string  false  "File format, inferred from the file extension when omitted"  Enums(csv, ndjson)
+// @Param        importKey       formData  string  false  "Key that scopes row IDs; reuse it to resume an import with a corrected file (defaults to a hash of the mapping)"
+// @Param        concurrency     formData  int     false  "Parallel submissions (max 16)"  default(4)
+// @Param        dryRun          formData  bool    false  "Only validate rows"
+// @Param        Idempotency-Key header    string  false  "Replays the original response when a request is retried with the same key"
+// @Success      202             {object}  models.Envelope{data=models.ImportJob}
+// @Failure      400             {object}  models.ErrorEnvelope
+// @Failure      403             {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/imports [post]
+func (h *Handler) StartImport(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	// Check write access
+	if !h.validateWriteAccess(c, channel) {
+		return
+	}
+
+	var req models.ImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		validationErr := apperrors.NewValidationError("Invalid import request: " + err.Error())
+		h.handleError(c, validationErr)
+		return
+	}
+
+	var mapping models.ImportMapping
+	if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+		validationErr := apperrors.NewValidationError("Invalid import mapping: " + err.Error())
+		h.handleError(c, validationErr)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		validationErr := apperrors.NewValidationError("Missing import file: " + err.Error())
+		h.handleError(c, validationErr)
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		validationErr := apperrors.NewValidationError(fmt.Sprintf("Import file exceeds %d MB", maxImportFileSize>>20))
+		h.handleError(c, validationErr)
+		return
+	}
+
+	if req.Format == "" {
+		switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+		case ".csv":
+			req.Format = models.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			req.Format = models.ImportFormatNDJSON
+		default:
+			validationErr := apperrors.NewValidationError("Cannot infer import format from " + fileHeader.Filename + "; set format to csv or ndjson")
+			h.handleError(c, validationErr)
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.handleError(c, apperrors.NewAppError(apperrors.ErrCodeInternalError, "Failed to open import file", err))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		h.handleError(c, apperrors.NewAppError(apperrors.ErrCodeInternalError, "Failed to read import file", err))
+		return
+	}
+
+	job, err := h.importService.StartImport(c.Request.Context(), channel, &req, &mapping, content)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.OK(c, http.StatusAccepted, job)
+}
+
+// GetImport godoc
+// @Summary      Get import job
+// @Description  Get progress and per-row results of an import job
+// @Tags         Imports
+// @Produce      json
+// @Param        channel    path      string  true   "Channel (union, state, region)"
+// @Param        jobId      path      string  true   "Import job ID"
+// @Param        rowStatus  query     string  false  "Only include rows with this status"  Enums(PENDING, VALID, CREATED, SKIPPED, INVALID, FAILED)
+// @Success      200        {object}  models.Envelope{data=models.ImportJob}
+// @Failure      400        {object}  models.ErrorEnvelope
+// @Failure      404        {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/imports/{jobId} [get]
+func (h *Handler) GetImport(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	jobID := c.Param("jobId")
+	rowStatus := models.ImportRowStatus(c.Query("rowStatus"))
+
+	job, err := h.importService.GetJob(channel, jobID, rowStatus)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.OK(c, http.StatusOK, job)
+}
+
+// ListImports godoc
+// @Summary      List import jobs
+// @Description  List recent import jobs on a channel (without row results)
+// @Tags         Imports
+// @Produce      json
+// @Param        channel  path      string  true  "Channel (union, state, region)"
+// @Success      200      {object}  models.Envelope{data=[]models.ImportJob}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/imports [get]
+func (h *Handler) ListImports(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	jobs := h.importService.ListJobs(channel)
+
+	response.List(c, http.StatusOK, jobs, gin.H{
+		"imports": jobs,
+		"total":   len(jobs),
+	})
+}
+
+// =============================================================================
+// Bulk Export
+// =============================================================================
+
+// ExportDocuments godoc
+// @Summary      Export documents
+// @Description  Stream every document matching the filter as CSV, NDJSON or Parquet, paging through the ledger without loading the result set into memory. The X-Export-ID header names the export; its signed manifest with the SHA-256 of the file is available once the stream ends, and the digest is also sent as the X-Export-SHA256 trailer. A stream that fails after the status line ends early with an X-Export-Error trailer instead; treat the file as incomplete.
+// @Tags         Exports
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.apache.parquet
+// @Param        channel          path      string    true   "Channel (union, state, region)"
+// @Param        format           query     string    true   "Export format"  Enums(csv, ndjson, parquet)
+// @Param        organizationId   query     string    false  "Filter by organization"
+// @Param        documentTypeId   query     string    false  "Filter by document type"
+// @Param        status           query     string    false  "Filter by status (ACTIVE, INVALIDATED)"  Enums(ACTIVE, INVALIDATED)
+// @Param        fromDate         query     string    false  "From date (ISO 8601)"
+// @Param        toDate           query     string    false  "To date (ISO 8601)"
+// @Param        minAmount        query     number    false  "Minimum amount"
+// @Param        maxAmount        query     number    false  "Maximum amount"
+// @Param        hasLinkedDoc     query     bool      false  "Has linked document"
+// @Param        linkedDirection  query     string    false  "Link direction"  Enums(OUTGOING, INCOMING)
+// @Param        sortBy           query     string    false  "Sort field"  Enums(createdAt, updatedAt, amount, title)  default(createdAt)
+// @Param        sortOrder        query     string    false  "Sort direction"  Enums(asc, desc)  default(desc)
+// @Param        where            query     []string  false  "Field conditions as field:op:value"  collectionFormat(multi)
+// @Success      200              {file}    file
+// @Failure      400              {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/exports/documents [get]
+func (h *Handler) ExportDocuments(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	format := models.ExportFormat(c.Query("format"))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		validationErr := apperrors.NewValidationError("Invalid export format: " + string(format)).
+			WithDetails("Supported formats are csv, ndjson and parquet")
+		h.handleError(c, validationErr)
+		return
+	}
+
+	filter, ok := h.bindQueryFilter(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.exportService.PrepareExport(c.Request.Context(), channel, format, filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", channel+"-documents-"+export.ID+"."+string(format)))
+	header.Set("X-Export-ID", export.ID)
+	header.Set("Trailer", "X-Export-SHA256, X-Export-Error")
+	c.Status(http.StatusOK)
+
+	writer := http.NewResponseController(c.Writer)
+	export.BeforePage = func() error {
+		err := writer.SetWriteDeadline(time.Now().Add(exportPageWriteTimeout))
+		if errors.Is(err, http.ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := export.WriteTo(c.Writer); err != nil {
+		logging.Ctx(c.Request.Context()).Error().
+			Err(err).
+			Str("exportId", export.ID).
+			Str("channel", channel).
+			Msg("Export stream interrupted")
+		// The status line is already sent: the trailer is the only way left
+		// to tell the client the file is truncated.
+		header.Set("X-Export-Error", strings.Join(strings.Fields(err.Error()), " "))
+		return
+	}
+
+	header.Set("X-Export-SHA256", export.SHA256())
+}
+
+// GetExportManifest godoc
+// @Summary      Get export manifest
+// @Description  Get the signed manifest of a finished export, including the SHA-256 of the exported file
+// @Tags         Exports
+// @Produce      json
+// @Param        channel   path      string  true  "Channel (union, state, region)"
+// @Param        exportId  path      string  true  "Export ID (X-Export-ID header)"
+// @Success      200       {object}  models.Envelope{data=models.ExportManifest}
+// @Failure      400       {object}  models.ErrorEnvelope
+// @Failure      404       {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/exports/{exportId}/manifest [get]
+func (h *Handler) GetExportManifest(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	manifest, err := h.exportService.GetManifest(channel, c.Param("exportId"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.OK(c, http.StatusOK, manifest)
+}
+
+var exportContentTypes = map[models.ExportFormat]string{
+	models.ExportFormatCSV:     "text/csv; charset=utf-8",
+	models.ExportFormatNDJSON:  "application/x-ndjson",
+	models.ExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// =============================================================================
+// Transactions
+// =============================================================================
+
+// GetTransactionStatus godoc
+// @Summary      Get transaction status
+// @Description  Report the commit status of a transaction, e.g. one submitted with async=true. Status is PENDING until the transaction is on the ledger, then VALID or the peer's validation code (MVCC_READ_CONFLICT, ENDORSEMENT_POLICY_FAILURE, ...).
+// @Tags         Transactions
+// @Produce      json
+// @Param        channel  path      string  true  "Channel (union, state, region)"
+// @Param        txId     path      string  true  "Fabric transaction ID"
+// @Success      200      {object}  models.Envelope{data=models.TransactionStatus}
+// @Failure      400      {object}  models.ErrorEnvelope
+// @Failure      404      {object}  models.ErrorEnvelope
+// @Failure      500      {object}  models.ErrorEnvelope
+// @Router       /api/v1/{channel}/transactions/{txId} [get]
+func (h *Handler) GetTransactionStatus(c *gin.Context) {
+	channel, ok := h.validateChannel(c)
+	if !ok {
+		return
+	}
+
+	txID := c.Param("txId")
+
+	result, err := h.fabricService.GetTransactionStatus(c.Request.Context(), channel, txID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.OK(c, http.StatusOK, result)
+}
+
+// =============================================================================
+// Query Helpers
+// =============================================================================
+
+// bindAsync reads the async query parameter accepted by write endpoints.
+func (h *Handler) bindAsync(c *gin.Context) (bool, bool) {
+	raw := c.Query("async")
+	if raw == "" {
+		return false, true
+	}
+
+	async, err := strconv.ParseBool(raw)
+	if err != nil {
+		h.handleError(c, apperrors.NewValidationError("Invalid async parameter: "+raw))
+		return false, false
+	}
+	return async, true
+}
+
+// bindQueryFilter binds the document filter query parameters, including
+// where clauses, and writes a validation error response on failure.
+func (h *Handler) bindQueryFilter(c *gin.Context) (*models.QueryFilter, bool) {
+	var filter models.QueryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := apperrors.NewValidationError("Invalid query parameters: " + err.Error())
+		h.handleError(c, validationErr)
+		return nil, false
+	}
+
+	conditions, err := parseWhereClauses(filter.Where)
+	if err != nil {
+		h.handleError(c, apperrors.NewAppError(apperrors.ErrCodeInvalidQuery, err.Error(), err))
+		return nil, false
+	}
+	filter.Conditions = conditions
+
+	return &filter, true
+}
+
+// parseWhereClauses turns "field:op:value" query parameters into field
+// conditions. Values that parse as a JSON scalar keep their type (10, true,
+// "10"); anything else is taken as a plain string. "in" values are separated
+// by "|". Field and operator whitelisting is enforced by the chaincode.
+func parseWhereClauses(clauses []string) ([]models.FieldCondition, error) {
+	conditions := make([]models.FieldCondition, 0, len(clauses))
+	for _, clause := range clauses {
+		parts := strings.SplitN(clause, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid where clause %q: expected field:op:value", clause)
+		}
+
+		cond := models.FieldCondition{Field: parts[0], Op: parts[1]}
+		if cond.Op == "in" {
+			for _, v := range strings.Split(parts[2], "|") {
+				cond.Values = append(cond.Values, parseWhereValue(v))
+			}
+		} else {
+			cond.Value = parseWhereValue(parts[2])
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, nil
+}
+
+func parseWhereValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err == nil {
+		switch value.(type) {
+		case string, float64, bool:
+			return value
+		}
+	}
+	return raw
+}
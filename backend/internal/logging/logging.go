@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type requestIDKey struct{}
+
+const maxRequestIDLength = 128
+
+// WithRequestID returns a copy of ctx that carries the request ID and a logger
+// adding it to every line. Work started from the request, including
+// background work under context.WithoutCancel, keeps both.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	logger := log.With().Str("request_id", requestID).Logger()
+	return logger.WithContext(context.WithValue(ctx, requestIDKey{}, requestID))
+}
+
+// RequestID returns the ID of the request ctx belongs to, or "".
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Ctx returns the request-scoped logger of ctx, or the global logger outside a
+// request.
+func Ctx(ctx context.Context) *zerolog.Logger {
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+	return &log.Logger
+}
+
+// NewRequestID returns a UUIDv7: random and unguessable, but sortable by
+// creation time.
+func NewRequestID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// ValidRequestID accepts 1 to 128 characters of [A-Za-z0-9._:-], which covers
+// UUIDs, ULIDs and the IDs of common proxies while keeping logs and headers
+// safe from injection.
+func ValidRequestID(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		ch := id[i]
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+		case ch == '.', ch == '_', ch == ':', ch == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gov_spending"
+
+// Registry holds every collector exposed on /metrics. A dedicated registry
+// keeps metrics registered by dependencies out of the output.
+var Registry = prometheus.NewRegistry()
+
+// =============================================================================
+// HTTP
+// =============================================================================
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route", "status"})
+)
+
+// =============================================================================
+// gRPC
+// =============================================================================
+
+var (
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC unary call latency by method and status code.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "code"})
+)
+
+// =============================================================================
+// Fabric
+// =============================================================================
+
+var (
+	fabricDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "call_duration_seconds",
+		Help:      "Latency of chaincode evaluations and submissions, including retries.",
+		Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"kind", "channel", "function"})
+
+	fabricErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "call_errors_total",
+		Help:      "Failed chaincode evaluations and submissions by API error code.",
+	}, []string{"kind", "channel", "function", "code"})
+
+	connectionState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "connection_state",
+		Help:      "1 for the current connection state of each channel, 0 otherwise.",
+	}, []string{"channel", "state"})
+
+	connectionFailovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "connection_failovers_total",
+		Help:      "Connections established on a peer other than the one tried first.",
+	}, []string{"channel"})
+
+	connectionEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fabric",
+		Name:      "connection_evictions_total",
+		Help:      "Peer connections closed because the peer became unreachable.",
+	}, []string{"channel"})
+)
+
+// ConnectionStates lists the values of the state label.
+var ConnectionStates = []string{
+	"NOT_CONNECTED", "DISCONNECTED", "IDLE", "CONNECTING", "READY", "TRANSIENT_FAILURE", "SHUTDOWN",
+}
+
+// =============================================================================
+// Domain Events
+// =============================================================================
+
+var (
+	documentsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "documents_created_total",
+		Help:      "Documents committed to the ledger, including transfer documents.",
+	}, []string{"channel"})
+
+	documentsInvalidated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "documents_invalidated_total",
+		Help:      "Documents invalidated on the ledger.",
+	}, []string{"channel"})
+
+	transfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Inter-government transfers by stage (initiated, acknowledged) and the channel written to.",
+	}, []string{"stage", "channel"})
+
+	anchorVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "anchor_verifications_total",
+		Help:      "Cross-channel anchor verifications by result (VERIFIED, MISMATCH).",
+	}, []string{"result"})
+
+	anchorMismatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "anchor_mismatches_total",
+		Help:      "Failed anchor checks by reason. A MISMATCH can have several reasons.",
+	}, []string{"reason"})
+)
+
+const (
+	TransferInitiated    = "initiated"
+	TransferAcknowledged = "acknowledged"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		grpcRequests,
+		grpcDuration,
+		fabricDuration,
+		fabricErrors,
+		connectionState,
+		connectionFailovers,
+		connectionEvictions,
+		documentsCreated,
+		documentsInvalidated,
+		transfers,
+		anchorVerifications,
+		anchorMismatches,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a served request. route is the matched route
+// template, so path parameters do not create a series per ID.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveGRPCRequest records a finished gRPC call. Streams are counted but
+// their duration is not observed, as it is as long as the client listens.
+func ObserveGRPCRequest(method, code string, stream bool, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code).Inc()
+	if !stream {
+		grpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+	}
+}
+
+// ObserveFabricCall records a chaincode call. code is the API error code of a
+// failed call and empty on success.
+func ObserveFabricCall(kind, channel, function string, duration time.Duration, code string) {
+	fabricDuration.WithLabelValues(kind, channel, function).Observe(duration.Seconds())
+	if code != "" {
+		fabricErrors.WithLabelValues(kind, channel, function, code).Inc()
+	}
+}
+
+// SetConnectionState marks state as the channel's current connection state.
+func SetConnectionState(channel, state string) {
+	for _, s := range ConnectionStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		connectionState.WithLabelValues(channel, s).Set(value)
+	}
+}
+
+func ConnectionFailover(channel string) {
+	connectionFailovers.WithLabelValues(channel).Inc()
+}
+
+func ConnectionEvicted(channel string) {
+	connectionEvictions.WithLabelValues(channel).Inc()
+}
+
+func DocumentCreated(channel string) {
+	documentsCreated.WithLabelValues(channel).Inc()
+}
+
+func DocumentInvalidated(channel string) {
+	documentsInvalidated.WithLabelValues(channel).Inc()
+}
+
+func Transfer(stage, channel string) {
+	transfers.WithLabelValues(stage, channel).Inc()
+}
+
+// AnchorVerified records a verification result with its mismatch reasons.
+func AnchorVerified(result string, reasons []string) {
+	anchorVerifications.WithLabelValues(result).Inc()
+	for _, reason := range reasons {
+		anchorMismatches.WithLabelValues(reason).Inc()
+	}
+}
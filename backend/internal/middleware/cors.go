@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+var (
+	defaultCORSMethods = []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}
+	defaultCORSHeaders = []string{
+		"Accept", "Authorization", "Cache-Control", "Content-Type", "X-Request-ID",
+		IdempotencyKeyHeader, "traceparent", "tracestate",
+	}
+	defaultCORSExposedHeaders = []string{
+		"X-Request-ID", IdempotencyReplayedHeader, RateLimitLimitHeader, RateLimitRemainingHeader,
+		RateLimitResetHeader, RateLimitPolicyHeader, RetryAfterHeader,
+		DeprecationHeader, SunsetHeader, LinkHeader, FabricAttemptsHeader,
+	}
+	defaultCORSMaxAge = 10 * time.Minute
+)
+
+type corsPolicy struct {
+	anyOrigin   bool
+	origins     map[string]bool
+	patterns    [][2]string
+	credentials bool
+	methods     string
+	headers     string
+	exposed     string
+	maxAge      string
+}
+
+// CORS applies the configured cross-origin policy. Requests from an allowed
+// origin get that origin echoed back, or "*" when any origin is allowed, in
+// which case credentials are never allowed. Other origins get no CORS headers,
+// so the browser blocks the response. Preflight requests are answered here
+// with 204, or 403 for a disallowed origin, and never reach the handlers.
+func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+	policy := newCORSPolicy(cfg)
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		if !policy.allows(origin) {
+			if preflight {
+				log.Debug().
+					Str("origin", origin).
+					Str("path", c.Request.URL.Path).
+					Msg("CORS preflight from disallowed origin")
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if policy.anyOrigin {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if policy.credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			header.Set("Access-Control-Allow-Methods", policy.methods)
+			header.Set("Access-Control-Allow-Headers", policy.headers)
+			header.Set("Access-Control-Max-Age", policy.maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if policy.exposed != "" {
+			header.Set("Access-Control-Expose-Headers", policy.exposed)
+		}
+		c.Next()
+	}
+}
+
+func newCORSPolicy(cfg config.CORSConfig) *corsPolicy {
+	policy := &corsPolicy{
+		origins:     make(map[string]bool),
+		credentials: cfg.AllowCredentials,
+		methods:     strings.Join(orDefault(cfg.AllowedMethods, defaultCORSMethods), ", "),
+		headers:     strings.Join(orDefault(cfg.AllowedHeaders, defaultCORSHeaders), ", "),
+		exposed:     strings.Join(orDefault(cfg.ExposedHeaders, defaultCORSExposedHeaders), ", "),
+	}
+
+	maxAge := cfg.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultCORSMaxAge
+	}
+	policy.maxAge = strconv.Itoa(int(maxAge.Seconds()))
+
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+		switch {
+		case origin == "*":
+			policy.anyOrigin = true
+		case strings.Contains(origin, "*"):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			policy.patterns = append(policy.patterns, [2]string{prefix, suffix})
+		case origin != "":
+			policy.origins[origin] = true
+		}
+	}
+
+	if policy.anyOrigin && policy.credentials {
+		log.Warn().Msg("CORS allow_credentials is ignored because allowed_origins contains \"*\"")
+		policy.credentials = false
+	}
+	return policy
+}
+
+func (p *corsPolicy) allows(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if p.origins[origin] {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if len(origin) > len(pattern[0])+len(pattern[1]) &&
+			strings.HasPrefix(origin, pattern[0]) && strings.HasSuffix(origin, pattern[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(values, fallback []string) []string {
+	if len(values) == 0 {
+		return fallback
+	}
+	return values
+}
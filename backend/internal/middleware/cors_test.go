@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+func newCORSRouter(cfg config.CORSConfig, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORS(cfg))
+	handler := func(c *gin.Context) {
+		*calls++
+		c.Status(http.StatusOK)
+	}
+	r.GET("/api/v1/union/documents", handler)
+	r.OPTIONS("/api/v1/union/documents", handler)
+	return r
+}
+
+func corsRequest(r http.Handler, method, origin string, preflight bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/union/documents", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if preflight {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type, Idempotency-Key")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSPreflight(t *testing.T) {
+	cfg := config.CORSConfig{
+		AllowedOrigins:   []string{"https://portal.gov.br"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowCredentials: true,
+		MaxAge:           5 * time.Minute,
+	}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		calls := 0
+		w := corsRequest(newCORSRouter(cfg, &calls), http.MethodOptions, "https://portal.gov.br", true)
+		if w.Code != http.StatusNoContent || calls != 0 {
+			t.Fatalf("preflight = %d after %d handler calls, want 204 answered by the middleware", w.Code, calls)
+		}
+		want := map[string]string{
+			"Access-Control-Allow-Origin":      "https://portal.gov.br",
+			"Access-Control-Allow-Credentials": "true",
+			"Access-Control-Allow-Methods":     "GET, POST",
+			"Access-Control-Max-Age":           "300",
+		}
+		for header, value := range want {
+			if got := w.Header().Get(header); got != value {
+				t.Errorf("%s = %q, want %q", header, got, value)
+			}
+		}
+		if w.Header().Get("Access-Control-Allow-Headers") == "" {
+			t.Error("preflight is missing Access-Control-Allow-Headers")
+		}
+		if vary := w.Header().Values("Vary"); len(vary) != 3 {
+			t.Errorf("Vary = %v, want Origin and the two request headers", vary)
+		}
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		calls := 0
+		w := corsRequest(newCORSRouter(cfg, &calls), http.MethodOptions, "https://evil.example", true)
+		if w.Code != http.StatusForbidden || calls != 0 {
+			t.Fatalf("preflight = %d after %d handler calls, want 403 answered by the middleware", w.Code, calls)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want none", got)
+		}
+	})
+
+	t.Run("plain OPTIONS", func(t *testing.T) {
+		calls := 0
+		w := corsRequest(newCORSRouter(cfg, &calls), http.MethodOptions, "https://evil.example", false)
+		if w.Code != http.StatusOK || calls != 1 {
+			t.Errorf("OPTIONS without a preflight header = %d after %d handler calls, want it passed to the handler", w.Code, calls)
+		}
+	})
+}
+
+func TestCORSSimpleRequests(t *testing.T) {
+	cfg := config.CORSConfig{AllowedOrigins: []string{"https://portal.gov.br/"}}
+
+	calls := 0
+	r := newCORSRouter(cfg, &calls)
+
+	w := corsRequest(r, http.MethodGet, "https://PORTAL.gov.br", false)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://PORTAL.gov.br" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want the origin echoed back", got)
+	}
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got == "" {
+		t.Error("an allowed request is missing Access-Control-Expose-Headers")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q without allow_credentials", got)
+	}
+
+	// A disallowed origin still reaches the handler; the browser blocks the
+	// response because it carries no CORS headers.
+	w = corsRequest(r, http.MethodGet, "https://evil.example", false)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("disallowed origin = %d with Allow-Origin %q, want 200 without CORS headers",
+			w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	w = corsRequest(r, http.MethodGet, "", false)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("same-origin request = %d with Allow-Origin %q, want 200 without CORS headers",
+			w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if calls != 3 {
+		t.Errorf("handler ran %d times, want 3", calls)
+	}
+}
+
+func TestCORSAnyOriginNeverAllowsCredentials(t *testing.T) {
+	calls := 0
+	r := newCORSRouter(config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, &calls)
+
+	w := corsRequest(r, http.MethodOptions, "https://anywhere.example", true)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Fatalf("preflight = %d with Allow-Origin %q, want 204 with *", w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q alongside *", got)
+	}
+}
+
+func TestCORSWildcardOrigins(t *testing.T) {
+	policy := newCORSPolicy(config.CORSConfig{AllowedOrigins: []string{"https://*.gov.br", "http://localhost:*"}})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://portal.gov.br", true},
+		{"https://a.b.gov.br", true},
+		{"https://Portal.GOV.br", true},
+		{"http://localhost:5173", true},
+		{"https://gov.br", false},
+		{"https://.gov.br", false},
+		{"http://portal.gov.br", false},
+		{"https://portal.gov.br.evil.example", false},
+		{"https://evilgov.br", false},
+		{"http://localhost", false},
+	}
+	for _, tt := range tests {
+		if got := policy.allows(tt.origin); got != tt.want {
+			t.Errorf("allows(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
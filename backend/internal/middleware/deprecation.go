@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+	LinkHeader        = "Link"
+)
+
+// Deprecation marks the routes it is attached to as deprecated in favour of
+// the same route under successorPrefix. Every response carries the date they
+// were deprecated (RFC 9745), the date they will be removed (RFC 8594) and a
+// successor-version link to the route that replaces them.
+func Deprecation(prefix, successorPrefix string, deprecatedAt, sunset time.Time) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set(DeprecationHeader, deprecation)
+		header.Set(SunsetHeader, sunsetDate)
+		if rest, ok := strings.CutPrefix(c.Request.URL.Path, prefix); ok {
+			header.Add(LinkHeader, "<"+successorPrefix+rest+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
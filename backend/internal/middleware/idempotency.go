@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/response"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyKeyContextKey holds the validated key for handlers and services.
+	IdempotencyKeyContextKey = "idempotency_key"
+
+	maxIdempotencyKeyLength  = 255
+	defaultIdempotencyTTL    = 24 * time.Hour
+	idempotencySweepInterval = time.Minute
+)
+
+// IdempotencyRecord is the stored outcome of the first request made with a key.
+type IdempotencyRecord struct {
+	Fingerprint string
+	InProgress  bool
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// IdempotencyStore remembers key -> result mappings. Reserve must be atomic:
+// exactly one caller may reserve a given key.
+type IdempotencyStore interface {
+	// Reserve claims the key for a new request. When the key is already known
+	// it returns the existing record and false.
+	Reserve(key, fingerprint string) (*IdempotencyRecord, bool)
+	Complete(key string, record *IdempotencyRecord)
+	Release(key string)
+}
+
+// MemoryIdempotencyStore keeps records in process memory for a fixed TTL.
+// Expired records are ignored at once and dropped by a periodic sweep.
+type MemoryIdempotencyStore struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	records   map[string]*IdempotencyRecord
+	lastSweep time.Time
+	// now is the store's clock, replaced in tests.
+	now func() time.Time
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:       ttl,
+		records:   make(map[string]*IdempotencyRecord),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryIdempotencyStore) Reserve(key, fingerprint string) (*IdempotencyRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) > idempotencySweepInterval {
+		for k, r := range s.records {
+			if now.Sub(r.CreatedAt) > s.ttl {
+				delete(s.records, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if existing, ok := s.records[key]; ok && now.Sub(existing.CreatedAt) <= s.ttl {
+		copied := *existing
+		return &copied, false
+	}
+
+	s.records[key] = &IdempotencyRecord{
+		Fingerprint: fingerprint,
+		InProgress:  true,
+		CreatedAt:   now,
+	}
+	return nil, true
+}
+
+func (s *MemoryIdempotencyStore) Complete(key string, record *IdempotencyRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record
+}
+
+func (s *MemoryIdempotencyStore) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+}
+
+// Idempotency replays the stored response for POST requests that repeat an
+// Idempotency-Key. The response is recorded even if the client has already
+// disconnected, so a retry after a client-side timeout gets the original
+// result. Server errors (5xx) and rate-limit rejections (429) are not recorded
+// and the key is released so the request can be retried; services derive
+// deterministic IDs from the key, which lets such a retry find work whose
+// commit completed late.
+//
+// Keys are scoped by the caller (principal or client IP) and path. Bodies
+// larger than maxBodySize are rejected with 413, since they are read whole to
+// fingerprint the request.
+func Idempotency(store IdempotencyStore, maxBodySize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if !ValidIdempotencyKey(key) {
+			abortWithAppError(c, apperrors.NewValidationError("Invalid Idempotency-Key header").
+				WithDetails("Keys must be 1 to 255 printable ASCII characters"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abortWithAppError(c, apperrors.NewValidationError(fmt.Sprintf("Request body exceeds %d MB", maxBodySize>>20)).
+					WithHTTPStatus(http.StatusRequestEntityTooLarge))
+				return
+			}
+			abortWithAppError(c, apperrors.NewValidationError("Failed to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scopedKey := requestClient(c) + "|" + c.Request.URL.Path + "|" + key
+		fingerprint := requestFingerprint(c.Request, body)
+
+		existing, reserved := store.Reserve(scopedKey, fingerprint)
+		if !reserved {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				abortWithAppError(c, apperrors.NewAppError(apperrors.ErrCodeIdempotencyMismatch,
+					"Idempotency-Key was already used with a different request", nil).
+					WithDetails("Use a new key for a different request body"))
+			case existing.InProgress:
+				abortWithAppError(c, apperrors.NewAppError(apperrors.ErrCodeIdempotencyInProgress,
+					"A request with this Idempotency-Key is still being processed", nil).
+					WithDetails("Retry after the original request completes"))
+			default:
+				log.Info().
+					Str("path", c.Request.URL.Path).
+					Int("status", existing.Status).
+					Msg("Replaying idempotent response")
+				c.Header(IdempotencyReplayedHeader, "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		c.Set(IdempotencyKeyContextKey, key)
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			if !completed {
+				store.Release(scopedKey)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			return
+		}
+
+		store.Complete(scopedKey, &IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+			CreatedAt:   time.Now(),
+		})
+		completed = true
+	}
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ValidIdempotencyKey accepts 1 to 255 printable ASCII characters.
+func ValidIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func abortWithAppError(c *gin.Context, appErr *apperrors.AppError) {
+	requestID, _ := c.Get(RequestIDContextKey)
+	reqID, _ := requestID.(string)
+
+	context := map[string]interface{}{"retriable": appErr.Retriable}
+	for k, v := range appErr.Context {
+		context[k] = v
+	}
+
+	response.Abort(c, appErr.HTTPStatus, models.ErrorResponse{
+		Success:   false,
+		Error:     appErr.Message,
+		Details:   appErr.Details,
+		Code:      string(appErr.Code),
+		RequestID: reqID,
+		Context:   context,
+	})
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newIdempotencyRouter(status *int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Idempotency(NewMemoryIdempotencyStore(time.Hour), 1<<20))
+	r.POST("/api/v1/union/documents", func(c *gin.Context) {
+		*calls++
+		c.JSON(*status, gin.H{"call": *calls, "key": c.GetString(IdempotencyKeyContextKey)})
+	})
+	return r
+}
+
+func postWithKey(r http.Handler, key, body string) *httptest.ResponseRecorder {
+	return postFrom(r, "192.0.2.10:40000", key, body)
+}
+
+func postFrom(r http.Handler, remoteAddr, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/union/documents", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	req.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysTheFirstResponse(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := newIdempotencyRouter(&status, &calls)
+
+	first := postWithKey(r, "order-1", `{"title":"Laptops"}`)
+	second := postWithKey(r, "order-1", `{"title":"Laptops"}`)
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get(IdempotencyReplayedHeader) != "true" {
+		t.Errorf("replay is missing the %s header", IdempotencyReplayedHeader)
+	}
+	if !strings.Contains(first.Body.String(), `"key":"order-1"`) {
+		t.Errorf("handler did not see the key: %s", first.Body)
+	}
+}
+
+func TestIdempotencyRejectsAReusedKeyWithAnotherBody(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := newIdempotencyRouter(&status, &calls)
+
+	postWithKey(r, "order-1", `{"title":"Laptops"}`)
+	w := postWithKey(r, "order-1", `{"title":"Desks"}`)
+
+	if w.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Fatalf("reused key = %d after %d calls, want 422 after 1", w.Code, calls)
+	}
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error.Code != "IDEMPOTENCY_KEY_MISMATCH" {
+		t.Errorf("body = %s, want code IDEMPOTENCY_KEY_MISMATCH", w.Body)
+	}
+}
+
+func TestIdempotencyReleasesKeysAfterServerErrors(t *testing.T) {
+	for _, failure := range []int{http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		status, calls := failure, 0
+		r := newIdempotencyRouter(&status, &calls)
+
+		postWithKey(r, "order-1", `{}`)
+		status = http.StatusCreated
+		w := postWithKey(r, "order-1", `{}`)
+
+		if w.Code != http.StatusCreated || calls != 2 {
+			t.Errorf("retry after %d = %d after %d calls, want 201 after 2", failure, w.Code, calls)
+		}
+	}
+
+	// Client errors are final and replayed like successes.
+	status, calls := http.StatusBadRequest, 0
+	r := newIdempotencyRouter(&status, &calls)
+	postWithKey(r, "order-1", `{}`)
+	if w := postWithKey(r, "order-1", `{}`); w.Code != http.StatusBadRequest || calls != 1 {
+		t.Errorf("retry after 400 = %d after %d calls, want a replayed 400", w.Code, calls)
+	}
+}
+
+func TestIdempotencyRejectsAKeyStillInProgress(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Hour)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Idempotency(store, 1<<20))
+	r.POST("/api/v1/union/documents", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	fingerprint := requestFingerprint(httptest.NewRequest(http.MethodPost, "/api/v1/union/documents", nil), []byte(`{}`))
+	store.Reserve("ip:192.0.2.10|/api/v1/union/documents|order-1", fingerprint)
+
+	if w := postWithKey(r, "order-1", `{}`); w.Code != http.StatusConflict {
+		t.Errorf("in-progress key = %d, want 409", w.Code)
+	}
+}
+
+func TestIdempotencyScopesKeysByClient(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := newIdempotencyRouter(&status, &calls)
+
+	postFrom(r, "192.0.2.10:40000", "order-1", `{"title":"Laptops"}`)
+	w := postFrom(r, "198.51.100.7:40000", "order-1", `{"title":"Desks"}`)
+
+	// Another client's key neither replays nor conflicts with the first.
+	if w.Code != http.StatusCreated || calls != 2 || w.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Errorf("same key from another client = %d after %d calls, want a new 201", w.Code, calls)
+	}
+}
+
+func TestIdempotencyLimitsTheBody(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := newIdempotencyRouter(&status, &calls)
+
+	w := postWithKey(r, "order-1", strings.Repeat("x", 1<<20+1))
+	if w.Code != http.StatusRequestEntityTooLarge || calls != 0 {
+		t.Fatalf("oversized body = %d after %d calls, want 413 before the handler", w.Code, calls)
+	}
+	// The rejected request did not reserve the key.
+	if w := postWithKey(r, "order-1", `{}`); w.Code != http.StatusCreated || calls != 1 {
+		t.Errorf("retry with a smaller body = %d after %d calls, want 201", w.Code, calls)
+	}
+}
+
+func TestMemoryIdempotencyStoreExpiresRecords(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := NewMemoryIdempotencyStore(time.Hour)
+	store.now = clock.Now
+	store.lastSweep = clock.now
+
+	store.Reserve("old", "f")
+	clock.Advance(time.Hour + time.Second)
+	store.lastSweep = clock.now
+
+	// An expired record is ignored before a sweep has dropped it.
+	if _, reserved := store.Reserve("old", "g"); !reserved {
+		t.Error("an expired key could not be reserved again")
+	}
+
+	store.Reserve("stale", "f")
+	clock.Advance(time.Hour + time.Second)
+	store.Reserve("fresh", "f")
+	if _, ok := store.records["stale"]; ok {
+		t.Error("an expired record survived the sweep")
+	}
+	if _, ok := store.records["fresh"]; !ok {
+		t.Error("a live record was swept")
+	}
+}
+
+func TestValidIdempotencyKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"order-1":                 true,
+		"":                        false,
+		"has space":               false,
+		"tab\t":                   false,
+		"ação":                    false,
+		strings.Repeat("k", 255):  true,
+		strings.Repeat("k", 256):  false,
+		"550e8400-e29b-41d4-a716": true,
+	} {
+		if got := ValidIdempotencyKey(key); got != want {
+			t.Errorf("ValidIdempotencyKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
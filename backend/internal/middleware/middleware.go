@@ -1,12 +1,28 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/metrics"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/response"
+	"github.com/gov-spending/backend/pkg/fabric"
 )
 
+// RequestIDContextKey holds the request ID assigned by RequestID.
+const RequestIDContextKey = response.RequestIDContextKey
+
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -18,12 +34,13 @@ func Logger() gin.HandlerFunc {
 
 		statusCode := c.Writer.Status()
 
-		logEvent := log.Info()
+		logger := logging.Ctx(c.Request.Context())
+		logEvent := logger.Info()
 		if statusCode >= 400 {
-			logEvent = log.Warn()
+			logEvent = logger.Warn()
 		}
 		if statusCode >= 500 {
-			logEvent = log.Error()
+			logEvent = logger.Error()
 		}
 
 		if raw != "" {
@@ -41,19 +58,51 @@ func Logger() gin.HandlerFunc {
 	}
 }
 
+// Metrics records request counts and latency by route template. Requests that
+// match no route are grouped under "unmatched".
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// Tracing starts a server span per request, continuing the caller's trace when
+// a traceparent header is present. Probes and metric scrapes are not traced.
+func Tracing(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		return r.URL.Path != "/metrics" && !strings.HasPrefix(r.URL.Path, "/health")
+	}))
+}
+
+// Recovery answers a panic with a 500 and logs it with the request ID. It is
+// registered first so that it covers every other middleware.
 func Recovery() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Error().
+				// Tracing restores the request's original context as the
+				// panic unwinds it, so the logger is rebuilt from the ID.
+				ctx := c.Request.Context()
+				if requestID := c.GetString(RequestIDContextKey); requestID != "" {
+					ctx = logging.WithRequestID(ctx, requestID)
+				}
+				logging.Ctx(ctx).Error().
 					Interface("error", err).
 					Str("path", c.Request.URL.Path).
 					Msg("Panic recovered")
 
-				c.AbortWithStatusJSON(500, gin.H{
-					"success": false,
-					"error":   "Internal server error",
-					"code":    "INTERNAL_ERROR",
+				response.Abort(c, http.StatusInternalServerError, models.ErrorResponse{
+					Success:   false,
+					Error:     "Internal server error",
+					Code:      string(apperrors.ErrCodeInternalError),
+					RequestID: c.GetString(RequestIDContextKey),
 				})
 			}
 		}()
@@ -61,44 +110,74 @@ func Recovery() gin.HandlerFunc {
 	}
 }
 
-func CORS() gin.HandlerFunc {
+// RequestID assigns every request an ID, reusing a well-formed inbound
+// X-Request-ID. The ID is echoed in the response, recorded on the server span
+// and carried by the request-scoped logger in the request context.
+func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+		requestID := c.GetHeader("X-Request-ID")
+		if !logging.ValidRequestID(requestID) {
+			if requestID != "" {
+				log.Debug().
+					Str("path", c.Request.URL.Path).
+					Int("length", len(requestID)).
+					Msg("Replacing malformed X-Request-ID")
+			}
+			requestID = logging.NewRequestID()
 		}
-
+		c.Set(RequestIDContextKey, requestID)
+		c.Writer.Header().Set("X-Request-ID", requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("http.request_id", requestID))
 		c.Next()
 	}
 }
 
-func RequestID() gin.HandlerFunc {
+// FabricAttemptsHeader reports the most attempts any Fabric call of the request
+// needed; a value above 1 means the call was retried.
+const FabricAttemptsHeader = "X-Fabric-Attempts"
+
+// FabricAttempts counts the Fabric attempts made while handling a request and
+// reports them in the X-Fabric-Attempts header of its response.
+func FabricAttempts() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = generateRequestID()
-		}
-		c.Set("request_id", requestID)
-		c.Writer.Header().Set("X-Request-ID", requestID)
+		ctx, counter := fabric.WithAttemptCounter(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &attemptsWriter{ResponseWriter: c.Writer, counter: counter}
 		c.Next()
 	}
 }
 
-func generateRequestID() string {
-	return time.Now().Format("20060102150405") + "-" + randomString(8)
+// attemptsWriter sets the attempts header just before the response headers
+// are written, once the handler's Fabric calls have completed.
+type attemptsWriter struct {
+	gin.ResponseWriter
+	counter *fabric.AttemptCounter
 }
 
-func randomString(n int) string {
-	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
-	b := make([]byte, n)
-	for i := range b {
-		b[i] = letters[time.Now().UnixNano()%int64(len(letters))]
-		time.Sleep(time.Nanosecond)
+func (w *attemptsWriter) setHeader() {
+	if attempts := w.counter.Attempts(); attempts > 0 && !w.Written() {
+		w.Header().Set(FabricAttemptsHeader, strconv.Itoa(attempts))
 	}
-	return string(b)
-}
\ No newline at end of file
+}
+
+func (w *attemptsWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *attemptsWriter) Write(b []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *attemptsWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to extend
+// the write deadline of a long response.
+func (w *attemptsWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
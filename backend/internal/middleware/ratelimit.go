@@ -0,0 +1,287 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/gov-spending/backend/internal/config"
+	apperrors "github.com/gov-spending/backend/internal/errors"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+
+	// PrincipalContextKey holds the authenticated caller, when an
+	// authentication middleware has identified one. Rate limits and
+	// idempotency keys are scoped by it, falling back to the client IP.
+	PrincipalContextKey = "principal"
+
+	RateLimitRead  = "read"
+	RateLimitWrite = "write"
+
+	rateLimitSweepInterval = time.Minute
+)
+
+var (
+	defaultReadRateLimit  = config.RateLimitRule{Rate: 20, Burst: 40}
+	defaultWriteRateLimit = config.RateLimitRule{Rate: 5, Burst: 10}
+)
+
+// rateLimitReadRoutes are POST routes that only evaluate chaincode and count
+// against the read limit.
+var rateLimitReadRoutes = map[string]bool{
+	"/api/anchors/verify":    true,
+	"/api/v1/anchors/verify": true,
+	"/graphql":               true,
+}
+
+// RateLimitDecision is the outcome of taking a token from a bucket.
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero when Allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimitStore holds the token buckets. Take must be atomic per key so
+// concurrent requests cannot spend the same token; a shared store lets several
+// API replicas enforce one limit.
+type RateLimitStore interface {
+	Take(key string, rule config.RateLimitRule) RateLimitDecision
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory. Buckets that
+// have refilled completely are dropped, since a new bucket starts full.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	// now is the store's clock, replaced in tests.
+	now func() time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(key string, rule config.RateLimitRule) RateLimitDecision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) > rateLimitSweepInterval {
+		for k, b := range s.buckets {
+			if now.After(b.full) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	burst := float64(rule.Burst)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*rule.Rate)
+	b.updated = now
+
+	decision := RateLimitDecision{Limit: rule.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - b.tokens) / rule.Rate)
+	}
+
+	decision.Remaining = int(b.tokens)
+	decision.Reset = secondsToDuration((burst - b.tokens) / rule.Rate)
+	b.full = now.Add(decision.Reset)
+	return decision
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// RateLimiter charges requests to per-client token buckets, by class and
+// channel. The REST middleware and the gRPC interceptors share it, so calls
+// through either API draw on the same buckets of a shared store.
+type RateLimiter struct {
+	read     config.RateLimitRule
+	write    config.RateLimitRule
+	channels map[string]config.ChannelRateLimitConfig
+	store    RateLimitStore
+	// known reports whether a channel is configured; requests naming any
+	// other channel are charged to the channel-less buckets.
+	known func(channel string) bool
+}
+
+// NewRateLimiter returns nil when rate limiting is disabled. known reports
+// whether a channel is configured, so that requests naming made-up channels
+// cannot each get fresh buckets before the channel is rejected.
+func NewRateLimiter(cfg config.RateLimitConfig, store RateLimitStore, known func(channel string) bool) *RateLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &RateLimiter{
+		read:     withDefaultRule(cfg.Read, defaultReadRateLimit),
+		write:    withDefaultRule(cfg.Write, defaultWriteRateLimit),
+		channels: cfg.Channels,
+		store:    store,
+		known:    known,
+	}
+}
+
+// RateLimitResult is the outcome of charging a request to its bucket.
+type RateLimitResult struct {
+	RateLimitDecision
+	Class   string
+	Channel string
+	Client  string
+	Rule    config.RateLimitRule
+}
+
+// Take charges a request of class (RateLimitRead or RateLimitWrite) by client
+// on channel, which is empty for requests outside a channel.
+func (l *RateLimiter) Take(class, channel, client string) RateLimitResult {
+	if channel != "" && !l.known(channel) {
+		channel = ""
+	}
+	channelCfg := l.channels[channel]
+	rule := withDefaultRule(channelCfg.Read, l.read)
+	if class == RateLimitWrite {
+		rule = withDefaultRule(channelCfg.Write, l.write)
+	}
+
+	return RateLimitResult{
+		RateLimitDecision: l.store.Take(class+"|"+channel+"|"+client, rule),
+		Class:             class,
+		Channel:           channel,
+		Client:            client,
+		Rule:              rule,
+	}
+}
+
+// Headers returns the RateLimit-* headers of the bucket charged, and
+// Retry-After when the request was rejected.
+func (r RateLimitResult) Headers() map[string]string {
+	headers := map[string]string{
+		RateLimitLimitHeader:     strconv.Itoa(r.Limit),
+		RateLimitRemainingHeader: strconv.Itoa(r.Remaining),
+		RateLimitResetHeader:     strconv.Itoa(ceilSeconds(r.Reset)),
+		RateLimitPolicyHeader:    fmt.Sprintf("%d;w=%d", r.Rule.Burst, ceilSeconds(secondsToDuration(float64(r.Rule.Burst)/r.Rule.Rate))),
+	}
+	if !r.Allowed {
+		headers[RetryAfterHeader] = strconv.Itoa(ceilSeconds(r.RetryAfter))
+	}
+	return headers
+}
+
+// Err returns the error of a rejected request.
+func (r RateLimitResult) Err() *apperrors.AppError {
+	retryAfter := ceilSeconds(r.RetryAfter)
+	appErr := apperrors.NewAppError(apperrors.ErrCodeRateLimited, "Rate limit exceeded", nil).
+		WithDetails(fmt.Sprintf("The %s limit of %g requests per second (burst %d) was exceeded. Retry after %d seconds.",
+			r.Class, r.Rule.Rate, r.Rule.Burst, retryAfter)).
+		WithContext("retryAfter", retryAfter).
+		WithContext("limitClass", r.Class)
+	if r.Channel != "" {
+		appErr.WithContext("channel", r.Channel)
+	}
+	return appErr
+}
+
+// RateLimit enforces token-bucket limits per client on the routes it is
+// attached to. Reads (GET, HEAD and the read-only POST routes) and writes have
+// separate buckets per channel; routes without a :channel parameter share the
+// default buckets, as do routes naming a channel that is not configured. The
+// client is the principal or c.ClientIP(), which only honours forwarding
+// headers from the router's trusted proxies. Every response carries the RateLimit-* headers of the bucket
+// it was charged to, and rejected requests get 429 with Retry-After.
+func RateLimit(cfg config.RateLimitConfig, store RateLimitStore, known func(channel string) bool) gin.HandlerFunc {
+	limiter := NewRateLimiter(cfg, store, known)
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		class := RateLimitWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead ||
+			rateLimitReadRoutes[c.FullPath()] {
+			class = RateLimitRead
+		}
+
+		client := requestClient(c)
+		result := limiter.Take(class, c.Param("channel"), client)
+
+		header := c.Writer.Header()
+		for name, value := range result.Headers() {
+			header.Set(name, value)
+		}
+
+		if result.Allowed {
+			c.Next()
+			return
+		}
+
+		log.Warn().
+			Str("client", client).
+			Str("channel", result.Channel).
+			Str("class", class).
+			Str("path", c.Request.URL.Path).
+			Msg("Rate limit exceeded")
+
+		abortWithAppError(c, result.Err())
+	}
+}
+
+// requestClient identifies the caller of a request: its principal, or else
+// its client IP.
+func requestClient(c *gin.Context) string {
+	if principal := c.GetString(PrincipalContextKey); principal != "" {
+		return principal
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// withDefaultRule fills the unset fields of rule from fallback.
+func withDefaultRule(rule, fallback config.RateLimitRule) config.RateLimitRule {
+	if rule.Rate <= 0 {
+		rule.Rate = fallback.Rate
+	}
+	if rule.Burst <= 0 {
+		rule.Burst = fallback.Burst
+	}
+	return rule
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
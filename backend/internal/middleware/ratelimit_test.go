@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+// fakeClock is a store clock that only moves when advanced.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newClockedStore returns a store running on clock.
+func newClockedStore(clock *fakeClock) *MemoryRateLimitStore {
+	store := NewMemoryRateLimitStore()
+	store.now = clock.Now
+	store.lastSweep = clock.now
+	return store
+}
+
+func TestMemoryRateLimitStoreRefillsToTheBurst(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := newClockedStore(clock)
+	rule := config.RateLimitRule{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		if d := store.Take("k", rule); !d.Allowed || d.Remaining != 1-i {
+			t.Fatalf("take %d = %+v, want allowed with %d remaining", i+1, d, 1-i)
+		}
+	}
+
+	denied := store.Take("k", rule)
+	if denied.Allowed || denied.RetryAfter != time.Second || denied.Reset != 2*time.Second || denied.Limit != 2 {
+		t.Fatalf("take past the burst = %+v, want denied, retry after 1s, reset in 2s", denied)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if d := store.Take("k", rule); d.Allowed || d.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("take after half a token = %+v, want denied, retry after 500ms", d)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if d := store.Take("k", rule); !d.Allowed || d.Remaining != 0 {
+		t.Fatalf("take after a refilled token = %+v, want allowed with 0 remaining", d)
+	}
+
+	// A long idle period refills only up to the burst.
+	clock.Advance(time.Hour)
+	if d := store.Take("k", rule); !d.Allowed || d.Remaining != 1 || d.Reset != time.Second {
+		t.Fatalf("take after idling = %+v, want allowed with 1 remaining, reset in 1s", d)
+	}
+
+	if d := store.Take("other", rule); !d.Allowed || d.Remaining != 1 {
+		t.Errorf("another key = %+v, want its own full bucket", d)
+	}
+}
+
+func TestMemoryRateLimitStoreSweepsFullBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := newClockedStore(clock)
+	slow := config.RateLimitRule{Rate: 0.001, Burst: 1}
+	fast := config.RateLimitRule{Rate: 10, Burst: 1}
+
+	store.Take("slow", slow)
+	store.Take("fast", fast)
+
+	clock.Advance(rateLimitSweepInterval / 2)
+	store.Take("other", fast)
+	if len(store.buckets) != 3 {
+		t.Fatalf("buckets before the sweep interval = %d, want 3", len(store.buckets))
+	}
+
+	clock.Advance(rateLimitSweepInterval)
+	store.Take("other", fast)
+	if _, ok := store.buckets["fast"]; ok {
+		t.Error("a refilled bucket survived the sweep")
+	}
+	if _, ok := store.buckets["slow"]; !ok {
+		t.Error("a bucket still refilling was swept")
+	}
+}
+
+func newRateLimitRouter(cfg config.RateLimitConfig, store RateLimitStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	known := func(channel string) bool { return channel == "union" || channel == "state" }
+	r.Use(RateLimit(cfg, store, known))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/api/v1/:channel/documents", ok)
+	r.POST("/api/v1/:channel/documents", ok)
+	r.POST("/api/v1/anchors/verify", ok)
+	return r
+}
+
+func rateLimited(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "192.0.2.10:40000"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{Rate: 1, Burst: 2},
+		Write:   config.RateLimitRule{Rate: 1, Burst: 1},
+	}
+	r := newRateLimitRouter(cfg, newClockedStore(clock))
+
+	first := rateLimited(r, http.MethodGet, "/api/v1/state/documents")
+	want := map[string]string{
+		RateLimitLimitHeader:     "2",
+		RateLimitRemainingHeader: "1",
+		RateLimitResetHeader:     "1",
+		RateLimitPolicyHeader:    "2;w=2",
+	}
+	for header, value := range want {
+		if got := first.Header().Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+	if first.Header().Get(RetryAfterHeader) != "" {
+		t.Error("an allowed request carries Retry-After")
+	}
+
+	rateLimited(r, http.MethodGet, "/api/v1/state/documents")
+	denied := rateLimited(r, http.MethodGet, "/api/v1/state/documents")
+	if denied.Code != http.StatusTooManyRequests {
+		t.Fatalf("request past the burst = %d, want 429", denied.Code)
+	}
+	if got := denied.Header().Get(RetryAfterHeader); got != "1" {
+		t.Errorf("%s = %q, want \"1\"", RetryAfterHeader, got)
+	}
+	if got := denied.Header().Get(RateLimitRemainingHeader); got != "0" {
+		t.Errorf("%s = %q, want \"0\"", RateLimitRemainingHeader, got)
+	}
+
+	var body struct {
+		Error struct {
+			Code    string                 `json:"code"`
+			Context map[string]interface{} `json:"context"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(denied.Body.Bytes(), &body); err != nil || body.Error.Code != "RATE_LIMITED" {
+		t.Fatalf("body = %s, want code RATE_LIMITED", denied.Body)
+	}
+	if body.Error.Context["limitClass"] != RateLimitRead || body.Error.Context["channel"] != "state" {
+		t.Errorf("context = %v, want the read class on channel state", body.Error.Context)
+	}
+
+	clock.Advance(time.Second)
+	if w := rateLimited(r, http.MethodGet, "/api/v1/state/documents"); w.Code != http.StatusOK {
+		t.Errorf("request after the retry delay = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimitSeparatesReadsAndWrites(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{Rate: 1, Burst: 1},
+		Write:   config.RateLimitRule{Rate: 1, Burst: 2},
+	}
+	r := newRateLimitRouter(cfg, newClockedStore(clock))
+
+	for i := 0; i < 2; i++ {
+		if w := rateLimited(r, http.MethodPost, "/api/v1/state/documents"); w.Code != http.StatusOK {
+			t.Fatalf("write %d = %d, want 200", i+1, w.Code)
+		}
+	}
+	if w := rateLimited(r, http.MethodPost, "/api/v1/state/documents"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("third write = %d, want 429", w.Code)
+	}
+	if w := rateLimited(r, http.MethodGet, "/api/v1/state/documents"); w.Code != http.StatusOK {
+		t.Errorf("read after the writes ran out = %d, want 200", w.Code)
+	}
+	if w := rateLimited(r, http.MethodGet, "/api/v1/union/documents"); w.Code != http.StatusOK {
+		t.Errorf("read on another channel = %d, want 200", w.Code)
+	}
+
+	// Anchor verification is a POST but only evaluates chaincode.
+	if w := rateLimited(r, http.MethodPost, "/api/v1/anchors/verify"); w.Code != http.StatusOK {
+		t.Fatalf("first verification = %d, want 200", w.Code)
+	}
+	w := rateLimited(r, http.MethodPost, "/api/v1/anchors/verify")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second verification = %d, want 429", w.Code)
+	}
+	if w.Header().Get(RateLimitPolicyHeader) != "1;w=1" {
+		t.Errorf("verification charged to %q, want the read bucket", w.Header().Get(RateLimitPolicyHeader))
+	}
+}
+
+func TestRateLimitChannelOverrides(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{Rate: 1, Burst: 1},
+		Write:   config.RateLimitRule{Rate: 1, Burst: 1},
+		Channels: map[string]config.ChannelRateLimitConfig{
+			// Only the burst is overridden; the rate falls back to the default.
+			"union": {Read: config.RateLimitRule{Burst: 3}},
+		},
+	}
+	r := newRateLimitRouter(cfg, newClockedStore(clock))
+
+	for i := 0; i < 3; i++ {
+		if w := rateLimited(r, http.MethodGet, "/api/v1/union/documents"); w.Code != http.StatusOK {
+			t.Fatalf("union read %d = %d, want 200", i+1, w.Code)
+		}
+	}
+	w := rateLimited(r, http.MethodGet, "/api/v1/union/documents")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get(RateLimitPolicyHeader) != "3;w=3" {
+		t.Errorf("union read past its burst = %d with policy %q, want 429 with 3;w=3",
+			w.Code, w.Header().Get(RateLimitPolicyHeader))
+	}
+
+	rateLimited(r, http.MethodGet, "/api/v1/state/documents")
+	if w := rateLimited(r, http.MethodGet, "/api/v1/state/documents"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second state read = %d, want 429 under the default burst of 1", w.Code)
+	}
+
+	rateLimited(r, http.MethodPost, "/api/v1/union/documents")
+	if w := rateLimited(r, http.MethodPost, "/api/v1/union/documents"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second union write = %d, want 429 under the default write limit", w.Code)
+	}
+}
+
+func TestRateLimitChargesUnknownChannelsToTheDefaultBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimitRule{Rate: 1, Burst: 2},
+	}
+	r := newRateLimitRouter(cfg, newClockedStore(clock))
+
+	// Made-up channels share one bucket instead of getting a fresh one each.
+	for i, channel := range []string{"nowhere", "elsewhere"} {
+		if w := rateLimited(r, http.MethodGet, "/api/v1/"+channel+"/documents"); w.Code != http.StatusOK {
+			t.Fatalf("read %d = %d, want 200", i+1, w.Code)
+		}
+	}
+	if w := rateLimited(r, http.MethodGet, "/api/v1/somewhere/documents"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("third read on unknown channels = %d, want 429", w.Code)
+	}
+	if w := rateLimited(r, http.MethodGet, "/api/v1/state/documents"); w.Code != http.StatusOK {
+		t.Errorf("read on a configured channel = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	r := newRateLimitRouter(config.RateLimitConfig{Read: config.RateLimitRule{Rate: 1, Burst: 1}}, NewMemoryRateLimitStore())
+	for i := 0; i < 3; i++ {
+		w := rateLimited(r, http.MethodGet, "/api/v1/union/documents")
+		if w.Code != http.StatusOK || w.Header().Get(RateLimitLimitHeader) != "" {
+			t.Fatalf("request %d = %d with limit %q, want 200 without rate-limit headers",
+				i+1, w.Code, w.Header().Get(RateLimitLimitHeader))
+		}
+	}
+}
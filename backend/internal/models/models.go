@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // =============================================================================
 // Document Types
@@ -18,6 +22,19 @@ type DocumentType struct {
 	IsActive       bool     `json:"isActive"`
 }
 
+// CouchDBIndex is a CouchDB index definition as packaged under
+// META-INF/statedb/couchdb/indexes in the chaincode.
+type CouchDBIndex struct {
+	Index CouchDBIndexFields `json:"index"`
+	DDoc  string             `json:"ddoc"`
+	Name  string             `json:"name"`
+	Type  string             `json:"type"`
+}
+
+type CouchDBIndexFields struct {
+	Fields []string `json:"fields"`
+}
+
 type CreateDocumentTypeRequest struct {
 	ID             string   `json:"id" binding:"required"`
 	Name           string   `json:"name" binding:"required"`
@@ -75,6 +92,7 @@ type CreateDocumentRequest struct {
 	Amount         float64                `json:"amount"`
 	Currency       string                 `json:"currency"`
 	Data           map[string]interface{} `json:"data"`
+	IdempotencyKey string                 `json:"-"`
 }
 
 type InvalidateDocumentRequest struct {
@@ -87,23 +105,39 @@ type InvalidateDocumentRequest struct {
 // =============================================================================
 
 type QueryFilter struct {
-	OrganizationID  string         `json:"organizationId,omitempty" form:"organizationId"`
-	DocumentTypeID  string         `json:"documentTypeId,omitempty" form:"documentTypeId"`
-	Status          DocumentStatus `json:"status,omitempty" form:"status"`
-	FromDate        string         `json:"fromDate,omitempty" form:"fromDate"`
-	ToDate          string         `json:"toDate,omitempty" form:"toDate"`
-	MinAmount       float64        `json:"minAmount,omitempty" form:"minAmount"`
-	MaxAmount       float64        `json:"maxAmount,omitempty" form:"maxAmount"`
-	HasLinkedDoc    *bool          `json:"hasLinkedDoc,omitempty" form:"hasLinkedDoc"`
-	LinkedDirection string         `json:"linkedDirection,omitempty" form:"linkedDirection"`
-	PageSize        int            `json:"pageSize,omitempty" form:"pageSize"`
-	Bookmark        string         `json:"bookmark,omitempty" form:"bookmark"`
+	OrganizationID  string           `json:"organizationId,omitempty" form:"organizationId"`
+	DocumentTypeID  string           `json:"documentTypeId,omitempty" form:"documentTypeId"`
+	Status          DocumentStatus   `json:"status,omitempty" form:"status"`
+	FromDate        string           `json:"fromDate,omitempty" form:"fromDate"`
+	ToDate          string           `json:"toDate,omitempty" form:"toDate"`
+	MinAmount       float64          `json:"minAmount,omitempty" form:"minAmount"`
+	MaxAmount       float64          `json:"maxAmount,omitempty" form:"maxAmount"`
+	HasLinkedDoc    *bool            `json:"hasLinkedDoc,omitempty" form:"hasLinkedDoc"`
+	LinkedDirection string           `json:"linkedDirection,omitempty" form:"linkedDirection"`
+	SortBy          string           `json:"sortBy,omitempty" form:"sortBy" binding:"omitempty,oneof=createdAt updatedAt amount title"`
+	SortOrder       string           `json:"sortOrder,omitempty" form:"sortOrder" binding:"omitempty,oneof=asc desc"`
+	CountMode       string           `json:"countMode,omitempty" form:"count" binding:"omitempty,oneof=exact estimated"`
+	Where           []string         `json:"-" form:"where"`
+	Conditions      []FieldCondition `json:"conditions,omitempty" form:"-"`
+	PageSize        int              `json:"pageSize,omitempty" form:"pageSize"`
+	Bookmark        string           `json:"bookmark,omitempty" form:"bookmark"`
+}
+
+// FieldCondition filters on a document field or a key inside Data
+// ("data.<key>"). Data keys must be declared by a document type.
+type FieldCondition struct {
+	Field  string        `json:"field"`
+	Op     string        `json:"op" enums:"eq,in,gt,gte,lt,lte,exists"`
+	Value  interface{}   `json:"value,omitempty"`
+	Values []interface{} `json:"values,omitempty"`
 }
 
 type QueryResult struct {
-	Documents []*Document `json:"documents"`
-	Bookmark  string      `json:"bookmark,omitempty"`
-	Total     int         `json:"total"`
+	Documents      []*Document `json:"documents"`
+	Bookmark       string      `json:"bookmark,omitempty"`
+	Count          int         `json:"count"`                    // Documents in this page
+	Total          *int        `json:"total,omitempty"`          // All matches, only when a count mode is requested
+	TotalEstimated bool        `json:"totalEstimated,omitempty"` // true if an estimated count stopped at 1000 matches: total is then a lower bound
 }
 
 // =============================================================================
@@ -121,6 +155,7 @@ type InitiateTransferRequest struct {
 	Amount         float64                `json:"amount" binding:"required"`
 	Currency       string                 `json:"currency"`
 	Data           map[string]interface{} `json:"data"`
+	IdempotencyKey string                 `json:"-"`
 }
 
 // AcknowledgeTransferRequest for acknowledging a received transfer
@@ -131,6 +166,7 @@ type AcknowledgeTransferRequest struct {
 	Title          string                 `json:"title" binding:"required"`
 	Description    string                 `json:"description"`
 	Data           map[string]interface{} `json:"data"`
+	IdempotencyKey string                 `json:"-"`
 }
 
 // TransferResult is returned after transfer operations
@@ -163,12 +199,12 @@ type AnchorVerification struct {
 	TargetAmount      float64 `json:"targetAmount"`
 	TargetCurrency    string  `json:"targetCurrency"`
 
-	HashMatch      bool     `json:"hashMatch"`      // true if targetLinkedDocHash == sourceContentHash
-	IDMatch        bool     `json:"idMatch"`        // true if target.linkedDocId == source.id
-	ChannelMatch   bool     `json:"channelMatch"`   // true if target.linkedChannel == source.channel
-	AmountMatch    bool     `json:"amountMatch"`    // true if target.amount == source.amount
-	IsValid        bool     `json:"isValid"`        // true if all matches are true
-	Status         string   `json:"status"`         // "VERIFIED" or "MISMATCH"
+	HashMatch      bool     `json:"hashMatch"`    // true if targetLinkedDocHash == sourceContentHash
+	IDMatch        bool     `json:"idMatch"`      // true if target.linkedDocId == source.id
+	ChannelMatch   bool     `json:"channelMatch"` // true if target.linkedChannel == source.channel
+	AmountMatch    bool     `json:"amountMatch"`  // true if target.amount == source.amount
+	IsValid        bool     `json:"isValid"`      // true if all matches are true
+	Status         string   `json:"status"`       // "VERIFIED" or "MISMATCH"
 	MismatchReason []string `json:"mismatchReason,omitempty"`
 }
 
@@ -198,6 +234,290 @@ type HistoryEntry struct {
 	Document  *Document `json:"document"`
 }
 
+// =============================================================================
+// Bulk Import
+// =============================================================================
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusPending             ImportStatus = "PENDING"
+	ImportStatusRunning             ImportStatus = "RUNNING"
+	ImportStatusCompleted           ImportStatus = "COMPLETED"
+	ImportStatusCompletedWithErrors ImportStatus = "COMPLETED_WITH_ERRORS"
+	ImportStatusFailed              ImportStatus = "FAILED"
+)
+
+type ImportRowStatus string
+
+const (
+	RowStatusPending ImportRowStatus = "PENDING"
+	RowStatusValid   ImportRowStatus = "VALID" // dry run only
+	RowStatusCreated ImportRowStatus = "CREATED"
+	RowStatusSkipped ImportRowStatus = "SKIPPED" // already on the ledger from an earlier run
+	RowStatusInvalid ImportRowStatus = "INVALID" // rejected by validation, never submitted
+	RowStatusFailed  ImportRowStatus = "FAILED"
+)
+
+// ImportMapping maps source columns (CSV headers or NDJSON keys) to document
+// fields. Data maps each document type field to the column holding it.
+// DecimalSeparator ("." or ",") is the one of text amounts; when omitted it is
+// inferred, and amounts it leaves ambiguous, such as "1.234", are rejected.
+type ImportMapping struct {
+	ID               string            `json:"id,omitempty"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description,omitempty"`
+	Amount           string            `json:"amount"`
+	Currency         string            `json:"currency,omitempty"`
+	DecimalSeparator string            `json:"decimalSeparator,omitempty"`
+	Data             map[string]string `json:"data"`
+}
+
+// ImportRequest is sent as multipart form fields alongside the uploaded file.
+// Rows without a mapped id column get IDs derived from their content and the
+// importKey, so re-running with the same importKey skips rows that already
+// succeeded. importKey defaults to a hash of the mapping, so a re-run with a
+// corrected file and the same mapping resumes the import; pass a new
+// importKey to create identical rows again.
+type ImportRequest struct {
+	DocumentTypeID string       `form:"documentTypeId" binding:"required"`
+	Format         ImportFormat `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	Mapping        string       `form:"mapping" binding:"required"`
+	ImportKey      string       `form:"importKey"`
+	Concurrency    int          `form:"concurrency"`
+	DryRun         bool         `form:"dryRun"`
+}
+
+type ImportRowResult struct {
+	Row     int             `json:"row"`
+	DocID   string          `json:"docId,omitempty"`
+	Status  ImportRowStatus `json:"status"`
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+type ImportJob struct {
+	ID             string             `json:"id"`
+	Channel        string             `json:"channel"`
+	DocumentTypeID string             `json:"documentTypeId"`
+	ImportKey      string             `json:"importKey"`
+	Format         ImportFormat       `json:"format"`
+	DryRun         bool               `json:"dryRun,omitempty"`
+	Status         ImportStatus       `json:"status"`
+	TotalRows      int                `json:"totalRows"`
+	Processed      int                `json:"processed"`
+	Created        int                `json:"created"`
+	Skipped        int                `json:"skipped"`
+	Invalid        int                `json:"invalid"`
+	Failed         int                `json:"failed"`
+	Error          string             `json:"error,omitempty"`
+	CreatedAt      string             `json:"createdAt"`
+	FinishedAt     string             `json:"finishedAt,omitempty"`
+	Rows           []*ImportRowResult `json:"rows,omitempty"`
+}
+
+// =============================================================================
+// Bulk Export
+// =============================================================================
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV     ExportFormat = "csv"
+	ExportFormatNDJSON  ExportFormat = "ndjson"
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// ExportRow is the flat open-data record written to CSV and Parquet exports.
+// Data is serialized as JSON; TxIDs lists every transaction that touched the
+// document, separated by ";".
+type ExportRow struct {
+	ID              string  `json:"id" parquet:"id"`
+	DocumentTypeID  string  `json:"documentTypeId" parquet:"documentTypeId"`
+	OrganizationID  string  `json:"organizationId" parquet:"organizationId"`
+	ChannelID       string  `json:"channelId" parquet:"channelId"`
+	Status          string  `json:"status" parquet:"status"`
+	Title           string  `json:"title" parquet:"title"`
+	Description     string  `json:"description" parquet:"description"`
+	Amount          float64 `json:"amount" parquet:"amount"`
+	Currency        string  `json:"currency" parquet:"currency"`
+	Data            string  `json:"data" parquet:"data"`
+	ContentHash     string  `json:"contentHash" parquet:"contentHash"`
+	LinkedDocID     string  `json:"linkedDocId" parquet:"linkedDocId"`
+	LinkedChannel   string  `json:"linkedChannel" parquet:"linkedChannel"`
+	LinkedDocHash   string  `json:"linkedDocHash" parquet:"linkedDocHash"`
+	LinkedDirection string  `json:"linkedDirection" parquet:"linkedDirection"`
+	InvalidatedAt   string  `json:"invalidatedAt" parquet:"invalidatedAt"`
+	InvalidReason   string  `json:"invalidReason" parquet:"invalidReason"`
+	CorrectedByDoc  string  `json:"correctedByDoc" parquet:"correctedByDoc"`
+	CreatedAt       string  `json:"createdAt" parquet:"createdAt"`
+	UpdatedAt       string  `json:"updatedAt" parquet:"updatedAt"`
+	CreationTxID    string  `json:"creationTxId" parquet:"creationTxId"`
+	LastTxID        string  `json:"lastTxId" parquet:"lastTxId"`
+	TxIDs           string  `json:"txIds" parquet:"txIds"`
+}
+
+// ExportManifest describes a finished export. The signature is an ASN.1 ECDSA
+// signature, by the channel identity of this instance, over the SHA-256 of
+// SignedPayload. Filter is informative; the signature covers FilterQuery, the
+// same filter as a query string.
+type ExportManifest struct {
+	ExportID      string       `json:"exportId"`
+	Channel       string       `json:"channel"`
+	Format        ExportFormat `json:"format"`
+	Filter        QueryFilter  `json:"filter"`
+	FilterQuery   string       `json:"filterQuery"`
+	StartedAt     string       `json:"startedAt"`
+	CompletedAt   string       `json:"completedAt,omitempty"`
+	Complete      bool         `json:"complete"`
+	Error         string       `json:"error,omitempty"`
+	DocumentCount int          `json:"documentCount"`
+	Pages         int          `json:"pages"`
+	ByteSize      int64        `json:"byteSize"`
+	SHA256        string       `json:"sha256"`
+	SignerMSPID   string       `json:"signerMspId"`
+
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+	Signature          string `json:"signature,omitempty"`
+	SignerCertificate  string `json:"signerCertificate,omitempty"`
+}
+
+// ExportManifestVersion is the first line of SignedPayload.
+const ExportManifestVersion = "gov-spending-export-manifest/v1"
+
+// SignedPayload is the byte layout the manifest signature covers: the version
+// line, then one name=value line per field in the order below, each ending in
+// "\n". Values are written as they appear in the JSON manifest, integers in
+// decimal and booleans as true or false; none contains a newline.
+func (m *ExportManifest) SignedPayload() []byte {
+	fields := [][2]string{
+		{"exportId", m.ExportID},
+		{"channel", m.Channel},
+		{"format", string(m.Format)},
+		{"filterQuery", m.FilterQuery},
+		{"startedAt", m.StartedAt},
+		{"completedAt", m.CompletedAt},
+		{"complete", strconv.FormatBool(m.Complete)},
+		{"error", m.Error},
+		{"documentCount", strconv.Itoa(m.DocumentCount)},
+		{"pages", strconv.Itoa(m.Pages)},
+		{"byteSize", strconv.FormatInt(m.ByteSize, 10)},
+		{"sha256", m.SHA256},
+		{"signerMspId", m.SignerMSPID},
+	}
+
+	var b strings.Builder
+	b.WriteString(ExportManifestVersion + "\n")
+	for _, f := range fields {
+		b.WriteString(f[0] + "=" + f[1] + "\n")
+	}
+	return []byte(b.String())
+}
+
+// =============================================================================
+// Transactions
+// =============================================================================
+
+// Commit status values. Failed commits report the peer's validation code
+// instead (e.g. MVCC_READ_CONFLICT, ENDORSEMENT_POLICY_FAILURE).
+const (
+	TxStatusSubmitted = "SUBMITTED"
+	TxStatusPending   = "PENDING"
+	TxStatusValid     = "VALID"
+)
+
+// SubmittedTransaction is returned with 202 Accepted by write endpoints called
+// with async=true, once the transaction is endorsed and accepted by the orderer.
+type SubmittedTransaction struct {
+	Success   bool   `json:"success"`
+	ID        string `json:"id,omitempty"`
+	TxID      string `json:"txId"`
+	Channel   string `json:"channel"`
+	Status    string `json:"status"`
+	StatusURL string `json:"statusUrl"` // Status route under the API version of the write
+}
+
+// TransactionStatus reports the commit outcome of a transaction.
+type TransactionStatus struct {
+	TxID        string  `json:"txId"`
+	Channel     string  `json:"channel"`
+	Status      string  `json:"status"`
+	Committed   bool    `json:"committed"`
+	Valid       bool    `json:"valid"`
+	BlockNumber *uint64 `json:"blockNumber,omitempty"`
+	Operation   string  `json:"operation,omitempty"`
+	ID          string  `json:"id,omitempty"`
+	SubmittedAt string  `json:"submittedAt,omitempty"`
+}
+
+// =============================================================================
+// Events
+// =============================================================================
+
+// ChaincodeEvent is an event the chaincode emitted in a committed
+// transaction. ID is the document or document type it concerns and RequestID
+// the API request that submitted the transaction.
+type ChaincodeEvent struct {
+	Channel     string `json:"channel"`
+	Name        string `json:"name"`
+	ID          string `json:"id"`
+	TxID        string `json:"txId"`
+	RequestID   string `json:"requestId,omitempty"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// =============================================================================
+// Health
+// =============================================================================
+
+// ChannelHealth reports the readiness of one channel. State is the gRPC
+// connectivity state (READY, IDLE, CONNECTING, TRANSIENT_FAILURE), or
+// NOT_CONNECTED / DISCONNECTED when there is no usable connection. Checks maps
+// each readiness check to ok, failed or skipped; Error explains the failure.
+
+type ChannelHealth struct {
+	Channel              string                 `json:"channel"`
+	Ready                bool                   `json:"ready"`
+	Required             bool                   `json:"required"`
+	State                string                 `json:"state"`
+	Endpoint             string                 `json:"endpoint,omitempty"`
+	LatencyMs            int64                  `json:"latencyMs"`
+	Checks               map[string]string      `json:"checks"`
+	Error                string                 `json:"error,omitempty"`
+	CertificateExpiresAt string                 `json:"certificateExpiresAt,omitempty"`
+	ChaincodeVersion     string                 `json:"chaincodeVersion,omitempty"`
+	ChaincodeSequence    int64                  `json:"chaincodeSequence,omitempty"`
+	Failovers            int                    `json:"failovers"`
+	Since                string                 `json:"since,omitempty"`
+	LastError            string                 `json:"lastError,omitempty"`
+	CertificateError     *CertificateDiagnostic `json:"certificateError,omitempty"`
+}
+
+// CertificateDiagnostic describes a certificate that failed validation. For a
+// peer's certificate, file is the CA bundle it was checked against.
+type CertificateDiagnostic struct {
+	File         string   `json:"file"`
+	Peer         string   `json:"peer,omitempty"`
+	Problem      string   `json:"problem"`
+	ExpiresAt    string   `json:"expiresAt,omitempty"`
+	ExpectedName string   `json:"expectedName,omitempty"`
+	Names        []string `json:"names,omitempty"`
+}
+
+// ReadinessResponse is ready when every channel is, degraded when only
+// optional channels are down and not_ready when a required channel is.
+type ReadinessResponse struct {
+	Status   string           `json:"status"`
+	Channels []*ChannelHealth `json:"channels"`
+}
+
 // =============================================================================
 // API Responses
 // =============================================================================
@@ -209,15 +529,68 @@ type SuccessResponse struct {
 }
 
 type ErrorResponse struct {
-    Success   bool                   `json:"success"`
-    Error     string                 `json:"error"`
-    Details   string                 `json:"details,omitempty"`
-    Code      string                 `json:"code,omitempty"`
-    RequestID string                 `json:"request_id,omitempty"`
-    Context   map[string]interface{} `json:"context,omitempty"`
+	Success   bool                   `json:"success"`
+	Error     string                 `json:"error"`
+	Details   string                 `json:"details,omitempty"`
+	Code      string                 `json:"code,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Context   map[string]interface{} `json:"context,omitempty"`
 }
 
 type IDResponse struct {
 	Success bool   `json:"success"`
 	ID      string `json:"id"`
-}
\ No newline at end of file
+}
+
+// =============================================================================
+// API v1 Envelope
+// =============================================================================
+
+// Envelope wraps every successful /api/v1 response.
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+// ErrorEnvelope wraps every failed /api/v1 response.
+type ErrorEnvelope struct {
+	Error APIError `json:"error"`
+	Meta  Meta     `json:"meta"`
+}
+
+type Meta struct {
+	RequestID  string      `json:"requestId,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes a page of a list. NextBookmark is passed as the
+// bookmark query parameter to get the next page while HasMore is true. Total
+// is only set when it is known, or requested with count on queries.
+type Pagination struct {
+	Count          int    `json:"count"`
+	NextBookmark   string `json:"nextBookmark,omitempty"`
+	HasMore        bool   `json:"hasMore"`
+	Total          *int   `json:"total,omitempty"`
+	TotalEstimated bool   `json:"totalEstimated,omitempty"` // true if an estimated count stopped at 1000 matches: total is then a lower bound
+}
+
+// APIError is the error schema of /api/v1. Retriable requests may succeed
+// when repeated unchanged.
+type APIError struct {
+	Code      string                 `json:"code"`
+	Message   string                 `json:"message"`
+	Details   string                 `json:"details,omitempty"`
+	Retriable bool                   `json:"retriable"`
+	Context   map[string]interface{} `json:"context,omitempty"`
+}
+
+// ResourceID identifies a created resource.
+type ResourceID struct {
+	ID string `json:"id"`
+}
+
+// ActionResult reports a completed state change of a resource.
+type ActionResult struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,131 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+const (
+	// V1Prefix is the path prefix of the versioned API, whose responses are
+	// wrapped in models.Envelope and models.ErrorEnvelope.
+	V1Prefix = "/api/v1"
+
+	// LegacyPrefix is the path prefix of the unversioned routes, which keep
+	// their original response shapes until they are removed.
+	LegacyPrefix = "/api"
+
+	// RequestIDContextKey holds the request ID set by middleware.RequestID.
+	RequestIDContextKey = "request_id"
+)
+
+// IsV1 reports whether the request was made to the versioned API.
+func IsV1(c *gin.Context) bool {
+	return strings.HasPrefix(c.Request.URL.Path, V1Prefix+"/")
+}
+
+// Prefix returns the path prefix of the API version the request was made to.
+func Prefix(c *gin.Context) string {
+	if IsV1(c) {
+		return V1Prefix
+	}
+	return LegacyPrefix
+}
+
+// Submitted writes the 202 Accepted response of an async write, with its
+// status URL under the API version the write was made to.
+func Submitted(c *gin.Context, submitted *models.SubmittedTransaction) {
+	submitted.StatusURL = fmt.Sprintf("%s/%s/transactions/%s", Prefix(c), submitted.Channel, submitted.TxID)
+	OK(c, http.StatusAccepted, submitted)
+}
+
+// OK writes data, wrapped in the envelope on v1 routes.
+func OK(c *gin.Context, status int, data interface{}) {
+	Legacy(c, status, data, data)
+}
+
+// Legacy writes data on v1 routes and legacy on the legacy routes, for the
+// endpoints whose legacy body differs from the v1 data.
+func Legacy(c *gin.Context, status int, data, legacy interface{}) {
+	if !IsV1(c) {
+		c.JSON(status, legacy)
+		return
+	}
+	c.JSON(status, models.Envelope{Data: nonNil(data), Meta: meta(c, nil)})
+}
+
+// Page writes a page of a list with its pagination metadata.
+func Page(c *gin.Context, status int, data interface{}, pagination *models.Pagination, legacy interface{}) {
+	if !IsV1(c) {
+		c.JSON(status, legacy)
+		return
+	}
+	c.JSON(status, models.Envelope{Data: nonNil(data), Meta: meta(c, pagination)})
+}
+
+// List writes a complete list, whose count and total are its length.
+func List(c *gin.Context, status int, data interface{}, legacy interface{}) {
+	count := reflect.ValueOf(data).Len()
+	Page(c, status, data, &models.Pagination{Count: count, Total: &count}, legacy)
+}
+
+// Error writes an error response. Legacy routes get body as is; v1 routes get
+// it converted to the v1 error schema.
+func Error(c *gin.Context, status int, body models.ErrorResponse) {
+	if !IsV1(c) {
+		c.JSON(status, body)
+		return
+	}
+	c.JSON(status, errorEnvelope(c, body))
+}
+
+// Abort writes an error response and stops the handler chain.
+func Abort(c *gin.Context, status int, body models.ErrorResponse) {
+	Error(c, status, body)
+	c.Abort()
+}
+
+func errorEnvelope(c *gin.Context, body models.ErrorResponse) models.ErrorEnvelope {
+	apiErr := models.APIError{
+		Code:    body.Code,
+		Message: body.Error,
+		Details: body.Details,
+	}
+	for key, value := range body.Context {
+		if key == "retriable" {
+			apiErr.Retriable, _ = value.(bool)
+			continue
+		}
+		if apiErr.Context == nil {
+			apiErr.Context = make(map[string]interface{})
+		}
+		apiErr.Context[key] = value
+	}
+
+	envelope := models.ErrorEnvelope{Error: apiErr, Meta: meta(c, nil)}
+	if body.RequestID != "" {
+		envelope.Meta.RequestID = body.RequestID
+	}
+	return envelope
+}
+
+func meta(c *gin.Context, pagination *models.Pagination) models.Meta {
+	return models.Meta{
+		RequestID:  c.GetString(RequestIDContextKey),
+		Pagination: pagination,
+	}
+}
+
+// nonNil turns nil slices into empty ones, so lists are never null.
+func nonNil(data interface{}) interface{} {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	return data
+}
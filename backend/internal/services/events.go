@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// StreamEvents calls send with every chaincode event committed on the channel,
+// from startBlock when given, keeping only the named events when names is not
+// empty. It returns nil once ctx is done, the error of send when it fails, and
+// CHANNEL_UNAVAILABLE when the peer connection is lost or replaced.
+func (s *FabricService) StreamEvents(ctx context.Context, channelKey string, startBlock *uint64, names []string, send func(*models.ChaincodeEvent) error) error {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := s.gateway.ChaincodeEvents(ctx, channelKey, startBlock)
+	if err != nil {
+		return errors.ParseBlockchainError(err, "stream events").
+			WithContext("channel", channelKey).
+			WithContext("operation", "StreamEvents")
+	}
+
+	for event := range events {
+		if len(wanted) > 0 && !wanted[event.EventName] {
+			continue
+		}
+
+		var payload struct {
+			ID        string `json:"id"`
+			RequestID string `json:"requestId"`
+		}
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logging.Ctx(ctx).Warn().
+				Err(err).
+				Str("channel", channelKey).
+				Str("event", event.EventName).
+				Str("txId", event.TransactionID).
+				Msg("Skipping chaincode event with an undecodable payload")
+			continue
+		}
+
+		err := send(&models.ChaincodeEvent{
+			Channel:     channelKey,
+			Name:        event.EventName,
+			ID:          payload.ID,
+			TxID:        event.TransactionID,
+			RequestID:   payload.RequestID,
+			BlockNumber: event.BlockNumber,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return errors.NewAppError(errors.ErrCodeChannelUnavailable, "Event stream interrupted", nil).
+		WithDetails("Resume from the block after the last event received").
+		WithContext("channel", channelKey).
+		WithContext("operation", "StreamEvents")
+}
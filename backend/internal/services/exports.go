@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+const (
+	exportPageSize           = 200
+	exportParquetRowGroup    = 5000
+	maxExportManifests       = 500
+	exportSignatureAlgorithm = "ECDSA-SHA256"
+)
+
+var exportColumns = []string{
+	"id", "documentTypeId", "organizationId", "channelId", "status", "title", "description",
+	"amount", "currency", "data", "contentHash", "linkedDocId", "linkedChannel", "linkedDocHash",
+	"linkedDirection", "invalidatedAt", "invalidReason", "correctedByDoc", "createdAt", "updatedAt",
+	"creationTxId", "lastTxId", "txIds",
+}
+
+// ExportService streams query results in open-data formats, one
+// QueryDocuments page at a time, and keeps a signed manifest for each export.
+type ExportService struct {
+	fabric    *FabricService
+	gateway   *fabric.GatewayManager
+	mu        sync.RWMutex
+	manifests map[string]*models.ExportManifest
+	order     []string
+}
+
+// Export is a prepared export whose first page has already been fetched, so
+// query errors surface before anything is written to the client. ctx is the
+// request the export is streamed to; later pages are fetched under it.
+type Export struct {
+	ID     string
+	Format models.ExportFormat
+	// BeforePage, when set, runs before each page is written and the next one
+	// fetched. An error ends the export.
+	BeforePage func() error
+
+	ctx      context.Context
+	service  *ExportService
+	channel  string
+	filter   models.QueryFilter
+	first    *models.QueryResult
+	manifest *models.ExportManifest
+}
+
+func NewExportService(fabricService *FabricService, gateway *fabric.GatewayManager) *ExportService {
+	return &ExportService{
+		fabric:    fabricService,
+		gateway:   gateway,
+		manifests: make(map[string]*models.ExportManifest),
+	}
+}
+
+func (s *ExportService) PrepareExport(ctx context.Context, channelKey string, format models.ExportFormat, filter *models.QueryFilter) (*Export, error) {
+	pageFilter := *filter
+	pageFilter.PageSize = exportPageSize
+	pageFilter.Bookmark = ""
+	pageFilter.CountMode = ""
+
+	first, err := s.fabric.QueryDocuments(ctx, channelKey, &pageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	exportID := uuid.New().String()
+	return &Export{
+		ID:      exportID,
+		Format:  format,
+		ctx:     ctx,
+		service: s,
+		channel: channelKey,
+		filter:  pageFilter,
+		first:   first,
+		manifest: &models.ExportManifest{
+			ExportID:    exportID,
+			Channel:     channelKey,
+			Format:      format,
+			Filter:      *filter,
+			FilterQuery: filterQuery(filter),
+			StartedAt:   time.Now().UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// WriteTo streams every page of the export to w, hashing the bytes as they are
+// written. The manifest is signed and stored whether or not the export
+// completes; an incomplete manifest records the error.
+func (e *Export) WriteTo(w io.Writer) (int64, error) {
+	hasher := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(w, hasher)}
+
+	err := e.stream(counter)
+
+	e.manifest.ByteSize = counter.n
+	e.manifest.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+	e.manifest.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	e.manifest.Complete = err == nil
+	if err != nil {
+		// SignedPayload is line-based
+		e.manifest.Error = strings.Join(strings.Fields(err.Error()), " ")
+	}
+
+	if signErr := e.service.sign(e.manifest); signErr != nil {
+		logging.Ctx(e.ctx).Warn().
+			Err(signErr).
+			Str("exportId", e.ID).
+			Str("channel", e.channel).
+			Msg("Failed to sign export manifest")
+	}
+	e.service.store(e.manifest)
+
+	logging.Ctx(e.ctx).Info().
+		Str("exportId", e.ID).
+		Str("channel", e.channel).
+		Str("format", string(e.Format)).
+		Int("documents", e.manifest.DocumentCount).
+		Int64("bytes", e.manifest.ByteSize).
+		Bool("complete", e.manifest.Complete).
+		Msg("Export finished")
+
+	return counter.n, err
+}
+
+// SHA256 returns the hex digest of the bytes written so far.
+func (e *Export) SHA256() string {
+	return e.manifest.SHA256
+}
+
+func (e *Export) stream(w io.Writer) error {
+	sink, err := newExportSink(e.Format, w)
+	if err != nil {
+		return err
+	}
+
+	page := e.first
+	for {
+		if e.BeforePage != nil {
+			if err := e.BeforePage(); err != nil {
+				return err
+			}
+		}
+		e.manifest.Pages++
+		for _, doc := range page.Documents {
+			if err := sink.write(doc); err != nil {
+				return fmt.Errorf("failed to write document %s: %w", doc.ID, err)
+			}
+			e.manifest.DocumentCount++
+		}
+		if err := sink.flush(); err != nil {
+			return fmt.Errorf("failed to flush export: %w", err)
+		}
+
+		if page.Bookmark == "" || len(page.Documents) < exportPageSize {
+			break
+		}
+
+		e.filter.Bookmark = page.Bookmark
+		page, err = e.service.fabric.QueryDocuments(e.ctx, e.channel, &e.filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return sink.close()
+}
+
+// GetManifest returns the signed manifest of a finished export.
+func (s *ExportService) GetManifest(channelKey, exportID string) (*models.ExportManifest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	manifest, ok := s.manifests[exportID]
+	if !ok || manifest.Channel != channelKey {
+		return nil, errors.NewNotFoundError("Export manifest", exportID).
+			WithContext("channel", channelKey).
+			WithDetails("The export does not exist, has not finished yet, or its manifest has expired.")
+	}
+
+	copied := *manifest
+	return &copied, nil
+}
+
+func (s *ExportService) sign(manifest *models.ExportManifest) error {
+	conn, err := s.gateway.GetConnection(manifest.Channel)
+	if err != nil {
+		return err
+	}
+	manifest.SignerMSPID = conn.ChannelCfg.MspID
+
+	digest := sha256.Sum256(manifest.SignedPayload())
+
+	signature, certPEM, err := s.gateway.Sign(manifest.Channel, digest[:])
+	if err != nil {
+		return err
+	}
+
+	manifest.SignatureAlgorithm = exportSignatureAlgorithm
+	manifest.Signature = base64.StdEncoding.EncodeToString(signature)
+	manifest.SignerCertificate = string(certPEM)
+	return nil
+}
+
+// filterQuery encodes the filter as a query string with sorted keys, the form
+// the manifest signature covers.
+func filterQuery(filter *models.QueryFilter) string {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("organizationId", filter.OrganizationID)
+	set("documentTypeId", filter.DocumentTypeID)
+	set("status", string(filter.Status))
+	set("fromDate", filter.FromDate)
+	set("toDate", filter.ToDate)
+	if filter.MinAmount != 0 {
+		set("minAmount", strconv.FormatFloat(filter.MinAmount, 'f', -1, 64))
+	}
+	if filter.MaxAmount != 0 {
+		set("maxAmount", strconv.FormatFloat(filter.MaxAmount, 'f', -1, 64))
+	}
+	if filter.HasLinkedDoc != nil {
+		set("hasLinkedDoc", strconv.FormatBool(*filter.HasLinkedDoc))
+	}
+	set("linkedDirection", filter.LinkedDirection)
+	set("sortBy", filter.SortBy)
+	set("sortOrder", filter.SortOrder)
+	for _, where := range filter.Where {
+		values.Add("where", where)
+	}
+	return values.Encode()
+}
+
+func (s *ExportService) store(manifest *models.ExportManifest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.manifests[manifest.ExportID] = manifest
+	s.order = append(s.order, manifest.ExportID)
+	if len(s.order) > maxExportManifests {
+		delete(s.manifests, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// =============================================================================
+// Format Writers
+// =============================================================================
+
+type exportSink interface {
+	write(doc *models.Document) error
+	flush() error
+	close() error
+}
+
+func newExportSink(format models.ExportFormat, w io.Writer) (exportSink, error) {
+	switch format {
+	case models.ExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportColumns); err != nil {
+			return nil, err
+		}
+		return &csvSink{w: writer}, nil
+	case models.ExportFormatNDJSON:
+		return &ndjsonSink{enc: json.NewEncoder(w)}, nil
+	case models.ExportFormatParquet:
+		return &parquetSink{w: parquet.NewGenericWriter[models.ExportRow](w,
+			parquet.Compression(&parquet.Snappy),
+			parquet.MaxRowsPerRowGroup(exportParquetRowGroup),
+		)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type csvSink struct {
+	w *csv.Writer
+}
+
+func (s *csvSink) write(doc *models.Document) error {
+	row := toExportRow(doc)
+	return s.w.Write([]string{
+		row.ID, row.DocumentTypeID, row.OrganizationID, row.ChannelID, row.Status, row.Title, row.Description,
+		strconv.FormatFloat(row.Amount, 'f', -1, 64), row.Currency, row.Data, row.ContentHash,
+		row.LinkedDocID, row.LinkedChannel, row.LinkedDocHash, row.LinkedDirection,
+		row.InvalidatedAt, row.InvalidReason, row.CorrectedByDoc, row.CreatedAt, row.UpdatedAt,
+		row.CreationTxID, row.LastTxID, row.TxIDs,
+	})
+}
+
+func (s *csvSink) flush() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func (s *csvSink) close() error {
+	return s.flush()
+}
+
+// ndjsonSink writes the documents as the API returns them, so Data keeps its
+// structure and History carries the transaction IDs.
+type ndjsonSink struct {
+	enc *json.Encoder
+}
+
+func (s *ndjsonSink) write(doc *models.Document) error {
+	return s.enc.Encode(doc)
+}
+
+func (s *ndjsonSink) flush() error {
+	return nil
+}
+
+func (s *ndjsonSink) close() error {
+	return nil
+}
+
+type parquetSink struct {
+	w *parquet.GenericWriter[models.ExportRow]
+}
+
+func (s *parquetSink) write(doc *models.Document) error {
+	_, err := s.w.Write([]models.ExportRow{toExportRow(doc)})
+	return err
+}
+
+// flush is a no-op: row groups are cut by MaxRowsPerRowGroup, and flushing
+// every page would produce tiny row groups.
+func (s *parquetSink) flush() error {
+	return nil
+}
+
+func (s *parquetSink) close() error {
+	return s.w.Close()
+}
+
+func toExportRow(doc *models.Document) models.ExportRow {
+	data, _ := json.Marshal(doc.Data)
+
+	row := models.ExportRow{
+		ID:              doc.ID,
+		DocumentTypeID:  doc.DocumentTypeID,
+		OrganizationID:  doc.OrganizationID,
+		ChannelID:       doc.ChannelID,
+		Status:          string(doc.Status),
+		Title:           doc.Title,
+		Description:     doc.Description,
+		Amount:          doc.Amount,
+		Currency:        doc.Currency,
+		Data:            string(data),
+		ContentHash:     doc.ContentHash,
+		LinkedDocID:     doc.LinkedDocID,
+		LinkedChannel:   doc.LinkedChannel,
+		LinkedDocHash:   doc.LinkedDocHash,
+		LinkedDirection: doc.LinkedDirection,
+		InvalidatedAt:   doc.InvalidatedAt,
+		InvalidReason:   doc.InvalidReason,
+		CorrectedByDoc:  doc.CorrectedByDoc,
+		CreatedAt:       doc.CreatedAt,
+		UpdatedAt:       doc.UpdatedAt,
+		TxIDs:           strings.Join(doc.History, ";"),
+	}
+	if len(doc.History) > 0 {
+		row.CreationTxID = doc.History[0]
+		row.LastTxID = doc.History[len(doc.History)-1]
+	}
+	return row
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,612 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/tracing"
+)
+
+const (
+	defaultImportConcurrency = 4
+	maxImportConcurrency     = 16
+	maxImportRows            = 50000
+	maxImportJobs            = 100
+)
+
+// importNamespace seeds the deterministic document IDs of imported rows, so the
+// same row content under the same import key always maps to the same ledger key.
+var importNamespace = uuid.MustParse("6f1c9a52-3a0e-4d7b-9a43-1c2f0e7b5d18")
+
+// ImportService validates bulk files against a document type and submits their
+// rows as documents in the background. Jobs are kept in memory; idempotency
+// across runs comes from the ledger itself, not from this store.
+type ImportService struct {
+	fabric *FabricService
+	mu     sync.RWMutex
+	jobs   map[string]*models.ImportJob
+	order  []string
+}
+
+type importRow struct {
+	result *models.ImportRowResult
+	req    *models.CreateDocumentRequest
+}
+
+func NewImportService(fabric *FabricService) *ImportService {
+	return &ImportService{
+		fabric: fabric,
+		jobs:   make(map[string]*models.ImportJob),
+	}
+}
+
+// StartImport parses and validates every row before anything is submitted.
+// Valid rows are then created with bounded concurrency in the background;
+// invalid rows are reported and never submitted.
+func (s *ImportService) StartImport(ctx context.Context, channelKey string, req *models.ImportRequest, mapping *models.ImportMapping, content []byte) (*models.ImportJob, error) {
+	docType, err := s.fabric.GetDocumentType(ctx, channelKey, req.DocumentTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if !docType.IsActive {
+		return nil, errors.NewAppError(errors.ErrCodeInvalidDocumentType, "Document type is not active", nil).
+			WithContext("typeId", req.DocumentTypeID).
+			WithContext("channel", channelKey)
+	}
+
+	if err := validateImportMapping(mapping, docType); err != nil {
+		return nil, err.
+			WithContext("typeId", req.DocumentTypeID).
+			WithContext("operation", "StartImport")
+	}
+
+	records, err := parseImportRecords(req.Format, content)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrCodeValidationFailed, "Failed to parse import file", err).
+			WithContext("operation", "StartImport").
+			WithDetails(err.Error())
+	}
+
+	importKey := req.ImportKey
+	if importKey == "" {
+		importKey = defaultImportKey(mapping)
+	}
+
+	job := &models.ImportJob{
+		ID:             uuid.New().String(),
+		Channel:        channelKey,
+		DocumentTypeID: req.DocumentTypeID,
+		ImportKey:      importKey,
+		Format:         req.Format,
+		DryRun:         req.DryRun,
+		Status:         models.ImportStatusPending,
+		TotalRows:      len(records),
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+	}
+
+	rows := make([]*importRow, len(records))
+	occurrences := make(map[string]int)
+	for i, record := range records {
+		rowNum := i + 1
+		docReq, err := buildImportDocument(record, mapping, docType)
+		result := &models.ImportRowResult{Row: rowNum, Status: models.RowStatusPending}
+		if err != nil {
+			result.Status = models.RowStatusInvalid
+			result.Code = string(errors.ErrCodeValidationFailed)
+			result.Message = err.Error()
+			job.Invalid++
+			job.Processed++
+		} else {
+			if docReq.ID == "" {
+				docReq.ID = importRowID(channelKey, importKey, docReq, occurrences)
+			}
+			result.DocID = docReq.ID
+			if req.DryRun {
+				result.Status = models.RowStatusValid
+			}
+		}
+		job.Rows = append(job.Rows, result)
+		rows[i] = &importRow{result: result, req: docReq}
+	}
+
+	s.store(job)
+
+	logging.Ctx(ctx).Info().
+		Str("jobId", job.ID).
+		Str("channel", channelKey).
+		Str("documentTypeId", req.DocumentTypeID).
+		Str("importKey", importKey).
+		Int("rows", job.TotalRows).
+		Int("invalid", job.Invalid).
+		Bool("dryRun", req.DryRun).
+		Msg("Import accepted")
+
+	if req.DryRun {
+		s.finish(job)
+		return s.GetJob(channelKey, job.ID, "")
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultImportConcurrency
+	}
+	if concurrency > maxImportConcurrency {
+		concurrency = maxImportConcurrency
+	}
+
+	// The job outlives the request: keep its trace, drop its cancellation.
+	go s.run(context.WithoutCancel(ctx), job, rows, concurrency)
+
+	return s.GetJob(channelKey, job.ID, "")
+}
+
+// GetJob returns a snapshot of a job. rowStatus, when set, limits the rows
+// included in the snapshot.
+func (s *ImportService) GetJob(channelKey, jobID string, rowStatus models.ImportRowStatus) (*models.ImportJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[jobID]
+	if !ok || job.Channel != channelKey {
+		return nil, errors.NewNotFoundError("Import job", jobID).
+			WithContext("channel", channelKey)
+	}
+
+	snapshot := *job
+	snapshot.Rows = make([]*models.ImportRowResult, 0, len(job.Rows))
+	for _, row := range job.Rows {
+		if rowStatus == "" || row.Status == rowStatus {
+			r := *row
+			snapshot.Rows = append(snapshot.Rows, &r)
+		}
+	}
+	return &snapshot, nil
+}
+
+// ListJobs returns job summaries for a channel, newest first, without rows.
+func (s *ImportService) ListJobs(channelKey string) []*models.ImportJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := []*models.ImportJob{}
+	for i := len(s.order) - 1; i >= 0; i-- {
+		job := s.jobs[s.order[i]]
+		if job.Channel != channelKey {
+			continue
+		}
+		summary := *job
+		summary.Rows = nil
+		jobs = append(jobs, &summary)
+	}
+	return jobs
+}
+
+func (s *ImportService) run(ctx context.Context, job *models.ImportJob, rows []*importRow, concurrency int) {
+	ctx, span := tracing.Start(ctx, "ImportService.run",
+		attribute.String("import.job_id", job.ID),
+		attribute.String("channel", job.Channel),
+		attribute.Int("import.rows", job.TotalRows),
+	)
+	defer span.End()
+
+	s.mu.Lock()
+	job.Status = models.ImportStatusRunning
+	s.mu.Unlock()
+
+	work := make(chan *importRow)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range work {
+				s.submitRow(ctx, job, row)
+			}
+		}()
+	}
+
+	for _, row := range rows {
+		if row.req != nil {
+			work <- row
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	s.finish(job)
+
+	logging.Ctx(ctx).Info().
+		Str("jobId", job.ID).
+		Str("channel", job.Channel).
+		Str("status", string(job.Status)).
+		Int("created", job.Created).
+		Int("skipped", job.Skipped).
+		Int("invalid", job.Invalid).
+		Int("failed", job.Failed).
+		Msg("Import finished")
+}
+
+// submitRow creates one document. A document already on the ledger under the
+// row's ID means an earlier run succeeded, so the row is skipped.
+func (s *ImportService) submitRow(ctx context.Context, job *models.ImportJob, row *importRow) {
+	status := models.RowStatusCreated
+	var code, message string
+
+	if _, err := s.fabric.GetDocument(ctx, job.Channel, row.req.ID); err == nil {
+		status = models.RowStatusSkipped
+	} else if _, err := s.fabric.CreateDocument(ctx, job.Channel, row.req); err != nil {
+		appErr, ok := err.(*errors.AppError)
+		switch {
+		case ok && appErr.Code == errors.ErrCodeAlreadyExists:
+			status = models.RowStatusSkipped
+		case ok:
+			status = models.RowStatusFailed
+			code, message = string(appErr.Code), appErr.Message
+		default:
+			status = models.RowStatusFailed
+			code, message = string(errors.ErrCodeInternalError), err.Error()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row.result.Status = status
+	row.result.Code = code
+	row.result.Message = message
+	job.Processed++
+	switch status {
+	case models.RowStatusCreated:
+		job.Created++
+	case models.RowStatusSkipped:
+		job.Skipped++
+	default:
+		job.Failed++
+	}
+}
+
+func (s *ImportService) store(job *models.ImportJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+	s.order = append(s.order, job.ID)
+
+	// Evict the oldest finished jobs once the store is full
+	for i := 0; len(s.order) > maxImportJobs && i < len(s.order); {
+		old := s.jobs[s.order[i]]
+		if old.Status == models.ImportStatusPending || old.Status == models.ImportStatusRunning {
+			i++
+			continue
+		}
+		delete(s.jobs, old.ID)
+		s.order = append(s.order[:i], s.order[i+1:]...)
+	}
+}
+
+func (s *ImportService) finish(job *models.ImportJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	switch {
+	case job.Created+job.Skipped == 0 && job.TotalRows > 0 && !job.DryRun:
+		job.Status = models.ImportStatusFailed
+	case job.Invalid > 0 || job.Failed > 0:
+		job.Status = models.ImportStatusCompletedWithErrors
+	default:
+		job.Status = models.ImportStatusCompleted
+	}
+}
+
+// =============================================================================
+// Parsing and Validation
+// =============================================================================
+
+func validateImportMapping(mapping *models.ImportMapping, docType *models.DocumentType) *errors.AppError {
+	if mapping.Title == "" || mapping.Amount == "" {
+		return errors.NewValidationError("Import mapping must map title and amount columns")
+	}
+
+	declared := make(map[string]bool)
+	for _, f := range docType.RequiredFields {
+		declared[f] = true
+	}
+	for _, f := range docType.OptionalFields {
+		declared[f] = true
+	}
+
+	switch mapping.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return errors.NewValidationError(`Import mapping decimalSeparator must be "." or ","`)
+	}
+
+	var undeclared []string
+	for field := range mapping.Data {
+		if !declared[field] {
+			undeclared = append(undeclared, field)
+		}
+	}
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return errors.NewValidationError("Import mapping uses fields not declared by the document type: " +
+			strings.Join(undeclared, ", "))
+	}
+
+	var unmapped []string
+	for _, f := range docType.RequiredFields {
+		if _, ok := mapping.Data[f]; !ok {
+			unmapped = append(unmapped, f)
+		}
+	}
+	if len(unmapped) > 0 {
+		return errors.NewValidationError("Import mapping is missing required fields: " + strings.Join(unmapped, ", "))
+	}
+
+	return nil
+}
+
+// parseImportRecords reads CSV (header row first) or NDJSON into one map per
+// row, keyed by column name.
+func parseImportRecords(format models.ImportFormat, content []byte) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+
+	switch format {
+	case models.ImportFormatCSV:
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.TrimLeadingSpace = true
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		for {
+			fields, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			record := make(map[string]interface{}, len(header))
+			for i, name := range header {
+				if i < len(fields) {
+					record[strings.TrimSpace(name)] = fields[i]
+				}
+			}
+			records = append(records, record)
+			if len(records) > maxImportRows {
+				return nil, fmt.Errorf("import exceeds %d rows", maxImportRows)
+			}
+		}
+
+	case models.ImportFormatNDJSON:
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			var record map[string]interface{}
+			if err := json.Unmarshal([]byte(text), &record); err != nil {
+				return nil, fmt.Errorf("line %d is not a JSON object: %w", line, err)
+			}
+			records = append(records, record)
+			if len(records) > maxImportRows {
+				return nil, fmt.Errorf("import exceeds %d rows", maxImportRows)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("import file has no rows")
+	}
+	return records, nil
+}
+
+func buildImportDocument(record map[string]interface{}, mapping *models.ImportMapping, docType *models.DocumentType) (*models.CreateDocumentRequest, error) {
+	req := &models.CreateDocumentRequest{
+		DocumentTypeID: docType.ID,
+		Title:          recordString(record, mapping.Title),
+		Description:    recordString(record, mapping.Description),
+		Currency:       recordString(record, mapping.Currency),
+		Data:           make(map[string]interface{}),
+	}
+	if mapping.ID != "" {
+		req.ID = recordString(record, mapping.ID)
+		if req.ID == "" {
+			return nil, fmt.Errorf("column %s (id) is empty", mapping.ID)
+		}
+	}
+	if req.Title == "" {
+		return nil, fmt.Errorf("column %s (title) is empty", mapping.Title)
+	}
+
+	amount, err := parseImportAmount(record[mapping.Amount], mapping.DecimalSeparator)
+	if err != nil {
+		return nil, fmt.Errorf("column %s (amount): %v", mapping.Amount, err)
+	}
+	req.Amount = amount
+
+	for field, column := range mapping.Data {
+		value, ok := record[column]
+		if !ok || value == nil || value == "" {
+			continue
+		}
+		req.Data[field] = value
+	}
+
+	var missing []string
+	for _, f := range docType.RequiredFields {
+		if _, ok := req.Data[f]; !ok {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required field: %s", strings.Join(missing, ", "))
+	}
+
+	return req, nil
+}
+
+// importRowID derives the ID of a row without a mapped id column from the
+// document it builds rather than from its position, so fixing or inserting
+// other rows leaves it unchanged. Identical rows are told apart by how many
+// came before them.
+func importRowID(channelKey, importKey string, req *models.CreateDocumentRequest, occurrences map[string]int) string {
+	content, _ := json.Marshal(req)
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	n := occurrences[digest]
+	occurrences[digest]++
+	return uuid.NewSHA1(importNamespace,
+		[]byte(fmt.Sprintf("%s|%s|%s|%s|%d", channelKey, req.DocumentTypeID, importKey, digest, n))).String()
+}
+
+// defaultImportKey scopes the rows of an import without an importKey by its
+// mapping, so a re-run with a corrected file and the same mapping resumes it.
+// Channel, document type and row content are already part of each row ID.
+func defaultImportKey(mapping *models.ImportMapping) string {
+	content, _ := json.Marshal(mapping)
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func recordString(record map[string]interface{}, column string) string {
+	if column == "" {
+		return ""
+	}
+	switch v := record[column].(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseImportAmount accepts JSON numbers and strings using decimalSeparator,
+// with the other of "." and "," grouping thousands. Without a separator it is
+// inferred, and amounts where it cannot be, such as "1,234" or "1.234", are
+// rejected rather than guessed.
+func parseImportAmount(value interface{}, decimalSeparator string) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		text := strings.TrimSpace(v)
+		if text == "" {
+			return 0, fmt.Errorf("value is empty")
+		}
+		separator := decimalSeparator
+		if separator == "" {
+			var ok bool
+			if separator, ok = inferDecimalSeparator(text); !ok {
+				return 0, fmt.Errorf("%q is ambiguous: set decimalSeparator in the mapping", v)
+			}
+		}
+		text, ok := normalizeAmount(text, separator)
+		if !ok {
+			return 0, fmt.Errorf("%q is not a number with decimal separator %q", v, separator)
+		}
+		amount, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		return amount, nil
+	default:
+		return 0, fmt.Errorf("value is missing or not a number")
+	}
+}
+
+// inferDecimalSeparator picks the decimal separator of an amount: the last of
+// "." and "," when both appear, and a lone one unless it is followed by exactly
+// three digits, where it could as well group thousands.
+func inferDecimalSeparator(text string) (string, bool) {
+	dot, comma := strings.LastIndex(text, "."), strings.LastIndex(text, ",")
+	switch {
+	case dot < 0 && comma < 0:
+		return ".", true
+	case dot >= 0 && comma >= 0:
+		if dot > comma {
+			return ".", true
+		}
+		return ",", true
+	}
+
+	separator, last := ".", dot
+	if comma >= 0 {
+		separator, last = ",", comma
+	}
+	if strings.Count(text, separator) > 1 {
+		// Only a grouping separator repeats.
+		return groupSeparator(separator), true
+	}
+	if len(text)-last-1 == 3 {
+		return "", false
+	}
+	return separator, true
+}
+
+// normalizeAmount drops the grouping separators of an amount and rewrites its
+// decimal separator as ".". Groups must be of three digits, and only the
+// integer part may be grouped.
+func normalizeAmount(text, separator string) (string, bool) {
+	group := groupSeparator(separator)
+	integer, fraction, found := strings.Cut(text, separator)
+	if strings.Contains(fraction, separator) || strings.Contains(fraction, group) {
+		return "", false
+	}
+	if strings.Contains(integer, group) {
+		groups := strings.Split(strings.TrimLeft(integer, "+-"), group)
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return "", false
+		}
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				return "", false
+			}
+		}
+		integer = strings.ReplaceAll(integer, group, "")
+	}
+	if !found {
+		return integer, true
+	}
+	return integer + "." + fraction, true
+}
+
+func groupSeparator(decimalSeparator string) string {
+	if decimalSeparator == "," {
+		return "."
+	}
+	return ","
+}
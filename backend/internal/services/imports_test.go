@@ -0,0 +1,189 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+func TestParseImportRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  models.ImportFormat
+		content string
+		want    []map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "csv",
+			format:  models.ImportFormatCSV,
+			content: "title, amount ,vendor\nLaptops, \"1.234,56\",Acme\n",
+			want:    []map[string]interface{}{{"title": "Laptops", "amount": "1.234,56", "vendor": "Acme"}},
+		},
+		{
+			name:    "csv short row",
+			format:  models.ImportFormatCSV,
+			content: "title,amount,vendor\n\"Desks, oak\",10\n",
+			wantErr: "wrong number of fields",
+		},
+		{
+			name:    "ndjson skips blank lines",
+			format:  models.ImportFormatNDJSON,
+			content: "{\"title\":\"Laptops\",\"amount\":1500}\n\n  \n{\"title\":\"Desks\",\"amount\":\"200\"}\n",
+			want: []map[string]interface{}{
+				{"title": "Laptops", "amount": 1500.0},
+				{"title": "Desks", "amount": "200"},
+			},
+		},
+		{
+			name:    "ndjson reports the line",
+			format:  models.ImportFormatNDJSON,
+			content: "{\"title\":\"Laptops\"}\n[1, 2]\n",
+			wantErr: "line 2 is not a JSON object",
+		},
+		{
+			name:    "csv without rows",
+			format:  models.ImportFormatCSV,
+			content: "title,amount\n",
+			wantErr: "no rows",
+		},
+		{
+			name:    "empty csv",
+			format:  models.ImportFormatCSV,
+			content: "",
+			wantErr: "failed to read CSV header",
+		},
+		{
+			name:    "unknown format",
+			format:  "xlsx",
+			content: "title\nLaptops\n",
+			wantErr: `unsupported import format "xlsx"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportRecords(tt.format, []byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImportRecords: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportRecordsRowLimit(t *testing.T) {
+	content := "title\n" + strings.Repeat("row\n", maxImportRows+1)
+	if _, err := parseImportRecords(models.ImportFormatCSV, []byte(content)); err == nil {
+		t.Fatalf("an import of %d rows should be rejected", maxImportRows+1)
+	}
+}
+
+func TestParseImportAmount(t *testing.T) {
+	tests := []struct {
+		value     interface{}
+		separator string
+		want      float64
+		wantErr   bool
+	}{
+		{value: 1500.0, want: 1500},
+		{value: "1234.56", want: 1234.56},
+		{value: " 1.234,56 ", want: 1234.56},
+		{value: "1.234.567,8", want: 1234567.8},
+		{value: "0,5", want: 0.5},
+		{value: "-10", want: -10},
+		{value: "1,234.56", want: 1234.56},
+		{value: "1.234,56", want: 1234.56},
+		{value: "1,234", wantErr: true},
+		{value: "1.234", wantErr: true},
+		{value: "1.234.567", want: 1234567},
+		{value: "1,234", separator: ".", want: 1234},
+		{value: "1.234", separator: ",", want: 1234},
+		{value: "1,234", separator: ",", want: 1.234},
+		{value: "1.234,56", separator: ".", wantErr: true},
+		{value: "12,34.5", wantErr: true},
+		{value: "1.2.3", separator: ".", wantErr: true},
+		{value: "", wantErr: true},
+		{value: "R$ 10", wantErr: true},
+		{value: "NaN", wantErr: true},
+		{value: nil, wantErr: true},
+		{value: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseImportAmount(tt.value, tt.separator)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseImportAmount(%#v, %q) = %v, want an error", tt.value, tt.separator, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseImportAmount(%#v, %q) = %v, %v; want %v", tt.value, tt.separator, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportRowIDsFollowContent(t *testing.T) {
+	row := func(title string, amount float64) *models.CreateDocumentRequest {
+		return &models.CreateDocumentRequest{
+			DocumentTypeID: "federal-expense",
+			Title:          title,
+			Amount:         amount,
+			Data:           map[string]interface{}{"vendor": "Acme"},
+		}
+	}
+	ids := func(importKey string, rows ...*models.CreateDocumentRequest) []string {
+		occurrences := make(map[string]int)
+		var out []string
+		for _, r := range rows {
+			out = append(out, importRowID("union", importKey, r, occurrences))
+		}
+		return out
+	}
+
+	first := ids("payroll-2025-01", row("Laptops", 1500), row("Broken", -1), row("Desks", 200))
+	// The broken row is fixed and a row is inserted before the others.
+	rerun := ids("payroll-2025-01", row("Chairs", 80), row("Laptops", 1500), row("Fixed", 10), row("Desks", 200))
+	if rerun[1] != first[0] || rerun[3] != first[2] {
+		t.Errorf("unchanged rows got new IDs: %v then %v", first, rerun)
+	}
+	if rerun[2] == first[1] {
+		t.Error("a changed row kept its ID")
+	}
+
+	if other := ids("payroll-2025-02", row("Laptops", 1500)); other[0] == first[0] {
+		t.Error("another import key produced the same ID")
+	}
+	if twice := ids("payroll-2025-01", row("Laptops", 1500), row("Laptops", 1500)); twice[0] != first[0] || twice[1] == twice[0] {
+		t.Errorf("identical rows: %v, want distinct IDs starting with %s", twice, first[0])
+	}
+}
+
+func TestDefaultImportKeyFollowsMapping(t *testing.T) {
+	mapping := func(amount string) *models.ImportMapping {
+		return &models.ImportMapping{
+			Title:  "title",
+			Amount: amount,
+			Data:   map[string]string{"vendor": "supplier", "category": "kind"},
+		}
+	}
+
+	// Re-running with a corrected file keeps the key, so its rows keep their IDs.
+	if defaultImportKey(mapping("amount")) != defaultImportKey(mapping("amount")) {
+		t.Error("the same mapping produced another default import key")
+	}
+	if defaultImportKey(mapping("amount")) == defaultImportKey(mapping("value")) {
+		t.Error("another mapping produced the same default import key")
+	}
+}
@@ -1,32 +1,252 @@
 package services
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/metrics"
 	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/internal/tracing"
 	"github.com/gov-spending/backend/pkg/fabric"
 )
 
 type FabricService struct {
 	gateway *fabric.GatewayManager
+
+	txMu         sync.Mutex
+	transactions map[string]*trackedTransaction
+	txOrder      []string
 }
 
 func NewFabricService(gateway *fabric.GatewayManager) *FabricService {
 	return &FabricService{
-		gateway: gateway,
+		gateway:      gateway,
+		transactions: make(map[string]*trackedTransaction),
+	}
+}
+
+// idempotencyNamespace scopes document IDs derived from Idempotency-Key headers.
+var idempotencyNamespace = uuid.MustParse("0b6f3c1e-5d2a-4f7e-9a41-3c8d2e7b6f10")
+
+// newDocumentID returns a random ID, or a deterministic one when the request
+// carries an idempotency key so that a retried submit hits ALREADY_EXISTS
+// instead of creating a duplicate. The bool reports whether the ID was derived.
+func newDocumentID(channelKey, operation, idempotencyKey string) (string, bool) {
+	if idempotencyKey == "" {
+		return uuid.New().String(), false
+	}
+	name := channelKey + "|" + operation + "|" + idempotencyKey
+	return uuid.NewSHA1(idempotencyNamespace, []byte(name)).String(), true
+}
+
+// verifyReplay reads the document an earlier request created under the same
+// derived ID and checks it against args, this request's CreateDocument
+// arguments. An Idempotency-Key reused for different content is rejected
+// rather than reported as a successful replay.
+func verifyReplay(ctx context.Context, contract *fabric.Contract, channelKey, docID string, args []string) (*models.Document, error) {
+	result, err := contract.EvaluateTransaction(ctx, "GetDocument", docID)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get replayed document").
+			WithContext("docId", docID).
+			WithContext("channel", channelKey)
+	}
+
+	var doc models.Document
+	if err := json.Unmarshal(result, &doc); err != nil {
+		return nil, errors.NewAppError(errors.ErrCodeUnmarshalingFailed, "Failed to parse document data", err).
+			WithContext("docId", docID).
+			WithContext("channel", channelKey)
+	}
+
+	if field := replayMismatch(&doc, args); field != "" {
+		return nil, errors.NewAppError(errors.ErrCodeIdempotencyMismatch,
+			"Idempotency-Key was already used with a different request", nil).
+			WithDetails(fmt.Sprintf("The document created with this key has a different %s; use a new key for a different request", field)).
+			WithContext("docId", docID).
+			WithContext("channel", channelKey)
+	}
+	return &doc, nil
+}
+
+// replayMismatch names the first field in which doc differs from the
+// CreateDocument arguments, or returns "" when they match. The content hash is
+// recomputed as the chaincode does, over the re-marshaled data. Link fields
+// the request leaves empty are skipped, since a later acknowledgment fills them.
+func replayMismatch(doc *models.Document, args []string) string {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(args[6]), &data); err != nil {
+		return "data"
+	}
+	dataJSON, _ := json.Marshal(data)
+	sum := sha256.Sum256(dataJSON)
+	amount, _ := strconv.ParseFloat(args[4], 64)
+
+	fields := []struct{ name, got, want string }{
+		{"documentTypeId", doc.DocumentTypeID, args[1]},
+		{"title", doc.Title, args[2]},
+		{"description", doc.Description, args[3]},
+		{"amount", strconv.FormatFloat(doc.Amount, 'f', -1, 64), strconv.FormatFloat(amount, 'f', -1, 64)},
+		{"currency", doc.Currency, args[5]},
+		{"contentHash", doc.ContentHash, hex.EncodeToString(sum[:])},
+	}
+	if len(args) == 11 {
+		fields = append(fields,
+			struct{ name, got, want string }{"linkedDocId", doc.LinkedDocID, args[7]},
+			struct{ name, got, want string }{"linkedChannel", doc.LinkedChannel, args[8]},
+			struct{ name, got, want string }{"linkedDocHash", doc.LinkedDocHash, args[9]},
+			struct{ name, got, want string }{"linkedDirection", doc.LinkedDirection, args[10]},
+		)
+	}
+	for _, f := range fields {
+		if f.want != f.got && (f.want != "" || !strings.HasPrefix(f.name, "linked")) {
+			return f.name
+		}
+	}
+	return ""
+}
+
+// =============================================================================
+// Health
+// =============================================================================
+
+// ChannelHealth probes every configured channel in parallel, each within the
+// gateway's probe timeout, and reports its readiness with its connection state.
+func (s *FabricService) ChannelHealth(ctx context.Context) []*models.ChannelHealth {
+	states := s.gateway.ConnectionStates()
+	probes := make([]*fabric.ProbeResult, len(states))
+
+	var wg sync.WaitGroup
+	for i, state := range states {
+		wg.Add(1)
+		go func(i int, channelKey string) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, s.gateway.ProbeTimeout())
+			defer cancel()
+			probes[i] = s.gateway.Probe(probeCtx, channelKey)
+		}(i, state.Channel)
+	}
+	wg.Wait()
+
+	// Probing may have connected, failed over or evicted connections.
+	current := make(map[string]fabric.ConnectionState, len(states))
+	for _, state := range s.gateway.ConnectionStates() {
+		current[state.Channel] = state
+	}
+
+	result := make([]*models.ChannelHealth, 0, len(probes))
+	for _, probe := range probes {
+		state := current[probe.Channel]
+		health := &models.ChannelHealth{
+			Channel:           probe.Channel,
+			Ready:             probe.Ready(),
+			Required:          probe.Required,
+			State:             state.State,
+			Endpoint:          state.Endpoint,
+			LatencyMs:         probe.Latency.Milliseconds(),
+			Checks:            probeChecks(probe),
+			ChaincodeVersion:  probe.ChaincodeVersion,
+			ChaincodeSequence: probe.ChaincodeSequence,
+			Failovers:         state.Failovers,
+		}
+		if probe.Err != nil {
+			health.Error = errors.SanitizeError(probe.Err)
+			health.CertificateError = certificateDiagnostic(probe.Err)
+			logging.Ctx(ctx).Warn().
+				Err(probe.Err).
+				Str("channel", probe.Channel).
+				Str("check", probe.Failed).
+				Msg("Channel not ready")
+		}
+		if !probe.CertificateExpiresAt.IsZero() {
+			health.CertificateExpiresAt = probe.CertificateExpiresAt.Format(time.RFC3339)
+		}
+		if state.LastError != "" {
+			health.LastError = errors.SanitizeError(fmt.Errorf("%s", state.LastError))
+		}
+		if !state.Since.IsZero() {
+			health.Since = state.Since.Format(time.RFC3339)
+		}
+		result = append(result, health)
 	}
+	return result
+}
+
+func certificateDiagnostic(err error) *models.CertificateDiagnostic {
+	var certErr *fabric.CertificateError
+	if !stderrors.As(err, &certErr) {
+		return nil
+	}
+	diagnostic := &models.CertificateDiagnostic{
+		File:         certErr.File,
+		Peer:         certErr.Peer,
+		Problem:      certErr.Problem,
+		ExpectedName: certErr.ExpectedName,
+		Names:        certErr.Names,
+	}
+	if !certErr.NotAfter.IsZero() {
+		diagnostic.ExpiresAt = certErr.NotAfter.UTC().Format(time.RFC3339)
+	}
+	return diagnostic
+}
+
+func probeChecks(probe *fabric.ProbeResult) map[string]string {
+	checks := make(map[string]string, len(fabric.ProbeChecks))
+	for _, check := range fabric.ProbeChecks {
+		checks[check] = "skipped"
+	}
+	for _, check := range probe.Passed {
+		checks[check] = "ok"
+	}
+	if probe.Failed != "" {
+		checks[probe.Failed] = "failed"
+	}
+	return checks
 }
 
 // =============================================================================
 // Document Type Operations
 // =============================================================================
 
-func (s *FabricService) RegisterDocumentType(channelKey string, req *models.CreateDocumentTypeRequest) (*models.IDResponse, error) {
+func (s *FabricService) RegisterDocumentType(ctx context.Context, channelKey string, req *models.CreateDocumentTypeRequest) (*models.IDResponse, error) {
+	contract, err := s.gateway.GetContract(channelKey)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get contract").
+			WithContext("channel", channelKey).
+			WithContext("operation", "RegisterDocumentType")
+	}
+
+	args, err := documentTypeArgs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = contract.SubmitTransaction(ctx, "RegisterDocumentType", args...)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "register document type").
+			WithContext("typeId", req.ID).
+			WithContext("channel", channelKey)
+	}
+
+	logging.Ctx(ctx).Info().Str("typeId", req.ID).Str("channel", channelKey).Msg("Document type registered")
+	return &models.IDResponse{Success: true, ID: req.ID}, nil
+}
+
+func (s *FabricService) RegisterDocumentTypeAsync(ctx context.Context, channelKey string, req *models.CreateDocumentTypeRequest) (*models.SubmittedTransaction, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -34,6 +254,21 @@ func (s *FabricService) RegisterDocumentType(channelKey string, req *models.Crea
 			WithContext("operation", "RegisterDocumentType")
 	}
 
+	args, err := documentTypeArgs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.submitAsync(ctx, channelKey, "RegisterDocumentType", req.ID, contract, "RegisterDocumentType", args...)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "register document type").
+			WithContext("typeId", req.ID).
+			WithContext("channel", channelKey)
+	}
+	return submitted, nil
+}
+
+func documentTypeArgs(req *models.CreateDocumentTypeRequest) ([]string, error) {
 	requiredFieldsJSON, err := json.Marshal(req.RequiredFields)
 	if err != nil {
 		return nil, errors.NewAppError(errors.ErrCodeMarshalingFailed, "Failed to marshal required fields", err).
@@ -46,25 +281,16 @@ func (s *FabricService) RegisterDocumentType(channelKey string, req *models.Crea
 			WithContext("typeId", req.ID)
 	}
 
-	_, err = contract.SubmitTransaction(
-		"RegisterDocumentType",
+	return []string{
 		req.ID,
 		req.Name,
 		req.Description,
 		string(requiredFieldsJSON),
 		string(optionalFieldsJSON),
-	)
-	if err != nil {
-		return nil, errors.ParseBlockchainError(err, "register document type").
-			WithContext("typeId", req.ID).
-			WithContext("channel", channelKey)
-	}
-
-	log.Info().Str("typeId", req.ID).Str("channel", channelKey).Msg("Document type registered")
-	return &models.IDResponse{Success: true, ID: req.ID}, nil
+	}, nil
 }
 
-func (s *FabricService) GetDocumentType(channelKey, typeID string) (*models.DocumentType, error) {
+func (s *FabricService) GetDocumentType(ctx context.Context, channelKey, typeID string) (*models.DocumentType, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -72,7 +298,7 @@ func (s *FabricService) GetDocumentType(channelKey, typeID string) (*models.Docu
 			WithContext("operation", "GetDocumentType")
 	}
 
-	result, err := contract.EvaluateTransaction("GetDocumentType", typeID)
+	result, err := contract.EvaluateTransaction(ctx, "GetDocumentType", typeID)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get document type").
 			WithContext("typeId", typeID).
@@ -89,7 +315,7 @@ func (s *FabricService) GetDocumentType(channelKey, typeID string) (*models.Docu
 	return &docType, nil
 }
 
-func (s *FabricService) ListDocumentTypes(channelKey, orgID string) ([]*models.DocumentType, error) {
+func (s *FabricService) ListDocumentTypes(ctx context.Context, channelKey, orgID string) ([]*models.DocumentType, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -97,7 +323,7 @@ func (s *FabricService) ListDocumentTypes(channelKey, orgID string) ([]*models.D
 			WithContext("operation", "ListDocumentTypes")
 	}
 
-	result, err := contract.EvaluateTransaction("ListDocumentTypes", orgID)
+	result, err := contract.EvaluateTransaction(ctx, "ListDocumentTypes", orgID)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "list document types").
 			WithContext("orgId", orgID).
@@ -118,7 +344,35 @@ func (s *FabricService) ListDocumentTypes(channelKey, orgID string) ([]*models.D
 	return types, nil
 }
 
-func (s *FabricService) DeactivateDocumentType(channelKey, typeID string) error {
+var indexNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// DeriveDocumentTypeIndexes builds one CouchDB index per data field declared by
+// the document type, keyed on documentTypeId so "data.<field>" conditions scoped
+// to that type can be served from an index.
+func (s *FabricService) DeriveDocumentTypeIndexes(ctx context.Context, channelKey, typeID string) ([]*models.CouchDBIndex, error) {
+	docType, err := s.GetDocumentType(ctx, channelKey, typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := append(append([]string{}, docType.RequiredFields...), docType.OptionalFields...)
+	typeName := indexNameUnsafe.ReplaceAllString(docType.ID, "")
+
+	indexes := make([]*models.CouchDBIndex, 0, len(fields))
+	for _, field := range fields {
+		name := "index" + typeName + indexNameUnsafe.ReplaceAllString(field, "")
+		indexes = append(indexes, &models.CouchDBIndex{
+			Index: models.CouchDBIndexFields{Fields: []string{"documentTypeId", "data." + field}},
+			DDoc:  name + "Doc",
+			Name:  name,
+			Type:  "json",
+		})
+	}
+
+	return indexes, nil
+}
+
+func (s *FabricService) DeactivateDocumentType(ctx context.Context, channelKey, typeID string) error {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return errors.ParseBlockchainError(err, "get contract").
@@ -126,22 +380,39 @@ func (s *FabricService) DeactivateDocumentType(channelKey, typeID string) error
 			WithContext("operation", "DeactivateDocumentType")
 	}
 
-	_, err = contract.SubmitTransaction("DeactivateDocumentType", typeID)
+	_, err = contract.SubmitTransaction(ctx, "DeactivateDocumentType", typeID)
 	if err != nil {
 		return errors.ParseBlockchainError(err, "deactivate document type").
 			WithContext("typeId", typeID).
 			WithContext("channel", channelKey)
 	}
 
-	log.Info().Str("typeId", typeID).Str("channel", channelKey).Msg("Document type deactivated")
+	logging.Ctx(ctx).Info().Str("typeId", typeID).Str("channel", channelKey).Msg("Document type deactivated")
 	return nil
 }
 
+func (s *FabricService) DeactivateDocumentTypeAsync(ctx context.Context, channelKey, typeID string) (*models.SubmittedTransaction, error) {
+	contract, err := s.gateway.GetContract(channelKey)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get contract").
+			WithContext("channel", channelKey).
+			WithContext("operation", "DeactivateDocumentType")
+	}
+
+	submitted, err := s.submitAsync(ctx, channelKey, "DeactivateDocumentType", typeID, contract, "DeactivateDocumentType", typeID)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "deactivate document type").
+			WithContext("typeId", typeID).
+			WithContext("channel", channelKey)
+	}
+	return submitted, nil
+}
+
 // =============================================================================
 // Document Operations
 // =============================================================================
 
-func (s *FabricService) CreateDocument(channelKey string, req *models.CreateDocumentRequest) (*models.IDResponse, error) {
+func (s *FabricService) CreateDocument(ctx context.Context, channelKey string, req *models.CreateDocumentRequest) (*models.IDResponse, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -149,11 +420,73 @@ func (s *FabricService) CreateDocument(channelKey string, req *models.CreateDocu
 			WithContext("operation", "CreateDocument")
 	}
 
-	docID := req.ID
-	if docID == "" {
-		docID = uuid.New().String()
+	docID, derived := createDocumentID(channelKey, req)
+	args, err := createDocumentArgs(channelKey, docID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = contract.SubmitTransaction(ctx, "CreateSimpleDocument", args...)
+	if err != nil {
+		appErr := errors.ParseBlockchainError(err, "create document")
+		if !derived || appErr.Code != errors.ErrCodeAlreadyExists {
+			return nil, appErr.
+				WithContext("docId", docID).
+				WithContext("documentTypeId", req.DocumentTypeID).
+				WithContext("channel", channelKey)
+		}
+		if _, err := verifyReplay(ctx, contract, channelKey, docID, args); err != nil {
+			return nil, err
+		}
+		logging.Ctx(ctx).Info().Str("docId", docID).Str("channel", channelKey).Msg("Document already created by an earlier request with the same Idempotency-Key")
+		return &models.IDResponse{Success: true, ID: docID}, nil
+	}
+
+	recordCommitted("CreateDocument", channelKey)
+	logging.Ctx(ctx).Info().Str("docId", docID).Str("channel", channelKey).Msg("Document created")
+	return &models.IDResponse{Success: true, ID: docID}, nil
+}
+
+func (s *FabricService) CreateDocumentAsync(ctx context.Context, channelKey string, req *models.CreateDocumentRequest) (*models.SubmittedTransaction, error) {
+	contract, err := s.gateway.GetContract(channelKey)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get contract").
+			WithContext("channel", channelKey).
+			WithContext("operation", "CreateDocument")
+	}
+
+	docID, derived := createDocumentID(channelKey, req)
+	args, err := createDocumentArgs(channelKey, docID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.submitAsync(ctx, channelKey, "CreateDocument", docID, contract, "CreateSimpleDocument", args...)
+	if err != nil {
+		appErr := errors.ParseBlockchainError(err, "create document")
+		if !derived || appErr.Code != errors.ErrCodeAlreadyExists {
+			return nil, appErr.
+				WithContext("docId", docID).
+				WithContext("documentTypeId", req.DocumentTypeID).
+				WithContext("channel", channelKey)
+		}
+		doc, err := verifyReplay(ctx, contract, channelKey, docID, args)
+		if err != nil {
+			return nil, err
+		}
+		return committedCreation(channelKey, doc), nil
+	}
+	return submitted, nil
+}
+
+func createDocumentID(channelKey string, req *models.CreateDocumentRequest) (string, bool) {
+	if req.ID != "" {
+		return req.ID, false
 	}
+	return newDocumentID(channelKey, "CreateDocument", req.IdempotencyKey)
+}
 
+func createDocumentArgs(channelKey, docID string, req *models.CreateDocumentRequest) ([]string, error) {
 	currency := req.Currency
 	if currency == "" {
 		currency = "BRL"
@@ -166,8 +499,7 @@ func (s *FabricService) CreateDocument(channelKey string, req *models.CreateDocu
 			WithContext("channel", channelKey)
 	}
 
-	_, err = contract.SubmitTransaction(
-		"CreateSimpleDocument",
+	return []string{
 		docID,
 		req.DocumentTypeID,
 		req.Title,
@@ -175,19 +507,10 @@ func (s *FabricService) CreateDocument(channelKey string, req *models.CreateDocu
 		fmt.Sprintf("%f", req.Amount),
 		currency,
 		string(dataJSON),
-	)
-	if err != nil {
-		return nil, errors.ParseBlockchainError(err, "create document").
-			WithContext("docId", docID).
-			WithContext("documentTypeId", req.DocumentTypeID).
-			WithContext("channel", channelKey)
-	}
-
-	log.Info().Str("docId", docID).Str("channel", channelKey).Msg("Document created")
-	return &models.IDResponse{Success: true, ID: docID}, nil
+	}, nil
 }
 
-func (s *FabricService) GetDocument(channelKey, docID string) (*models.Document, error) {
+func (s *FabricService) GetDocument(ctx context.Context, channelKey, docID string) (*models.Document, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -195,7 +518,7 @@ func (s *FabricService) GetDocument(channelKey, docID string) (*models.Document,
 			WithContext("operation", "GetDocument")
 	}
 
-	result, err := contract.EvaluateTransaction("GetDocument", docID)
+	result, err := contract.EvaluateTransaction(ctx, "GetDocument", docID)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get document").
 			WithContext("docId", docID).
@@ -212,7 +535,7 @@ func (s *FabricService) GetDocument(channelKey, docID string) (*models.Document,
 	return &doc, nil
 }
 
-func (s *FabricService) QueryDocuments(channelKey string, filter *models.QueryFilter) (*models.QueryResult, error) {
+func (s *FabricService) QueryDocuments(ctx context.Context, channelKey string, filter *models.QueryFilter) (*models.QueryResult, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -226,7 +549,7 @@ func (s *FabricService) QueryDocuments(channelKey string, filter *models.QueryFi
 			WithContext("channel", channelKey)
 	}
 
-	result, err := contract.EvaluateTransaction("QueryDocuments", string(filterJSON))
+	result, err := contract.EvaluateTransaction(ctx, "QueryDocuments", string(filterJSON))
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "query documents").
 			WithContext("channel", channelKey).
@@ -247,7 +570,7 @@ func (s *FabricService) QueryDocuments(channelKey string, filter *models.QueryFi
 	return &queryResult, nil
 }
 
-func (s *FabricService) InvalidateDocument(channelKey, docID string, req *models.InvalidateDocumentRequest) error {
+func (s *FabricService) InvalidateDocument(ctx context.Context, channelKey, docID string, req *models.InvalidateDocumentRequest) error {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return errors.ParseBlockchainError(err, "get contract").
@@ -255,7 +578,7 @@ func (s *FabricService) InvalidateDocument(channelKey, docID string, req *models
 			WithContext("operation", "InvalidateDocument")
 	}
 
-	_, err = contract.SubmitTransaction(
+	_, err = contract.SubmitTransaction(ctx,
 		"InvalidateDocument",
 		docID,
 		req.Reason,
@@ -268,7 +591,8 @@ func (s *FabricService) InvalidateDocument(channelKey, docID string, req *models
 			WithContext("reason", req.Reason)
 	}
 
-	log.Info().
+	recordCommitted("InvalidateDocument", channelKey)
+	logging.Ctx(ctx).Info().
 		Str("docId", docID).
 		Str("channel", channelKey).
 		Str("reason", req.Reason).
@@ -277,7 +601,26 @@ func (s *FabricService) InvalidateDocument(channelKey, docID string, req *models
 	return nil
 }
 
-func (s *FabricService) GetDocumentHistory(channelKey, docID string) ([]map[string]any, error) {
+func (s *FabricService) InvalidateDocumentAsync(ctx context.Context, channelKey, docID string, req *models.InvalidateDocumentRequest) (*models.SubmittedTransaction, error) {
+	contract, err := s.gateway.GetContract(channelKey)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get contract").
+			WithContext("channel", channelKey).
+			WithContext("operation", "InvalidateDocument")
+	}
+
+	submitted, err := s.submitAsync(ctx, channelKey, "InvalidateDocument", docID, contract,
+		"InvalidateDocument", docID, req.Reason, req.CorrectionDocID)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "invalidate document").
+			WithContext("docId", docID).
+			WithContext("channel", channelKey).
+			WithContext("reason", req.Reason)
+	}
+	return submitted, nil
+}
+
+func (s *FabricService) GetDocumentHistory(ctx context.Context, channelKey, docID string) ([]map[string]any, error) {
 	contract, err := s.gateway.GetContract(channelKey)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get contract").
@@ -285,7 +628,7 @@ func (s *FabricService) GetDocumentHistory(channelKey, docID string) ([]map[stri
 			WithContext("operation", "GetDocumentHistory")
 	}
 
-	result, err := contract.EvaluateTransaction("GetDocumentHistory", docID)
+	result, err := contract.EvaluateTransaction(ctx, "GetDocumentHistory", docID)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get document history").
 			WithContext("docId", docID).
@@ -311,7 +654,13 @@ func (s *FabricService) GetDocumentHistory(channelKey, docID string) ([]map[stri
 // =============================================================================
 
 
-func (s *FabricService) InitiateTransfer(req *models.InitiateTransferRequest) (*models.TransferResult, error) {
+func (s *FabricService) InitiateTransfer(ctx context.Context, req *models.InitiateTransferRequest) (*models.TransferResult, error) {
+	ctx, span := tracing.Start(ctx, "FabricService.InitiateTransfer",
+		attribute.String("source_channel", req.FromChannel),
+		attribute.String("target_channel", req.ToChannel),
+	)
+	defer span.End()
+
 	// Step 1: Get source channel contract
 	sourceContract, err := s.gateway.GetContract(req.FromChannel)
 	if err != nil {
@@ -322,55 +671,39 @@ func (s *FabricService) InitiateTransfer(req *models.InitiateTransferRequest) (*
 			WithContext("step", "get_source_contract")
 	}
 
-	transferID := uuid.New().String()
-
-	currency := req.Currency
-	if currency == "" {
-		currency = "BRL"
-	}
-
-	data := req.Data
-	if data == nil {
-		data = make(map[string]any)
-	}
-	data["transferType"] = "OUTGOING"
-	data["targetOrg"] = req.ToOrg
-	data["targetChannel"] = req.ToChannel
-
-	dataJSON, err := json.Marshal(data)
+	transferID, derived := newDocumentID(req.FromChannel, "InitiateTransfer", req.IdempotencyKey)
+	args, err := transferArgs(transferID, req)
 	if err != nil {
-		return nil, errors.NewAppError(errors.ErrCodeMarshalingFailed, "Failed to marshal transfer data", err).
-			WithContext("transferId", transferID).
-			WithContext("sourceChannel", req.FromChannel)
+		return nil, err
 	}
 
 	// Step 2: Create transfer document on source channel
-	_, err = sourceContract.SubmitTransaction(
-		"CreateDocument",
-		transferID,
-		req.DocumentTypeID,
-		req.Title,
-		req.Description,
-		fmt.Sprintf("%f", req.Amount),
-		currency,
-		string(dataJSON),
-		"",
-		req.ToChannel,
-		"",
-		"OUTGOING",
-	)
+	stepCtx, step := startStep(ctx, "InitiateTransfer", "create_source_document", req.FromChannel)
+	_, err = sourceContract.SubmitTransaction(stepCtx, "CreateDocument", args...)
+	tracing.End(step, err)
 	if err != nil {
-		return nil, errors.ParseBlockchainError(err, "create transfer document").
-			WithContext("transferId", transferID).
-			WithContext("sourceChannel", req.FromChannel).
-			WithContext("targetChannel", req.ToChannel).
-			WithContext("documentTypeId", req.DocumentTypeID).
-			WithContext("step", "create_source_document").
-			WithDetails("Failed to create the outgoing transfer document on the source channel")
+		appErr := errors.ParseBlockchainError(err, "create transfer document")
+		if !derived || appErr.Code != errors.ErrCodeAlreadyExists {
+			return nil, appErr.
+				WithContext("transferId", transferID).
+				WithContext("sourceChannel", req.FromChannel).
+				WithContext("targetChannel", req.ToChannel).
+				WithContext("documentTypeId", req.DocumentTypeID).
+				WithContext("step", "create_source_document").
+				WithDetails("Failed to create the outgoing transfer document on the source channel")
+		}
+		if _, err := verifyReplay(ctx, sourceContract, req.FromChannel, transferID, args); err != nil {
+			return nil, err
+		}
+		logging.Ctx(ctx).Info().Str("transferId", transferID).Str("channel", req.FromChannel).Msg("Transfer already initiated by an earlier request with the same Idempotency-Key")
+	} else {
+		recordCommitted("InitiateTransfer", req.FromChannel)
 	}
 
 	// Step 3: Read back the created document to get content hash
-	result, err := sourceContract.EvaluateTransaction("GetDocument", transferID)
+	stepCtx, step = startStep(ctx, "InitiateTransfer", "verify_source_document", req.FromChannel)
+	result, err := sourceContract.EvaluateTransaction(stepCtx, "GetDocument", transferID)
+	tracing.End(step, err)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "read created transfer document").
 			WithContext("transferId", transferID).
@@ -387,7 +720,7 @@ func (s *FabricService) InitiateTransfer(req *models.InitiateTransferRequest) (*
 			WithContext("step", "parse_source_document")
 	}
 
-	log.Info().
+	logging.Ctx(ctx).Info().
 		Str("transferId", transferID).
 		Str("fromChannel", req.FromChannel).
 		Str("toChannel", req.ToChannel).
@@ -403,7 +736,88 @@ func (s *FabricService) InitiateTransfer(req *models.InitiateTransferRequest) (*
 	}, nil
 }
 
-func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models.AcknowledgeTransferRequest) (*models.TransferResult, error) {
+// InitiateTransferAsync submits the outgoing transfer document without waiting
+// for its commit. The content hash is available from the document once the
+// transaction status reports VALID.
+func (s *FabricService) InitiateTransferAsync(ctx context.Context, req *models.InitiateTransferRequest) (*models.SubmittedTransaction, error) {
+	sourceContract, err := s.gateway.GetContract(req.FromChannel)
+	if err != nil {
+		return nil, errors.ParseBlockchainError(err, "get source channel contract").
+			WithContext("sourceChannel", req.FromChannel).
+			WithContext("targetChannel", req.ToChannel).
+			WithContext("operation", "InitiateTransfer").
+			WithContext("step", "get_source_contract")
+	}
+
+	transferID, derived := newDocumentID(req.FromChannel, "InitiateTransfer", req.IdempotencyKey)
+	args, err := transferArgs(transferID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted, err := s.submitAsync(ctx, req.FromChannel, "InitiateTransfer", transferID, sourceContract, "CreateDocument", args...)
+	if err != nil {
+		appErr := errors.ParseBlockchainError(err, "create transfer document")
+		if !derived || appErr.Code != errors.ErrCodeAlreadyExists {
+			return nil, appErr.
+				WithContext("transferId", transferID).
+				WithContext("sourceChannel", req.FromChannel).
+				WithContext("targetChannel", req.ToChannel).
+				WithContext("documentTypeId", req.DocumentTypeID).
+				WithContext("step", "create_source_document")
+		}
+		doc, err := verifyReplay(ctx, sourceContract, req.FromChannel, transferID, args)
+		if err != nil {
+			return nil, err
+		}
+		return committedCreation(req.FromChannel, doc), nil
+	}
+	return submitted, nil
+}
+
+func transferArgs(transferID string, req *models.InitiateTransferRequest) ([]string, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = "BRL"
+	}
+
+	data := req.Data
+	if data == nil {
+		data = make(map[string]any)
+	}
+	data["transferType"] = "OUTGOING"
+	data["targetOrg"] = req.ToOrg
+	data["targetChannel"] = req.ToChannel
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrCodeMarshalingFailed, "Failed to marshal transfer data", err).
+			WithContext("transferId", transferID).
+			WithContext("sourceChannel", req.FromChannel)
+	}
+
+	return []string{
+		transferID,
+		req.DocumentTypeID,
+		req.Title,
+		req.Description,
+		fmt.Sprintf("%f", req.Amount),
+		currency,
+		string(dataJSON),
+		"",
+		req.ToChannel,
+		"",
+		"OUTGOING",
+	}, nil
+}
+
+func (s *FabricService) AcknowledgeTransfer(ctx context.Context, targetChannelKey string, req *models.AcknowledgeTransferRequest) (*models.TransferResult, error) {
+	ctx, span := tracing.Start(ctx, "FabricService.AcknowledgeTransfer",
+		attribute.String("source_channel", req.SourceChannel),
+		attribute.String("target_channel", targetChannelKey),
+	)
+	defer span.End()
+
 	// Step 1: Get and verify source document from source channel
 	sourceContract, err := s.gateway.GetContract(req.SourceChannel)
 	if err != nil {
@@ -414,7 +828,9 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 			WithContext("step", "get_source_contract")
 	}
 
-	sourceResult, err := sourceContract.EvaluateTransaction("GetDocument", req.SourceDocID)
+	stepCtx, step := startStep(ctx, "AcknowledgeTransfer", "fetch_source_document", req.SourceChannel)
+	sourceResult, err := sourceContract.EvaluateTransaction(stepCtx, "GetDocument", req.SourceDocID)
+	tracing.End(step, err)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "get source document").
 			WithContext("sourceDocId", req.SourceDocID).
@@ -442,7 +858,7 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 			WithContext("step", "get_target_contract")
 	}
 
-	ackID := uuid.New().String()
+	ackID, derived := newDocumentID(targetChannelKey, "AcknowledgeTransfer", req.IdempotencyKey)
 
 	data := req.Data
 	if data == nil {
@@ -461,8 +877,7 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 			WithContext("sourceDocId", req.SourceDocID)
 	}
 
-	_, err = targetContract.SubmitTransaction(
-		"CreateDocument",
+	ackArgs := []string{
 		ackID,
 		req.DocumentTypeID,
 		req.Title,
@@ -474,18 +889,35 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 		req.SourceChannel,
 		sourceDoc.ContentHash,
 		"INCOMING",
-	)
+	}
+
+	stepCtx, step = startStep(ctx, "AcknowledgeTransfer", "create_ack_document", targetChannelKey)
+	_, err = targetContract.SubmitTransaction(stepCtx, "CreateDocument", ackArgs...)
+	tracing.End(step, err)
 	if err != nil {
-		return nil, errors.ParseBlockchainError(err, "create acknowledgment document").
-			WithContext("ackId", ackID).
-			WithContext("sourceDocId", req.SourceDocID).
-			WithContext("targetChannel", targetChannelKey).
-			WithContext("documentTypeId", req.DocumentTypeID).
-			WithContext("step", "create_ack_document").
-			WithDetails("Failed to create the incoming acknowledgment document on the target channel")
+		appErr := errors.ParseBlockchainError(err, "create acknowledgment document")
+		if !derived || appErr.Code != errors.ErrCodeAlreadyExists {
+			return nil, appErr.
+				WithContext("ackId", ackID).
+				WithContext("sourceDocId", req.SourceDocID).
+				WithContext("targetChannel", targetChannelKey).
+				WithContext("documentTypeId", req.DocumentTypeID).
+				WithContext("step", "create_ack_document").
+				WithDetails("Failed to create the incoming acknowledgment document on the target channel")
+		}
+		if _, err := verifyReplay(ctx, targetContract, targetChannelKey, ackID, ackArgs); err != nil {
+			return nil, err
+		}
+		// The link update below writes the same values again, so a replay also
+		// repairs a link left unset by an attempt that failed after the ack commit.
+		logging.Ctx(ctx).Info().Str("ackId", ackID).Str("channel", targetChannelKey).Msg("Acknowledgment already created by an earlier request with the same Idempotency-Key")
+	} else {
+		recordCommitted("AcknowledgeTransfer", targetChannelKey)
 	}
 
-	ackResult, err := targetContract.EvaluateTransaction("GetDocument", ackID)
+	stepCtx, step = startStep(ctx, "AcknowledgeTransfer", "verify_ack_document", targetChannelKey)
+	ackResult, err := targetContract.EvaluateTransaction(stepCtx, "GetDocument", ackID)
+	tracing.End(step, err)
 	if err != nil {
 		return nil, errors.ParseBlockchainError(err, "read acknowledgment document").
 			WithContext("ackId", ackID).
@@ -503,13 +935,15 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 	}
 
 	// Step 3: Update source document with link to acknowledgment
-	_, err = sourceContract.SubmitTransaction(
+	stepCtx, step = startStep(ctx, "AcknowledgeTransfer", "update_link", req.SourceChannel)
+	_, err = sourceContract.SubmitTransaction(stepCtx,
 		"UpdateDocumentLink",
 		req.SourceDocID,
 		ackID,
 		targetChannelKey,
 		ackDoc.ContentHash,
 	)
+	tracing.End(step, err)
 	if err != nil {
 		linkErr := errors.ParseBlockchainError(err, "update source document link").
 			WithContext("sourceDocId", req.SourceDocID).
@@ -518,7 +952,7 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 			WithContext("targetChannel", targetChannelKey).
 			WithContext("step", "update_link")
 
-		log.Warn().
+		logging.Ctx(ctx).Warn().
 			Err(linkErr).
 			Str("sourceDocId", req.SourceDocID).
 			Str("ackId", ackID).
@@ -526,7 +960,7 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 			Msg("Failed to update source document with acknowledgment link - transfer completed but bidirectional link not established")
 	}
 
-	log.Info().
+	logging.Ctx(ctx).Info().
 		Str("ackId", ackID).
 		Str("sourceDocId", req.SourceDocID).
 		Str("sourceChannel", req.SourceChannel).
@@ -549,9 +983,17 @@ func (s *FabricService) AcknowledgeTransfer(targetChannelKey string, req *models
 // Anchor Verification
 // =============================================================================
 
-func (s *FabricService) VerifyAnchor(sourceChannel, sourceDocID, targetChannel, targetDocID string) (*models.AnchorVerification, error) {
+func (s *FabricService) VerifyAnchor(ctx context.Context, sourceChannel, sourceDocID, targetChannel, targetDocID string) (*models.AnchorVerification, error) {
+	ctx, span := tracing.Start(ctx, "FabricService.VerifyAnchor",
+		attribute.String("source_channel", sourceChannel),
+		attribute.String("target_channel", targetChannel),
+	)
+	defer span.End()
+
 	// Fetch source document
-	sourceDoc, err := s.GetDocument(sourceChannel, sourceDocID)
+	stepCtx, step := startStep(ctx, "VerifyAnchor", "fetch_source_document", sourceChannel)
+	sourceDoc, err := s.GetDocument(stepCtx, sourceChannel, sourceDocID)
+	tracing.End(step, err)
 	if err != nil {
 		// The error from GetDocument is already structured
 		appErr, ok := err.(*errors.AppError)
@@ -567,7 +1009,9 @@ func (s *FabricService) VerifyAnchor(sourceChannel, sourceDocID, targetChannel,
 	}
 
 	// Fetch target document
-	targetDoc, err := s.GetDocument(targetChannel, targetDocID)
+	stepCtx, step = startStep(ctx, "VerifyAnchor", "fetch_target_document", targetChannel)
+	targetDoc, err := s.GetDocument(stepCtx, targetChannel, targetDocID)
+	tracing.End(step, err)
 	if err != nil {
 		appErr, ok := err.(*errors.AppError)
 		if ok {
@@ -581,24 +1025,34 @@ func (s *FabricService) VerifyAnchor(sourceChannel, sourceDocID, targetChannel,
 			WithContext("targetDocId", targetDocID)
 	}
 
+	verification := CompareAnchor(sourceChannel, sourceDoc, targetChannel, targetDoc)
+
+	span.SetAttributes(attribute.String("anchor.status", verification.Status))
+	metrics.AnchorVerified(verification.Status, anchorMismatchLabels(verification))
+	return verification, nil
+}
+
+// CompareAnchor checks the anchor target holds for source: the content hash,
+// ID and channel of source, and the same amount.
+func CompareAnchor(sourceChannel string, source *models.Document, targetChannel string, target *models.Document) *models.AnchorVerification {
 	verification := &models.AnchorVerification{
-		SourceDocID:       sourceDocID,
+		SourceDocID:       source.ID,
 		SourceChannel:     sourceChannel,
-		SourceContentHash: sourceDoc.ContentHash,
-		SourceAmount:      sourceDoc.Amount,
-		SourceCurrency:    sourceDoc.Currency,
-		TargetDocID:       targetDocID,
+		SourceContentHash: source.ContentHash,
+		SourceAmount:      source.Amount,
+		SourceCurrency:    source.Currency,
+		TargetDocID:       target.ID,
 		TargetChannel:     targetChannel,
-		TargetContentHash: targetDoc.ContentHash,
-		TargetLinkedHash:  targetDoc.LinkedDocHash, 
-		TargetAmount:      targetDoc.Amount,
-		TargetCurrency:    targetDoc.Currency,
+		TargetContentHash: target.ContentHash,
+		TargetLinkedHash:  target.LinkedDocHash,
+		TargetAmount:      target.Amount,
+		TargetCurrency:    target.Currency,
 	}
 
-	verification.HashMatch = (targetDoc.LinkedDocHash == sourceDoc.ContentHash)
-	verification.IDMatch = (targetDoc.LinkedDocID == sourceDocID)
-	verification.ChannelMatch = (targetDoc.LinkedChannel == sourceChannel)
-	verification.AmountMatch = (targetDoc.Amount == sourceDoc.Amount)
+	verification.HashMatch = (target.LinkedDocHash == source.ContentHash)
+	verification.IDMatch = (target.LinkedDocID == source.ID)
+	verification.ChannelMatch = (target.LinkedChannel == sourceChannel)
+	verification.AmountMatch = (target.Amount == source.Amount)
 
 	verification.IsValid = verification.HashMatch && verification.IDMatch && verification.ChannelMatch && verification.AmountMatch
 
@@ -621,13 +1075,39 @@ func (s *FabricService) VerifyAnchor(sourceChannel, sourceDocID, targetChannel,
 		}
 		verification.MismatchReason = reasons
 	}
+	return verification
+}
 
-	return verification, nil
+// startStep starts a span for one step of a multi-channel operation. Step
+// names match the "step" context of the errors the operation returns.
+func startStep(ctx context.Context, operation, step, channelKey string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, operation+"."+step,
+		attribute.String("step", step),
+		attribute.String("channel", channelKey),
+	)
+}
+
+// anchorMismatchLabels lists the failed anchor checks as metric label values.
+func anchorMismatchLabels(v *models.AnchorVerification) []string {
+	var labels []string
+	if !v.HashMatch {
+		labels = append(labels, "content_hash")
+	}
+	if !v.IDMatch {
+		labels = append(labels, "document_id")
+	}
+	if !v.ChannelMatch {
+		labels = append(labels, "channel")
+	}
+	if !v.AmountMatch {
+		labels = append(labels, "amount")
+	}
+	return labels
 }
 
-func (s *FabricService) GetLinkedDocuments(channelKey, docID string) (*models.LinkedDocuments, error) {
+func (s *FabricService) GetLinkedDocuments(ctx context.Context, channelKey, docID string) (*models.LinkedDocuments, error) {
 	// Get the primary document
-	doc, err := s.GetDocument(channelKey, docID)
+	doc, err := s.GetDocument(ctx, channelKey, docID)
 	if err != nil {
 		return nil, err
 	}
@@ -637,7 +1117,7 @@ func (s *FabricService) GetLinkedDocuments(channelKey, docID string) (*models.Li
 	}
 
 	if doc.LinkedDocID != "" && doc.LinkedChannel != "" {
-		linkedDoc, err := s.GetDocument(doc.LinkedChannel, doc.LinkedDocID)
+		linkedDoc, err := s.GetDocument(ctx, doc.LinkedChannel, doc.LinkedDocID)
 		if err != nil {
 			linkedErr := errors.ParseBlockchainError(err, "get linked document").
 				WithContext("docId", docID).
@@ -645,7 +1125,7 @@ func (s *FabricService) GetLinkedDocuments(channelKey, docID string) (*models.Li
 				WithContext("linkedChannel", doc.LinkedChannel).
 				WithContext("primaryChannel", channelKey)
 
-			log.Warn().
+			logging.Ctx(ctx).Warn().
 				Err(linkedErr).
 				Str("docId", docID).
 				Str("linkedDocId", doc.LinkedDocID).
@@ -660,7 +1140,7 @@ func (s *FabricService) GetLinkedDocuments(channelKey, docID string) (*models.Li
 			result.LinkVerified = (linkedDoc.ContentHash == doc.LinkedDocHash)
 
 			if !result.LinkVerified {
-				log.Warn().
+				logging.Ctx(ctx).Warn().
 					Str("docId", docID).
 					Str("linkedDocId", doc.LinkedDocID).
 					Str("expectedHash", doc.LinkedDocHash).
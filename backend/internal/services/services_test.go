@@ -0,0 +1,82 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+func TestReplayMismatch(t *testing.T) {
+	req := &models.CreateDocumentRequest{
+		DocumentTypeID: "federal-expense",
+		Title:          "Laptops",
+		Amount:         1500.5,
+		Data:           map[string]interface{}{"vendor": "Acme", "items": 3},
+	}
+	args, err := createDocumentArgs("union", "doc-1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := func() *models.Document {
+		return &models.Document{
+			ID:             "doc-1",
+			DocumentTypeID: "federal-expense",
+			Title:          "Laptops",
+			Amount:         1500.5,
+			Currency:       "BRL",
+			Data:           map[string]interface{}{"items": 3.0, "vendor": "Acme"},
+			ContentHash:    contentHashOf(t, `{"items":3,"vendor":"Acme"}`),
+		}
+	}
+
+	if field := replayMismatch(stored(), args); field != "" {
+		t.Fatalf("the same request mismatched on %s", field)
+	}
+
+	changes := map[string]func(d *models.Document){
+		"title":       func(d *models.Document) { d.Title = "Desks" },
+		"amount":      func(d *models.Document) { d.Amount = 1500 },
+		"currency":    func(d *models.Document) { d.Currency = "USD" },
+		"contentHash": func(d *models.Document) { d.ContentHash = contentHashOf(t, `{"items":4,"vendor":"Acme"}`) },
+	}
+	for want, change := range changes {
+		doc := stored()
+		change(doc)
+		if field := replayMismatch(doc, args); field != want {
+			t.Errorf("mismatch = %q, want %q", field, want)
+		}
+	}
+}
+
+func TestReplayMismatchIgnoresLinksSetLater(t *testing.T) {
+	req := &models.InitiateTransferRequest{
+		FromChannel: "union", ToChannel: "state", ToOrg: "StateMSP",
+		DocumentTypeID: "transfer", Title: "Health fund", Amount: 1e6,
+	}
+	args, err := transferArgs("tr-1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &models.Document{
+		DocumentTypeID: "transfer", Title: "Health fund", Amount: 1e6, Currency: "BRL",
+		ContentHash: contentHashOf(t, `{"targetChannel":"state","targetOrg":"StateMSP","transferType":"OUTGOING"}`),
+		// An acknowledgment links the transfer after it was created.
+		LinkedDocID: "ack-1", LinkedChannel: "state", LinkedDocHash: "abc", LinkedDirection: "OUTGOING",
+	}
+	if field := replayMismatch(doc, args); field != "" {
+		t.Errorf("an acknowledged transfer mismatched on %s", field)
+	}
+
+	doc.LinkedDirection = "INCOMING"
+	if field := replayMismatch(doc, args); field != "linkedDirection" {
+		t.Errorf("mismatch = %q, want linkedDirection", field)
+	}
+}
+
+func contentHashOf(t *testing.T, dataJSON string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(dataJSON))
+	return hex.EncodeToString(sum[:])
+}
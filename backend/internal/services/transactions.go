@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/metrics"
+	"github.com/gov-spending/backend/internal/models"
+	"github.com/gov-spending/backend/pkg/fabric"
+)
+
+// maxTrackedTransactions bounds the in-memory record of async submissions.
+// Older transactions are still answered from the ledger.
+const maxTrackedTransactions = 1000
+
+type trackedTransaction struct {
+	channel     string
+	operation   string
+	id          string
+	submittedAt time.Time
+	status      *fabric.CommitStatus
+}
+
+// submitAsync submits a transaction without waiting for its commit and tracks
+// it so GetTransactionStatus can report PENDING before it reaches the ledger.
+// Errors are returned unwrapped for the caller to classify.
+func (s *FabricService) submitAsync(ctx context.Context, channelKey, operation, id string, contract *fabric.Contract, name string, args ...string) (*models.SubmittedTransaction, error) {
+	pending, err := contract.SubmitAsync(ctx, name, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &trackedTransaction{
+		channel:     channelKey,
+		operation:   operation,
+		id:          id,
+		submittedAt: time.Now().UTC(),
+	}
+	s.trackTransaction(pending.TransactionID, tracked)
+	go s.awaitCommit(context.WithoutCancel(ctx), pending, tracked)
+
+	logging.Ctx(ctx).Info().
+		Str("txId", pending.TransactionID).
+		Str("operation", operation).
+		Str("id", id).
+		Str("channel", channelKey).
+		Msg("Transaction submitted")
+
+	return &models.SubmittedTransaction{
+		Success: true,
+		ID:      id,
+		TxID:    pending.TransactionID,
+		Channel: channelKey,
+		Status:  models.TxStatusSubmitted,
+	}, nil
+}
+
+func (s *FabricService) trackTransaction(txID string, tracked *trackedTransaction) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.transactions[txID] = tracked
+	s.txOrder = append(s.txOrder, txID)
+	for len(s.txOrder) > maxTrackedTransactions {
+		delete(s.transactions, s.txOrder[0])
+		s.txOrder = s.txOrder[1:]
+	}
+}
+
+// awaitCommit runs after the submitting request has returned; ctx only carries
+// its logger.
+func (s *FabricService) awaitCommit(ctx context.Context, pending *fabric.PendingTransaction, tracked *trackedTransaction) {
+	logger := logging.Ctx(ctx)
+	status, err := pending.Wait()
+	if err != nil {
+		// The status endpoint falls back to the ledger for this transaction.
+		logger.Warn().
+			Err(err).
+			Str("txId", pending.TransactionID).
+			Str("channel", tracked.channel).
+			Msg("Failed to observe commit of submitted transaction")
+		return
+	}
+
+	s.txMu.Lock()
+	tracked.status = status
+	s.txMu.Unlock()
+
+	logEvent := logger.Info()
+	if status.Valid() {
+		recordCommitted(tracked.operation, tracked.channel)
+	} else {
+		logEvent = logger.Warn()
+	}
+	logEvent.
+		Str("txId", pending.TransactionID).
+		Str("operation", tracked.operation).
+		Str("id", tracked.id).
+		Str("channel", tracked.channel).
+		Str("validationCode", status.Code.String()).
+		Msg("Submitted transaction committed")
+}
+
+// recordCommitted counts the domain event of an operation that committed on
+// the channel. Operations without a domain metric are ignored.
+func recordCommitted(operation, channelKey string) {
+	switch operation {
+	case "CreateDocument":
+		metrics.DocumentCreated(channelKey)
+	case "InvalidateDocument":
+		metrics.DocumentInvalidated(channelKey)
+	case "InitiateTransfer":
+		metrics.DocumentCreated(channelKey)
+		metrics.Transfer(metrics.TransferInitiated, channelKey)
+	case "AcknowledgeTransfer":
+		metrics.DocumentCreated(channelKey)
+		metrics.Transfer(metrics.TransferAcknowledged, channelKey)
+	}
+}
+
+// committedCreation answers an async create whose deterministic ID already
+// exists: an earlier request with the same Idempotency-Key created doc, so its
+// creation transaction is reported instead of submitting a duplicate.
+func committedCreation(channelKey string, doc *models.Document) *models.SubmittedTransaction {
+	var txID string
+	if len(doc.History) > 0 {
+		txID = doc.History[0]
+	}
+
+	return &models.SubmittedTransaction{
+		Success: true,
+		ID:      doc.ID,
+		TxID:    txID,
+		Channel: channelKey,
+		Status:  models.TxStatusValid,
+	}
+}
+
+// =============================================================================
+// Transaction Status
+// =============================================================================
+
+func (s *FabricService) GetTransactionStatus(ctx context.Context, channelKey, txID string) (*models.TransactionStatus, error) {
+	result := &models.TransactionStatus{TxID: txID, Channel: channelKey}
+
+	var status *fabric.CommitStatus
+	tracked := false
+
+	s.txMu.Lock()
+	if t, ok := s.transactions[txID]; ok && t.channel == channelKey {
+		tracked = true
+		status = t.status
+		result.Operation = t.operation
+		result.ID = t.id
+		result.SubmittedAt = t.submittedAt.Format(time.RFC3339)
+	}
+	s.txMu.Unlock()
+
+	if status == nil {
+		contract, err := s.gateway.GetContract(channelKey)
+		if err != nil {
+			return nil, errors.ParseBlockchainError(err, "get contract").
+				WithContext("channel", channelKey).
+				WithContext("operation", "GetTransactionStatus")
+		}
+
+		found := false
+		status, found, err = contract.CommitStatus(ctx, txID)
+		if err != nil {
+			return nil, errors.ParseBlockchainError(err, "query transaction status").
+				WithContext("txId", txID).
+				WithContext("channel", channelKey)
+		}
+		if !found {
+			if !tracked {
+				return nil, errors.NewNotFoundError("Transaction", txID).
+					WithContext("channel", channelKey)
+			}
+			result.Status = models.TxStatusPending
+			return result, nil
+		}
+	}
+
+	result.Committed = true
+	result.Valid = status.Valid()
+	result.Status = status.Code.String()
+	result.BlockNumber = status.BlockNumber
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	exportIDHeader     = "X-Export-ID"
+	exportSHA256Header = "X-Export-SHA256"
+	exportErrorHeader  = "X-Export-Error"
+)
+
+// StartImport uploads a CSV or NDJSON file whose rows become documents of
+// opts.DocumentTypeID, and returns the import job. Rows are created in the
+// background; poll GetImport for progress. The file is read into memory so
+// the upload can be retried.
+func (c *Client) StartImport(ctx context.Context, channel, fileName string, file io.Reader, opts *ImportOptions) (*ImportJob, error) {
+	mapping, err := json.Marshal(opts.Mapping)
+	if err != nil {
+		return nil, fmt.Errorf("encode import mapping: %w", err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := [][2]string{
+		{"documentTypeId", opts.DocumentTypeID},
+		{"mapping", string(mapping)},
+		{"format", string(opts.Format)},
+		{"importKey", opts.ImportKey},
+	}
+	if opts.Concurrency > 0 {
+		fields = append(fields, [2]string{"concurrency", strconv.Itoa(opts.Concurrency)})
+	}
+	if opts.DryRun {
+		fields = append(fields, [2]string{"dryRun", "true"})
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			return nil, err
+		}
+	}
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	var job ImportJob
+	r := newRequest(http.MethodPost, channel, "imports")
+	r.body = body.Bytes()
+	r.contentType = form.FormDataContentType()
+	r.out = &job
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListImports lists the recent import jobs of a channel, without row
+// results.
+func (c *Client) ListImports(ctx context.Context, channel string) ([]*ImportJob, error) {
+	var result []*ImportJob
+	r := newRequest(http.MethodGet, channel, "imports")
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetImport gets the progress and row results of an import job. A non-empty
+// rowStatus only includes rows with that status.
+func (c *Client) GetImport(ctx context.Context, channel, jobID string, rowStatus ImportRowStatus) (*ImportJob, error) {
+	var job ImportJob
+	r := newRequest(http.MethodGet, channel, "imports", jobID)
+	if rowStatus != "" {
+		r.query = url.Values{"rowStatus": {string(rowStatus)}}
+	}
+	r.out = &job
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ErrExportInterrupted is returned, wrapped, by Export.Read in place of
+// io.EOF when the server failed partway through the export and the file is
+// truncated.
+var ErrExportInterrupted = errors.New("export interrupted by the server")
+
+// Export is a document export being streamed from the server. Read it to
+// the end, then compare SHA256 with the manifest from GetExportManifest.
+// Close it when done.
+type Export struct {
+	ID          string
+	ContentType string
+
+	resp *http.Response
+}
+
+func (e *Export) Read(p []byte) (int, error) {
+	n, err := e.resp.Body.Read(p)
+	if err == io.EOF {
+		if msg := e.resp.Trailer.Get(exportErrorHeader); msg != "" {
+			err = fmt.Errorf("%w: %s", ErrExportInterrupted, msg)
+		}
+	}
+	return n, err
+}
+
+// Close releases the connection of the export.
+func (e *Export) Close() error {
+	return e.resp.Body.Close()
+}
+
+// SHA256 returns the hex SHA-256 of the export file, sent by the server
+// after the file. It is empty until the export was read to the end, and
+// stays empty when the server interrupted the stream.
+func (e *Export) SHA256() string {
+	return e.resp.Trailer.Get(exportSHA256Header)
+}
+
+// ExportDocuments starts streaming every document matching filter in the
+// given format. filter.PageSize and filter.Bookmark are ignored.
+func (c *Client) ExportDocuments(ctx context.Context, channel string, format ExportFormat, filter *QueryFilter) (*Export, error) {
+	query, err := encodeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	query.Del("pageSize")
+	query.Del("bookmark")
+	query.Set("format", string(format))
+
+	r := newRequest(http.MethodGet, channel, "exports", "documents")
+	r.query = query
+	r.accept = "*/*"
+
+	var export *Export
+	err = Retry(ctx, c.retry, func(ctx context.Context) error {
+		resp, err := c.send(ctx, r)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			return decodeError(resp)
+		}
+		export = &Export{
+			ID:          resp.Header.Get(exportIDHeader),
+			ContentType: resp.Header.Get("Content-Type"),
+			resp:        resp,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetExportManifest gets the signed manifest of a finished export.
+func (c *Client) GetExportManifest(ctx context.Context, channel, exportID string) (*ExportManifest, error) {
+	var manifest ExportManifest
+	r := newRequest(http.MethodGet, channel, "exports", exportID, "manifest")
+	r.out = &manifest
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// VerifyExportManifest checks the manifest's signature against the signer
+// certificate it carries. It does not establish who issued that certificate:
+// check it against the signing organization's CA before trusting the export.
+func VerifyExportManifest(manifest *ExportManifest) error {
+	if manifest.SignatureAlgorithm != "ECDSA-SHA256" {
+		return fmt.Errorf("unsupported manifest signature algorithm %q", manifest.SignatureAlgorithm)
+	}
+	block, _ := pem.Decode([]byte(manifest.SignerCertificate))
+	if block == nil {
+		return errors.New("manifest has no PEM signer certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid signer certificate: %w", err)
+	}
+	key, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("signer certificate does not hold an ECDSA key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(manifest.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid manifest signature: %w", err)
+	}
+	digest := sha256.Sum256(manifest.SignedPayload())
+	if !ecdsa.VerifyASN1(key, digest[:], signature) {
+		return errors.New("manifest signature does not match its content")
+	}
+	return nil
+}
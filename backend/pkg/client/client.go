@@ -0,0 +1,302 @@
+// Package client is the Go SDK of the gov-spending REST API. It calls the
+// versioned routes under /api/v1, unwraps their response envelope, decodes
+// error responses into *Error values carrying the API's ErrorCode, and
+// pages through document queries.
+//
+//	c, err := client.New("http://localhost:3000", client.WithRetry(client.DefaultRetryPolicy))
+//	ctx = client.WithRequestID(ctx, requestID)
+//	doc, err := c.GetDocument(ctx, "union", "DOC-001")
+//	if client.ErrorCodeOf(err) == client.ErrCodeNotFound { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+const (
+	requestIDHeader      = "X-Request-ID"
+	idempotencyKeyHeader = "Idempotency-Key"
+	retryAfterHeader     = "Retry-After"
+
+	apiPrefix = "/api/v1"
+)
+
+// Client calls the API of one backend instance. It is safe for concurrent
+// use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are made with, for example to
+// configure TLS or timeouts. The default is http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithRetry retries requests that fail with a retriable error. Reads are
+// always retried; writes only when the context carries an idempotency key,
+// so a retried write is applied once. Without this option no request is
+// retried.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// New creates a client for the instance at baseURL, such as
+// http://localhost:3000.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: want http(s)://host[:port]", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(u.String(), "/"),
+		httpClient: http.DefaultClient,
+		userAgent:  "gov-spending-go-client",
+		retry:      noRetry,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	idempotencyKeyKey
+)
+
+// WithRequestID returns a context whose requests carry id as X-Request-ID,
+// so the server logs and traces them under the caller's request ID. Retries
+// of a request keep its ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID set with WithRequestID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithIdempotencyKey returns a context whose write requests carry key as
+// Idempotency-Key: the server applies a write once per key and replays the
+// original response to repeats. Use a new key for each logical write.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyKey).(string)
+	return key
+}
+
+// request is one API call.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	accept      string
+
+	// out receives the envelope's data, meta its metadata. With raw, out
+	// receives the whole body of an endpoint outside /api/v1.
+	out  interface{}
+	meta *models.Meta
+	raw  bool
+	// okStatus is an error status whose body is a regular response.
+	okStatus int
+	// readOnly marks a POST without side effects, safe to retry.
+	readOnly bool
+}
+
+// newRequest creates a request to an /api/v1 route. Path segments are
+// escaped.
+func newRequest(method string, segments ...string) *request {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	return &request{method: method, path: apiPrefix + "/" + strings.Join(escaped, "/")}
+}
+
+// withJSON sets v as the JSON request body.
+func (r *request) withJSON(v interface{}) (*request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+	r.body = body
+	r.contentType = "application/json"
+	return r, nil
+}
+
+// withAsync asks a write endpoint to answer once the transaction is
+// submitted instead of committed.
+func (r *request) withAsync() *request {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	r.query.Set("async", "true")
+	return r
+}
+
+// do sends req, retrying it under the client's policy when that is safe.
+func (c *Client) do(ctx context.Context, req *request) error {
+	policy := c.retry
+	if req.method != http.MethodGet && !req.readOnly && idempotencyKey(ctx) == "" {
+		policy = noRetry
+	}
+	return Retry(ctx, policy, func(ctx context.Context) error {
+		resp, err := c.send(ctx, req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return decodeResponse(resp, req)
+	})
+}
+
+// send makes one attempt at req and returns the response to any status.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	accept := req.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+	httpReq.Header.Set("Accept", accept)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		httpReq.Header.Set(requestIDHeader, id)
+	}
+	if key := idempotencyKey(ctx); key != "" && req.method != http.MethodGet {
+		httpReq.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+// decodeResponse decodes a response into req's targets, or into an *Error.
+func decodeResponse(resp *http.Response, req *request) error {
+	success := resp.StatusCode >= 200 && resp.StatusCode < 300 || resp.StatusCode == req.okStatus
+	if !success {
+		return decodeError(resp)
+	}
+
+	if req.raw {
+		if req.out == nil {
+			return nil
+		}
+		return decodeJSON(resp, req.out)
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+		Meta models.Meta     `json:"meta"`
+	}
+	if err := decodeJSON(resp, &envelope); err != nil {
+		return err
+	}
+	if req.meta != nil {
+		*req.meta = envelope.Meta
+	}
+	if req.out != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, req.out); err != nil {
+			return decodeFailure(resp, err)
+		}
+	}
+	return nil
+}
+
+func decodeJSON(resp *http.Response, v interface{}) error {
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return decodeFailure(resp, err)
+	}
+	return nil
+}
+
+func decodeFailure(resp *http.Response, err error) *Error {
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Code:       ErrCodeUnmarshalingFailed,
+		Message:    "Failed to decode the response: " + err.Error(),
+		RequestID:  resp.Header.Get(requestIDHeader),
+	}
+}
+
+// decodeError decodes an error envelope. Responses that are not one, such as
+// a proxy's error page, get a code derived from the status.
+func decodeError(resp *http.Response) error {
+	var envelope models.ErrorEnvelope
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	var apiErr *Error
+	if json.Unmarshal(data, &envelope) != nil || envelope.Error.Code == "" {
+		apiErr = unexpectedError(resp)
+	} else {
+		apiErr = &Error{
+			StatusCode: resp.StatusCode,
+			Code:       ErrorCode(envelope.Error.Code),
+			Message:    envelope.Error.Message,
+			Details:    envelope.Error.Details,
+			Retriable:  envelope.Error.Retriable,
+			Context:    envelope.Error.Context,
+			RequestID:  envelope.Meta.RequestID,
+		}
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = resp.Header.Get(requestIDHeader)
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get(retryAfterHeader)); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
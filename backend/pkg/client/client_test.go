@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := New(server.URL, opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestGetDocumentUnwrapsEnvelope(t *testing.T) {
+	var requestID string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/documents/{id}", func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get("X-Request-ID")
+		writeJSON(w, http.StatusOK, models.Envelope{
+			Data: models.Document{ID: r.PathValue("id"), Title: "Grant"},
+			Meta: models.Meta{RequestID: r.Header.Get("X-Request-ID")},
+		})
+	})
+	c := newTestClient(t, mux)
+
+	doc, err := c.GetDocument(WithRequestID(context.Background(), "req-1"), "union", "DOC 1")
+	if err != nil {
+		t.Fatalf("GetDocument: %v", err)
+	}
+	if doc.ID != "DOC 1" || doc.Title != "Grant" {
+		t.Errorf("document = %+v", doc)
+	}
+	if requestID != "req-1" {
+		t.Errorf("X-Request-ID = %q, want req-1", requestID)
+	}
+}
+
+func TestErrorsAreDecoded(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/documents/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, models.ErrorEnvelope{
+			Error: models.APIError{Code: "NOT_FOUND", Message: "Document not found", Context: map[string]interface{}{"docId": "X"}},
+			Meta:  models.Meta{RequestID: r.Header.Get("X-Request-ID")},
+		})
+	})
+	mux.HandleFunc("GET /api/v1/union/document-types", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		http.Error(w, "<html>bad gateway</html>", http.StatusBadGateway)
+	})
+	c := newTestClient(t, mux)
+	ctx := WithRequestID(context.Background(), "req-2")
+
+	_, err := c.GetDocument(ctx, "union", "X")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != ErrCodeNotFound || apiErr.RequestID != "req-2" || apiErr.Context["docId"] != "X" {
+		t.Errorf("error = %+v", apiErr)
+	}
+	if IsRetriable(err) {
+		t.Error("NOT_FOUND should not be retriable")
+	}
+
+	_, err = c.ListDocumentTypes(ctx, "union")
+	if ErrorCodeOf(err) != ErrCodeServiceFailure || !IsRetriable(err) {
+		t.Errorf("non-envelope 502: code %q, retriable %v", ErrorCodeOf(err), IsRetriable(err))
+	}
+	if errors.As(err, &apiErr); apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want 7s", apiErr.RetryAfter)
+	}
+}
+
+func TestDocumentsFollowsBookmarks(t *testing.T) {
+	pages := map[string]struct {
+		ids  []string
+		next string
+	}{
+		"":   {[]string{"a", "b"}, "b1"},
+		"b1": {[]string{"c", "d"}, "b2"},
+		"b2": {[]string{"e"}, ""},
+	}
+	var wheres [][]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/state/documents", func(w http.ResponseWriter, r *http.Request) {
+		wheres = append(wheres, r.URL.Query()["where"])
+		page := pages[r.URL.Query().Get("bookmark")]
+		docs := make([]models.Document, len(page.ids))
+		for i, id := range page.ids {
+			docs[i] = models.Document{ID: id}
+		}
+		writeJSON(w, http.StatusOK, models.Envelope{
+			Data: docs,
+			Meta: models.Meta{Pagination: &models.Pagination{Count: len(docs), NextBookmark: page.next, HasMore: page.next != ""}},
+		})
+	})
+	c := newTestClient(t, mux)
+
+	filter := &QueryFilter{
+		PageSize: 2,
+		Conditions: []FieldCondition{
+			{Field: "data.municipality", Op: "in", Values: []interface{}{"Campinas", "Santos"}},
+			{Field: "data.year", Op: "gte", Value: 2024},
+		},
+	}
+	var got []string
+	for doc, err := range c.Documents(context.Background(), "state", filter) {
+		if err != nil {
+			t.Fatalf("Documents: %v", err)
+		}
+		got = append(got, doc.ID)
+	}
+	if strings.Join(got, ",") != "a,b,c,d,e" {
+		t.Errorf("documents = %v", got)
+	}
+	if len(wheres) != 3 {
+		t.Fatalf("requests = %d, want 3", len(wheres))
+	}
+	want := `data.municipality:in:"Campinas"|"Santos",data.year:gte:2024`
+	if strings.Join(wheres[2], ",") != want {
+		t.Errorf("where = %v, want %s", wheres[2], want)
+	}
+	if filter.Bookmark != "" {
+		t.Error("iteration modified the caller's filter")
+	}
+}
+
+func TestOnlySafeRequestsAreRetried(t *testing.T) {
+	var reads, writes atomic.Int32
+	var keys []string
+	unavailable := models.ErrorEnvelope{Error: models.APIError{Code: "CHANNEL_UNAVAILABLE", Message: "Channel unavailable", Retriable: true}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/document-types", func(w http.ResponseWriter, r *http.Request) {
+		if reads.Add(1) < 3 {
+			writeJSON(w, http.StatusServiceUnavailable, unavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, models.Envelope{Data: []models.DocumentType{{ID: "GRANT"}}})
+	})
+	mux.HandleFunc("POST /api/v1/union/documents", func(w http.ResponseWriter, r *http.Request) {
+		writes.Add(1)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		writeJSON(w, http.StatusServiceUnavailable, unavailable)
+	})
+	c := newTestClient(t, mux, WithRetry(fastRetry))
+	ctx := context.Background()
+
+	types, err := c.ListDocumentTypes(ctx, "union")
+	if err != nil || len(types) != 1 || reads.Load() != 3 {
+		t.Fatalf("ListDocumentTypes = %v, %v after %d attempts", types, err, reads.Load())
+	}
+
+	req := &CreateDocumentRequest{DocumentTypeID: "GRANT", Title: "Grant"}
+	if _, err := c.CreateDocument(ctx, "union", req); ErrorCodeOf(err) != ErrCodeChannelUnavailable || writes.Load() != 1 {
+		t.Errorf("write without key: err %v after %d attempts, want 1", err, writes.Load())
+	}
+
+	writes.Store(0)
+	keys = nil
+	if _, err := c.CreateDocument(WithIdempotencyKey(ctx, "key-1"), "union", req); err == nil || writes.Load() != 3 {
+		t.Errorf("write with key: err %v after %d attempts, want 3", err, writes.Load())
+	}
+	for _, key := range keys {
+		if key != "key-1" {
+			t.Errorf("Idempotency-Key = %q, want key-1", key)
+		}
+	}
+}
+
+func TestExportReportsTrailerDigest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/exports/documents", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "ndjson" || r.URL.Query().Has("pageSize") {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("X-Export-ID", "exp-1")
+		w.Header().Set("Trailer", "X-Export-SHA256")
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		io.WriteString(w, "{\"id\":\"a\"}\n")
+		w.Header().Set("X-Export-SHA256", "abc123")
+	})
+	c := newTestClient(t, mux)
+
+	export, err := c.ExportDocuments(context.Background(), "union", ExportFormatNDJSON, &QueryFilter{PageSize: 5})
+	if err != nil {
+		t.Fatalf("ExportDocuments: %v", err)
+	}
+	defer export.Close()
+	if export.SHA256() != "" {
+		t.Error("digest available before the body was read")
+	}
+	body, err := io.ReadAll(export)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if export.ID != "exp-1" || string(body) != "{\"id\":\"a\"}\n" || export.SHA256() != "abc123" {
+		t.Errorf("export %s = %q, digest %q", export.ID, body, export.SHA256())
+	}
+}
+
+func TestExportReportsInterruption(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/union/exports/documents", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Export-SHA256, X-Export-Error")
+		io.WriteString(w, "{\"id\":\"a\"}\n")
+		w.Header().Set("X-Export-Error", "Peer unavailable")
+	})
+	c := newTestClient(t, mux)
+
+	export, err := c.ExportDocuments(context.Background(), "union", ExportFormatNDJSON, &QueryFilter{})
+	if err != nil {
+		t.Fatalf("ExportDocuments: %v", err)
+	}
+	defer export.Close()
+	body, err := io.ReadAll(export)
+	if !errors.Is(err, ErrExportInterrupted) || !strings.Contains(err.Error(), "Peer unavailable") {
+		t.Fatalf("read error = %v, want ErrExportInterrupted", err)
+	}
+	if string(body) != "{\"id\":\"a\"}\n" || export.SHA256() != "" {
+		t.Errorf("export = %q, digest %q", body, export.SHA256())
+	}
+}
+
+func TestVerifyExportManifest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Admin@union.gov.br"},
+		NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := &ExportManifest{
+		ExportID: "exp-1", Channel: "union", Format: ExportFormatCSV, FilterQuery: "minAmount=1000&where=data.vendor%3Aeq%3AAcme",
+		StartedAt: "2025-01-01T00:00:00Z", CompletedAt: "2025-01-01T00:00:02Z", Complete: true,
+		DocumentCount: 2, Pages: 1, ByteSize: 512, SHA256: "abc123", SignerMSPID: "UnionMSP",
+		SignatureAlgorithm: "ECDSA-SHA256",
+		SignerCertificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+	want := "gov-spending-export-manifest/v1\nexportId=exp-1\nchannel=union\nformat=csv\n" +
+		"filterQuery=minAmount=1000&where=data.vendor%3Aeq%3AAcme\nstartedAt=2025-01-01T00:00:00Z\n" +
+		"completedAt=2025-01-01T00:00:02Z\ncomplete=true\nerror=\ndocumentCount=2\npages=1\nbyteSize=512\n" +
+		"sha256=abc123\nsignerMspId=UnionMSP\n"
+	if got := string(manifest.SignedPayload()); got != want {
+		t.Fatalf("signed payload =\n%s\nwant\n%s", got, want)
+	}
+
+	digest := sha256.Sum256([]byte(want))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.Signature = base64.StdEncoding.EncodeToString(signature)
+	if err := VerifyExportManifest(manifest); err != nil {
+		t.Fatalf("VerifyExportManifest: %v", err)
+	}
+
+	manifest.DocumentCount = 3
+	if err := VerifyExportManifest(manifest); err == nil {
+		t.Error("a tampered manifest verified")
+	}
+}
+
+func TestReadinessReturnsNotReadyReport(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health/ready", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusServiceUnavailable, models.ReadinessResponse{Status: "not_ready"})
+	})
+	c := newTestClient(t, mux)
+
+	ready, err := c.Readiness(context.Background())
+	if err != nil || ready.Status != "not_ready" {
+		t.Errorf("Readiness = %+v, %v", ready, err)
+	}
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// CreateDocument creates a document and waits for the commit.
+func (c *Client) CreateDocument(ctx context.Context, channel string, req *CreateDocumentRequest) (*ResourceID, error) {
+	r, err := newRequest(http.MethodPost, channel, "documents").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	var result ResourceID
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateDocumentAsync creates a document and returns once the transaction is
+// submitted. Follow it with WaitForTransaction.
+func (c *Client) CreateDocumentAsync(ctx context.Context, channel string, req *CreateDocumentRequest) (*SubmittedTransaction, error) {
+	r, err := newRequest(http.MethodPost, channel, "documents").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(ctx, r.withAsync())
+}
+
+// GetDocument gets a document.
+func (c *Client) GetDocument(ctx context.Context, channel, docID string) (*Document, error) {
+	var result Document
+	r := newRequest(http.MethodGet, channel, "documents", docID)
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// QueryDocuments gets one page of the documents matching filter. Pass the
+// page's Pagination.NextBookmark as filter.Bookmark for the next page, or
+// use Documents or DocumentPages to iterate.
+func (c *Client) QueryDocuments(ctx context.Context, channel string, filter *QueryFilter) (*DocumentPage, error) {
+	query, err := encodeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var page DocumentPage
+	var meta models.Meta
+	r := newRequest(http.MethodGet, channel, "documents")
+	r.query = query
+	r.out = &page.Documents
+	r.meta = &meta
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	if meta.Pagination != nil {
+		page.Pagination = *meta.Pagination
+	}
+	return &page, nil
+}
+
+// DocumentPages iterates over the pages of a query, from filter.Bookmark
+// on. Iteration stops after the last page or the first error.
+func (c *Client) DocumentPages(ctx context.Context, channel string, filter *QueryFilter) iter.Seq2[*DocumentPage, error] {
+	return func(yield func(*DocumentPage, error) bool) {
+		next := QueryFilter{}
+		if filter != nil {
+			next = *filter
+		}
+		for {
+			page, err := c.QueryDocuments(ctx, channel, &next)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+			if !page.Pagination.HasMore || page.Pagination.NextBookmark == "" || page.Pagination.NextBookmark == next.Bookmark {
+				return
+			}
+			next.Bookmark = page.Pagination.NextBookmark
+		}
+	}
+}
+
+// Documents iterates over every document matching filter, fetching pages
+// of filter.PageSize as needed.
+//
+//	for doc, err := range c.Documents(ctx, "union", &client.QueryFilter{Status: client.StatusActive}) {
+//		if err != nil { return err }
+//		...
+//	}
+func (c *Client) Documents(ctx context.Context, channel string, filter *QueryFilter) iter.Seq2[*Document, error] {
+	return func(yield func(*Document, error) bool) {
+		for page, err := range c.DocumentPages(ctx, channel, filter) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, doc := range page.Documents {
+				if !yield(doc, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetDocumentHistory gets every version of a document.
+func (c *Client) GetDocumentHistory(ctx context.Context, channel, docID string) ([]*HistoryEntry, error) {
+	var result []*HistoryEntry
+	r := newRequest(http.MethodGet, channel, "documents", docID, "history")
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLinkedDocuments gets a document and its cross-channel linked document,
+// if any.
+func (c *Client) GetLinkedDocuments(ctx context.Context, channel, docID string) (*LinkedDocuments, error) {
+	var result LinkedDocuments
+	r := newRequest(http.MethodGet, channel, "documents", docID, "linked")
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InvalidateDocument marks a document invalid and waits for the commit.
+func (c *Client) InvalidateDocument(ctx context.Context, channel, docID string, req *InvalidateDocumentRequest) (*ActionResult, error) {
+	r, err := newRequest(http.MethodPost, channel, "documents", docID, "invalidate").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.act(ctx, r)
+}
+
+// InvalidateDocumentAsync marks a document invalid and returns once the
+// transaction is submitted.
+func (c *Client) InvalidateDocumentAsync(ctx context.Context, channel, docID string, req *InvalidateDocumentRequest) (*SubmittedTransaction, error) {
+	r, err := newRequest(http.MethodPost, channel, "documents", docID, "invalidate").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(ctx, r.withAsync())
+}
+
+// encodeFilter turns a filter into the query parameters of document queries
+// and exports. Conditions are sent as where clauses, with values JSON-encoded
+// so they keep their type.
+func encodeFilter(filter *QueryFilter) (url.Values, error) {
+	query := url.Values{}
+	if filter == nil {
+		return query, nil
+	}
+
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	set("organizationId", filter.OrganizationID)
+	set("documentTypeId", filter.DocumentTypeID)
+	set("status", string(filter.Status))
+	set("fromDate", filter.FromDate)
+	set("toDate", filter.ToDate)
+	if filter.MinAmount != 0 {
+		query.Set("minAmount", strconv.FormatFloat(filter.MinAmount, 'f', -1, 64))
+	}
+	if filter.MaxAmount != 0 {
+		query.Set("maxAmount", strconv.FormatFloat(filter.MaxAmount, 'f', -1, 64))
+	}
+	if filter.HasLinkedDoc != nil {
+		query.Set("hasLinkedDoc", strconv.FormatBool(*filter.HasLinkedDoc))
+	}
+	set("linkedDirection", filter.LinkedDirection)
+	set("sortBy", filter.SortBy)
+	set("sortOrder", filter.SortOrder)
+	set("count", filter.CountMode)
+	if filter.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(filter.PageSize))
+	}
+	set("bookmark", filter.Bookmark)
+
+	for _, where := range filter.Where {
+		query.Add("where", where)
+	}
+	for _, cond := range filter.Conditions {
+		clause, err := whereClause(cond)
+		if err != nil {
+			return nil, err
+		}
+		query.Add("where", clause)
+	}
+	return query, nil
+}
+
+// whereClause encodes a condition as field:op:value, "in" values separated
+// by "|".
+func whereClause(cond FieldCondition) (string, error) {
+	values := cond.Values
+	if cond.Op != "in" {
+		values = []interface{}{cond.Value}
+	}
+	encoded := make([]string, len(values))
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("encode condition on %s: %w", cond.Field, err)
+		}
+		encoded[i] = string(data)
+	}
+	return cond.Field + ":" + cond.Op + ":" + strings.Join(encoded, "|"), nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// RegisterDocumentType registers a document type and waits for the commit.
+func (c *Client) RegisterDocumentType(ctx context.Context, channel string, req *CreateDocumentTypeRequest) (*ResourceID, error) {
+	r, err := newRequest(http.MethodPost, channel, "document-types").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	var result ResourceID
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RegisterDocumentTypeAsync registers a document type and returns once the
+// transaction is submitted. Follow it with WaitForTransaction.
+func (c *Client) RegisterDocumentTypeAsync(ctx context.Context, channel string, req *CreateDocumentTypeRequest) (*SubmittedTransaction, error) {
+	r, err := newRequest(http.MethodPost, channel, "document-types").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(ctx, r.withAsync())
+}
+
+// ListDocumentTypes lists the document types of a channel.
+func (c *Client) ListDocumentTypes(ctx context.Context, channel string) ([]*DocumentType, error) {
+	var result []*DocumentType
+	r := newRequest(http.MethodGet, channel, "document-types")
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDocumentType gets a document type.
+func (c *Client) GetDocumentType(ctx context.Context, channel, typeID string) (*DocumentType, error) {
+	var result DocumentType
+	r := newRequest(http.MethodGet, channel, "document-types", typeID)
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDocumentTypeIndexes derives CouchDB index definitions for the data
+// fields a document type declares.
+func (c *Client) GetDocumentTypeIndexes(ctx context.Context, channel, typeID string) ([]*CouchDBIndex, error) {
+	var result []*CouchDBIndex
+	r := newRequest(http.MethodGet, channel, "document-types", typeID, "indexes")
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeactivateDocumentType deactivates a document type and waits for the
+// commit.
+func (c *Client) DeactivateDocumentType(ctx context.Context, channel, typeID string) (*ActionResult, error) {
+	return c.act(ctx, newRequest(http.MethodDelete, channel, "document-types", typeID))
+}
+
+// DeactivateDocumentTypeAsync deactivates a document type and returns once
+// the transaction is submitted.
+func (c *Client) DeactivateDocumentTypeAsync(ctx context.Context, channel, typeID string) (*SubmittedTransaction, error) {
+	return c.submit(ctx, newRequest(http.MethodDelete, channel, "document-types", typeID).withAsync())
+}
+
+// act sends a request answered with an ActionResult.
+func (c *Client) act(ctx context.Context, r *request) (*ActionResult, error) {
+	var result ActionResult
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// submit sends an async write, answered with the submitted transaction.
+func (c *Client) submit(ctx context.Context, r *request) (*SubmittedTransaction, error) {
+	var result SubmittedTransaction
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
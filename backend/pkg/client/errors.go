@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+)
+
+// ErrorCode is the machine-readable code of an API error.
+type ErrorCode = apperrors.ErrorCode
+
+// Error codes the API returns.
+const (
+	ErrCodeNetworkFailure     = apperrors.ErrCodeNetworkFailure
+	ErrCodeConnectionTimeout  = apperrors.ErrCodeConnectionTimeout
+	ErrCodeChannelUnavailable = apperrors.ErrCodeChannelUnavailable
+	ErrCodeGatewayFailure     = apperrors.ErrCodeGatewayFailure
+
+	ErrCodeNotFound         = apperrors.ErrCodeNotFound
+	ErrCodeAlreadyExists    = apperrors.ErrCodeAlreadyExists
+	ErrCodeInvalidChannel   = apperrors.ErrCodeInvalidChannel
+	ErrCodeContractNotFound = apperrors.ErrCodeContractNotFound
+	ErrCodeInvalidState     = apperrors.ErrCodeInvalidState
+
+	ErrCodeValidationFailed    = apperrors.ErrCodeValidationFailed
+	ErrCodeInvalidInput        = apperrors.ErrCodeInvalidInput
+	ErrCodeInvalidDocumentType = apperrors.ErrCodeInvalidDocumentType
+	ErrCodeInvalidTransfer     = apperrors.ErrCodeInvalidTransfer
+	ErrCodeInvalidAnchor       = apperrors.ErrCodeInvalidAnchor
+
+	ErrCodeTransactionFailed  = apperrors.ErrCodeTransactionFailed
+	ErrCodeTransactionTimeout = apperrors.ErrCodeTransactionTimeout
+	ErrCodeEndorsementFailed  = apperrors.ErrCodeEndorsementFailed
+	ErrCodeCommitFailed       = apperrors.ErrCodeCommitFailed
+	ErrCodeRequestCanceled    = apperrors.ErrCodeRequestCanceled
+
+	ErrCodeUnauthorized       = apperrors.ErrCodeUnauthorized
+	ErrCodePermissionDenied   = apperrors.ErrCodePermissionDenied
+	ErrCodeWriteAccessDenied  = apperrors.ErrCodeWriteAccessDenied
+	ErrCodeInvalidCredentials = apperrors.ErrCodeInvalidCredentials
+	ErrCodeCertificateError   = apperrors.ErrCodeCertificateError
+
+	ErrCodeMarshalingFailed   = apperrors.ErrCodeMarshalingFailed
+	ErrCodeUnmarshalingFailed = apperrors.ErrCodeUnmarshalingFailed
+	ErrCodeDataCorruption     = apperrors.ErrCodeDataCorruption
+
+	ErrCodeTransferInitFailed   = apperrors.ErrCodeTransferInitFailed
+	ErrCodeTransferAckFailed    = apperrors.ErrCodeTransferAckFailed
+	ErrCodeLinkUpdateFailed     = apperrors.ErrCodeLinkUpdateFailed
+	ErrCodeAnchorVerifyFailed   = apperrors.ErrCodeAnchorVerifyFailed
+	ErrCodeSourceDocNotFound    = apperrors.ErrCodeSourceDocNotFound
+	ErrCodeLinkedDocUnavailable = apperrors.ErrCodeLinkedDocUnavailable
+
+	ErrCodeQueryFailed     = apperrors.ErrCodeQueryFailed
+	ErrCodeQueryTimeout    = apperrors.ErrCodeQueryTimeout
+	ErrCodeInvalidQuery    = apperrors.ErrCodeInvalidQuery
+	ErrCodeQueryTooComplex = apperrors.ErrCodeQueryTooComplex
+
+	ErrCodeIdempotencyInProgress = apperrors.ErrCodeIdempotencyInProgress
+	ErrCodeIdempotencyMismatch   = apperrors.ErrCodeIdempotencyMismatch
+
+	ErrCodeRateLimited = apperrors.ErrCodeRateLimited
+
+	ErrCodeInternalError  = apperrors.ErrCodeInternalError
+	ErrCodeConfigError    = apperrors.ErrCodeConfigError
+	ErrCodeServiceFailure = apperrors.ErrCodeServiceFailure
+)
+
+// Error is an error response of the API.
+type Error struct {
+	StatusCode int
+	Code       ErrorCode
+	Message    string
+	Details    string
+	// Retriable requests may succeed when repeated unchanged.
+	Retriable bool
+	Context   map[string]interface{}
+	// RequestID identifies the request in the server logs.
+	RequestID string
+	// RetryAfter is the wait the server asked for, zero when it did not.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Code, e.Message)
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	if e.RequestID != "" {
+		msg += " [request " + e.RequestID + "]"
+	}
+	return msg
+}
+
+// ErrorCodeOf returns the code of an API error, or "" when err is not one.
+func ErrorCodeOf(err error) ErrorCode {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsRetriable reports whether repeating the request may succeed: API errors
+// the server marked retriable, and transport errors, after which the request
+// may never have reached the server. Canceled and expired contexts are not
+// retriable.
+func IsRetriable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Retriable
+	}
+	return true
+}
+
+// unexpectedError describes a response that is not an API error envelope,
+// such as a proxy's error page. Gateway failures and unavailability are
+// retriable, as the API's own are.
+func unexpectedError(resp *http.Response) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Code:       ErrCodeInternalError,
+		Message:    "Unexpected response: " + resp.Status,
+		RequestID:  resp.Header.Get(requestIDHeader),
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		apiErr.Code = ErrCodeServiceFailure
+		apiErr.Retriable = true
+	case http.StatusTooManyRequests:
+		apiErr.Code = ErrCodeRateLimited
+		apiErr.Retriable = true
+	}
+	return apiErr
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ContentHash computes a document's ContentHash the way the chaincode does
+// on creation: the hex SHA-256 of the JSON encoding of its data. Decode the
+// document with encoding/json defaults (numbers as float64) so the data
+// encodes to the same bytes.
+func ContentHash(data map[string]interface{}) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
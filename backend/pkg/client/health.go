@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Health reports whether the API is running.
+func (c *Client) Health(ctx context.Context) (map[string]string, error) {
+	return c.probe(ctx, "/health")
+}
+
+// Liveness reports that the process serves HTTP, without contacting Fabric.
+func (c *Client) Liveness(ctx context.Context) (map[string]string, error) {
+	return c.probe(ctx, "/health/live")
+}
+
+func (c *Client) probe(ctx context.Context, path string) (map[string]string, error) {
+	var result map[string]string
+	r := &request{method: http.MethodGet, path: path, out: &result, raw: true}
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Readiness probes every configured channel. A not-ready instance answers
+// 503 with the same report, which is returned without an error: check
+// Status.
+func (c *Client) Readiness(ctx context.Context) (*ReadinessResponse, error) {
+	var result ReadinessResponse
+	r := &request{
+		method:   http.MethodGet,
+		path:     "/health/ready",
+		out:      &result,
+		raw:      true,
+		okStatus: http.StatusServiceUnavailable,
+	}
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Config gets the channels of the instance and which of them it can write
+// to.
+func (c *Client) Config(ctx context.Context) (*ConfigInfo, error) {
+	var result ConfigInfo
+	r := &request{method: http.MethodGet, path: "/config", out: &result, raw: true}
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
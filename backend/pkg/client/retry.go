@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how often and how long failed requests are retried.
+// Backoff doubles from InitialBackoff up to MaxBackoff, with jitter, unless
+// the server asked for a longer wait with Retry-After.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy makes up to four attempts over about three seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+// noRetry is the policy of clients created without WithRetry.
+var noRetry = RetryPolicy{MaxAttempts: 1}
+
+// Retry calls fn until it succeeds, fails with an error IsRetriable rejects,
+// or policy.MaxAttempts calls were made, and returns fn's last error. Only
+// retry writes that carry an idempotency key, or a failed attempt that was
+// in fact applied may be applied again.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil || !IsRetriable(err) || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff is the wait after the given failed attempt.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	wait := p.InitialBackoff << (attempt - 1)
+	if wait <= 0 || (p.MaxBackoff > 0 && wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+	if wait > 0 {
+		// Full jitter over the upper half spreads retries of many clients.
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > wait {
+		wait = apiErr.RetryAfter
+	}
+	return wait
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// GetTransactionStatus gets the commit status of a transaction.
+func (c *Client) GetTransactionStatus(ctx context.Context, channel, txID string) (*TransactionStatus, error) {
+	var status TransactionStatus
+	r := newRequest(http.MethodGet, channel, "transactions", txID)
+	r.out = &status
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// WaitForTransaction polls the status of a transaction submitted with an
+// Async method every interval until it is committed or ctx is done. A
+// transaction the peers rejected, such as on an MVCC read conflict, returns
+// its status and an *Error with ErrCodeCommitFailed.
+func (c *Client) WaitForTransaction(ctx context.Context, submitted *SubmittedTransaction, interval time.Duration) (*TransactionStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.GetTransactionStatus(ctx, submitted.Channel, submitted.TxID)
+		if err != nil && ErrorCodeOf(err) != ErrCodeNotFound {
+			return nil, err
+		}
+		if err == nil && status.Committed {
+			if !status.Valid {
+				return status, &Error{
+					Code:    ErrCodeCommitFailed,
+					Message: "Transaction " + status.TxID + " was committed as " + status.Status,
+				}
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
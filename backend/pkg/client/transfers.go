@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// InitiateTransfer starts a cross-channel transfer by creating its source
+// document, and waits for the commit.
+func (c *Client) InitiateTransfer(ctx context.Context, req *InitiateTransferRequest) (*TransferResult, error) {
+	r, err := newRequest(http.MethodPost, "transfers", "initiate").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	var result TransferResult
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InitiateTransferAsync initiates a transfer and returns once the
+// transaction is submitted.
+func (c *Client) InitiateTransferAsync(ctx context.Context, req *InitiateTransferRequest) (*SubmittedTransaction, error) {
+	r, err := newRequest(http.MethodPost, "transfers", "initiate").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.submit(ctx, r.withAsync())
+}
+
+// AcknowledgeTransfer acknowledges a received transfer by creating a
+// document on channel linked to the transfer's source document.
+func (c *Client) AcknowledgeTransfer(ctx context.Context, channel string, req *AcknowledgeTransferRequest) (*TransferResult, error) {
+	r, err := newRequest(http.MethodPost, channel, "transfers", "acknowledge").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	var result TransferResult
+	r.out = &result
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// VerifyAnchor verifies that two documents are linked across channels by
+// matching hashes. It has no side effects, so it is retried like a read.
+func (c *Client) VerifyAnchor(ctx context.Context, req *VerifyAnchorRequest) (*AnchorVerification, error) {
+	r, err := newRequest(http.MethodPost, "anchors", "verify").withJSON(req)
+	if err != nil {
+		return nil, err
+	}
+	var result AnchorVerification
+	r.out = &result
+	r.readOnly = true
+	if err := c.do(ctx, r); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"github.com/gov-spending/backend/internal/models"
+)
+
+// The API types are aliases of the server's models, so the SDK cannot drift
+// from the contract checked against docs/swagger.json.
+type (
+	DocumentType              = models.DocumentType
+	CreateDocumentTypeRequest = models.CreateDocumentTypeRequest
+	CouchDBIndex              = models.CouchDBIndex
+	CouchDBIndexFields        = models.CouchDBIndexFields
+
+	Document                  = models.Document
+	DocumentStatus            = models.DocumentStatus
+	CreateDocumentRequest     = models.CreateDocumentRequest
+	InvalidateDocumentRequest = models.InvalidateDocumentRequest
+	QueryFilter               = models.QueryFilter
+	FieldCondition            = models.FieldCondition
+	HistoryEntry              = models.HistoryEntry
+	LinkedDocuments           = models.LinkedDocuments
+
+	InitiateTransferRequest    = models.InitiateTransferRequest
+	AcknowledgeTransferRequest = models.AcknowledgeTransferRequest
+	TransferResult             = models.TransferResult
+	VerifyAnchorRequest        = models.VerifyAnchorRequest
+	AnchorVerification         = models.AnchorVerification
+
+	ImportFormat    = models.ImportFormat
+	ImportMapping   = models.ImportMapping
+	ImportJob       = models.ImportJob
+	ImportStatus    = models.ImportStatus
+	ImportRowStatus = models.ImportRowStatus
+	ImportRowResult = models.ImportRowResult
+
+	ExportFormat   = models.ExportFormat
+	ExportManifest = models.ExportManifest
+
+	SubmittedTransaction = models.SubmittedTransaction
+	TransactionStatus    = models.TransactionStatus
+
+	ReadinessResponse     = models.ReadinessResponse
+	ChannelHealth         = models.ChannelHealth
+	CertificateDiagnostic = models.CertificateDiagnostic
+
+	ResourceID   = models.ResourceID
+	ActionResult = models.ActionResult
+	Pagination   = models.Pagination
+)
+
+const (
+	StatusActive      = models.StatusActive
+	StatusInvalidated = models.StatusInvalidated
+
+	ImportFormatCSV    = models.ImportFormatCSV
+	ImportFormatNDJSON = models.ImportFormatNDJSON
+
+	ExportFormatCSV     = models.ExportFormatCSV
+	ExportFormatNDJSON  = models.ExportFormatNDJSON
+	ExportFormatParquet = models.ExportFormatParquet
+
+	TxStatusSubmitted = models.TxStatusSubmitted
+	TxStatusPending   = models.TxStatusPending
+	TxStatusValid     = models.TxStatusValid
+)
+
+// DocumentPage is one page of a document query.
+type DocumentPage struct {
+	Documents  []*Document
+	Pagination Pagination
+}
+
+// ImportOptions are the form fields of an import. Format is inferred from
+// the file name when empty; ImportKey defaults to a hash of the mapping.
+type ImportOptions struct {
+	DocumentTypeID string
+	Format         ImportFormat
+	Mapping        ImportMapping
+	ImportKey      string
+	Concurrency    int
+	DryRun         bool
+}
+
+// ConfigInfo is the channel configuration reported by GET /config.
+type ConfigInfo struct {
+	WritableChannels []string                 `json:"writableChannels"`
+	AllChannels      []string                 `json:"allChannels"`
+	ChannelDetails   map[string]ChannelDetail `json:"channelDetails"`
+}
+
+// ChannelDetail describes one channel of ConfigInfo.
+type ChannelDetail struct {
+	UserName    string `json:"userName"`
+	IsAdmin     bool   `json:"isAdmin"`
+	ChannelName string `json:"channelName"`
+}
@@ -0,0 +1,470 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+	"github.com/gov-spending/backend/internal/metrics"
+	"github.com/gov-spending/backend/internal/tracing"
+)
+
+// TransientRequestID is the transient data key carrying the API request ID
+// on submitted proposals. The chaincode echoes it in its events, which ties a
+// transaction to the request that submitted it.
+const TransientRequestID = "request_id"
+
+// Contract wraps the channel's chaincode with its retry policies. A call keeps
+// the connection it started on, even across a configuration reload, unless the
+// connection is evicted; a retry after a peer failure then goes to the peer the
+// manager failed over to. Evaluations are retried freely. Submissions are only
+// re-sent when doing so cannot apply the transaction twice.
+type Contract struct {
+	gm         *GatewayManager
+	channelKey string
+	channel    string
+}
+
+func newContract(gm *GatewayManager, channelKey, channelName string) *Contract {
+	return &Contract{
+		gm:         gm,
+		channelKey: channelKey,
+		channel:    channelName,
+	}
+}
+
+// startSpan starts the span covering a call and all of its retries. The gRPC
+// calls to peers are traced as its children.
+func (c *Contract) startSpan(ctx context.Context, kind, name string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "fabric."+kind+" "+name,
+		attribute.String("fabric.channel", c.channel),
+		attribute.String("fabric.channel_key", c.channelKey),
+		attribute.String("fabric.function", name),
+	)
+}
+
+// observe records the latency and outcome of a call, retries included, and
+// ends its span.
+func (c *Contract) observe(span trace.Span, kind, name string, start time.Time, err error) {
+	var code string
+	if err != nil {
+		code = string(apperrors.ParseBlockchainError(err, name).Code)
+		span.SetAttributes(attribute.String("error.code", code))
+	}
+	metrics.ObserveFabricCall(kind, c.channelKey, name, time.Since(start), code)
+	tracing.End(span, err)
+}
+
+func (c *Contract) EvaluateTransaction(ctx context.Context, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	ctx, span := c.startSpan(ctx, "evaluate", name)
+	result, err := c.evaluateTransaction(ctx, name, args)
+	c.observe(span, "evaluate", name, start, err)
+	return result, err
+}
+
+func (c *Contract) evaluateTransaction(ctx context.Context, name string, args []string) ([]byte, error) {
+	settings := c.gm.current()
+	r := newRetrier(ctx, "evaluate", c.channel, name, settings.retry.evaluate(name))
+	timeouts := settings.timeouts.Resolve(c.channelKey, name)
+
+	var conn *ChannelConnection
+	defer func() { conn.release() }()
+	for {
+		var err error
+		conn, err = c.gm.hold(ctx, c.channelKey, conn)
+		if err != nil {
+			return nil, r.failed(err)
+		}
+
+		result, err := evaluate(ctx, timeouts.Evaluate, conn.Contract, name, client.WithArguments(args...))
+		if err == nil {
+			r.succeeded()
+			return result, nil
+		}
+		if isRetriableStatus(err) {
+			c.gm.reportUnavailable(c.channelKey, conn, err)
+		}
+		if !isRetriableStatus(err) || !r.wait(err) {
+			return nil, r.failed(err)
+		}
+	}
+}
+
+// prepare returns the transaction to send next and the connection to send it
+// on. A new proposal is endorsed when txn is nil. Otherwise txn is resent as is,
+// recreated from its signed bytes if the connection it was bound to has been
+// evicted since. held is the connection the call holds, which prepare
+// releases or keeps; on error the call holds nothing.
+func (c *Contract) prepare(ctx context.Context, timeouts Timeouts, name string, args []string, txn *client.Transaction, held *ChannelConnection) (*client.Transaction, *ChannelConnection, error) {
+	conn, err := c.gm.hold(ctx, c.channelKey, held)
+	if err != nil {
+		return nil, nil, err
+	}
+	if txn == nil {
+		txn, err = c.endorse(ctx, conn, timeouts, name, args)
+	} else if conn != held {
+		var data []byte
+		if data, err = txn.Bytes(); err == nil {
+			txn, err = conn.Gateway.NewTransaction(data)
+		}
+	}
+	if err != nil {
+		conn.release()
+		return nil, nil, err
+	}
+	return txn, conn, nil
+}
+
+// SubmitTransaction endorses, orders and waits for the commit of a transaction.
+//
+// Failures before ordering (endorsement) are retried with a new proposal.
+// When the outcome of ordering is unknown, the ledger is checked by transaction
+// ID first; if the transaction is not there, the same signed envelope is sent
+// again, which peers would reject as DUPLICATE_TXID rather than apply twice.
+// MVCC and phantom read conflicts are retried with a fresh endorsement.
+func (c *Contract) SubmitTransaction(ctx context.Context, name string, args ...string) ([]byte, error) {
+	start := time.Now()
+	ctx, span := c.startSpan(ctx, "submit", name)
+	result, err := c.submitTransaction(ctx, name, args)
+	c.observe(span, "submit", name, start, err)
+	return result, err
+}
+
+func (c *Contract) submitTransaction(ctx context.Context, name string, args []string) ([]byte, error) {
+	settings := c.gm.current()
+	r := newRetrier(ctx, "submit", c.channel, name, settings.retry.submit(name))
+	timeouts := settings.timeouts.Resolve(c.channelKey, name)
+
+	var txn *client.Transaction
+	var conn *ChannelConnection
+	defer func() { conn.release() }()
+	for {
+		var err error
+		txn, conn, err = c.prepare(ctx, timeouts, name, args, txn, conn)
+		if err != nil {
+			if isRetriableStatus(err) && r.wait(err) {
+				txn = nil
+				continue
+			}
+			return nil, r.failed(err)
+		}
+
+		err = c.submit(ctx, timeouts, txn)
+		if err == nil {
+			r.succeeded()
+			return txn.Result(), nil
+		}
+
+		var commitErr *CommitError
+		switch {
+		case errors.As(err, &commitErr):
+			if !isRetriableValidation(commitErr.Code) {
+				return nil, r.failed(err)
+			}
+			txn = nil
+
+		case isRetriableStatus(err):
+			c.gm.reportUnavailable(c.channelKey, conn, err)
+			code, found := c.lookupTransaction(ctx, txn.TransactionID())
+			if found {
+				if code == peer.TxValidationCode_VALID {
+					r.succeeded()
+					return txn.Result(), nil
+				}
+				err = &CommitError{TransactionID: txn.TransactionID(), Code: code}
+				if !isRetriableValidation(code) {
+					return nil, r.failed(err)
+				}
+				txn = nil
+			}
+
+		default:
+			return nil, r.failed(err)
+		}
+
+		if !r.wait(err) {
+			return nil, r.failed(err)
+		}
+	}
+}
+
+// SubmitAsync endorses a transaction and sends it to the orderer without
+// waiting for the commit. Endorsement and ordering are retried under the same
+// rules as SubmitTransaction.
+func (c *Contract) SubmitAsync(ctx context.Context, name string, args ...string) (*PendingTransaction, error) {
+	start := time.Now()
+	ctx, span := c.startSpan(ctx, "submit_async", name)
+	pending, err := c.submitAsync(ctx, name, args)
+	c.observe(span, "submit_async", name, start, err)
+	return pending, err
+}
+
+func (c *Contract) submitAsync(ctx context.Context, name string, args []string) (*PendingTransaction, error) {
+	settings := c.gm.current()
+	r := newRetrier(ctx, "submit_async", c.channel, name, settings.retry.submit(name))
+	timeouts := settings.timeouts.Resolve(c.channelKey, name)
+
+	var txn *client.Transaction
+	var conn *ChannelConnection
+	defer func() { conn.release() }()
+	for {
+		var err error
+		txn, conn, err = c.prepare(ctx, timeouts, name, args, txn, conn)
+		if err != nil {
+			if isRetriableStatus(err) && r.wait(err) {
+				txn = nil
+				continue
+			}
+			return nil, r.failed(err)
+		}
+
+		submitCtx, cancel := context.WithTimeout(ctx, timeouts.Submit)
+		commit, err := txn.SubmitWithContext(submitCtx)
+		cancel()
+		if err == nil {
+			r.succeeded()
+			// The pending transaction keeps the lease until its commit is seen.
+			pending := &PendingTransaction{
+				TransactionID: txn.TransactionID(),
+				Result:        txn.Result(),
+				commit:        commit,
+				commitTimeout: timeouts.CommitStatus,
+				conn:          conn,
+			}
+			conn = nil
+			return pending, nil
+		}
+		if !isRetriableStatus(err) {
+			return nil, r.failed(err)
+		}
+		c.gm.reportUnavailable(c.channelKey, conn, err)
+
+		if code, found := c.lookupTransaction(ctx, txn.TransactionID()); found {
+			r.succeeded()
+			return &PendingTransaction{
+				TransactionID: txn.TransactionID(),
+				Result:        txn.Result(),
+				known:         &CommitStatus{TransactionID: txn.TransactionID(), Code: code},
+			}, nil
+		}
+
+		if !r.wait(err) {
+			return nil, r.failed(err)
+		}
+	}
+}
+
+// CommitStatus looks up a transaction on the ledger. found is false when the
+// channel has no transaction with that ID (yet).
+func (c *Contract) CommitStatus(ctx context.Context, txID string) (*CommitStatus, bool, error) {
+	ctx, span := tracing.Start(ctx, "fabric.commit_status",
+		attribute.String("fabric.channel", c.channel),
+		attribute.String("fabric.tx_id", txID),
+	)
+	defer span.End()
+
+	conn, err := c.gm.hold(ctx, c.channelKey, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.release()
+	qscc := conn.Network.GetContract("qscc")
+	timeout := c.gm.current().timeouts.Channel(c.channelKey).Evaluate
+
+	data, err := evaluate(ctx, timeout, qscc, "GetTransactionByID", client.WithArguments(c.channel, txID))
+	if err != nil {
+		if transactionNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var processed peer.ProcessedTransaction
+	if err := proto.Unmarshal(data, &processed); err != nil {
+		return nil, false, fmt.Errorf("failed to decode transaction %s: %w", txID, err)
+	}
+
+	st := &CommitStatus{
+		TransactionID: txID,
+		Code:          peer.TxValidationCode(processed.GetValidationCode()),
+	}
+
+	blockData, err := evaluate(ctx, timeout, qscc, "GetBlockByTxID", client.WithArguments(c.channel, txID))
+	if err == nil {
+		var block common.Block
+		if err := proto.Unmarshal(blockData, &block); err == nil && block.GetHeader() != nil {
+			number := block.GetHeader().GetNumber()
+			st.BlockNumber = &number
+		}
+	}
+
+	return st, true, nil
+}
+
+func (c *Contract) endorse(ctx context.Context, conn *ChannelConnection, timeouts Timeouts, name string, args []string) (*client.Transaction, error) {
+	opts := []client.ProposalOption{client.WithArguments(args...)}
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		opts = append(opts, client.WithTransient(map[string][]byte{TransientRequestID: []byte(requestID)}))
+	}
+	proposal, err := conn.Contract.NewProposal(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	endorseCtx, cancel := context.WithTimeout(ctx, timeouts.Endorse)
+	defer cancel()
+	txn, err := proposal.EndorseWithContext(endorseCtx)
+	if err != nil {
+		if isRetriableStatus(err) {
+			c.gm.reportUnavailable(c.channelKey, conn, err)
+		}
+		return nil, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("fabric.tx_id", txn.TransactionID()))
+	logging.Ctx(ctx).Info().
+		Str("txId", txn.TransactionID()).
+		Str("transaction", name).
+		Str("channel", c.channelKey).
+		Msg("Transaction endorsed")
+	return txn, nil
+}
+
+// submit sends an endorsed transaction to the orderer and waits for its commit.
+func (c *Contract) submit(ctx context.Context, timeouts Timeouts, txn *client.Transaction) error {
+	submitCtx, cancel := context.WithTimeout(ctx, timeouts.Submit)
+	defer cancel()
+	commit, err := txn.SubmitWithContext(submitCtx)
+	if err != nil {
+		return err
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, timeouts.CommitStatus)
+	defer cancel()
+	st, err := commit.StatusWithContext(statusCtx)
+	if err != nil {
+		return err
+	}
+	if st.Successful {
+		return nil
+	}
+	if st.Code == peer.TxValidationCode_DUPLICATE_TXID {
+		// A resent envelope: the first copy decides the outcome.
+		if code, found := c.lookupTransaction(ctx, txn.TransactionID()); found {
+			if code == peer.TxValidationCode_VALID {
+				return nil
+			}
+			return &CommitError{TransactionID: txn.TransactionID(), Code: code}
+		}
+	}
+	return &CommitError{TransactionID: txn.TransactionID(), Code: st.Code}
+}
+
+// lookupTransaction reads a transaction's validation code from the ledger.
+// found is false when the transaction is not (yet) committed or the lookup
+// itself failed.
+func (c *Contract) lookupTransaction(ctx context.Context, txID string) (peer.TxValidationCode, bool) {
+	conn, err := c.gm.hold(ctx, c.channelKey, nil)
+	if err != nil {
+		return 0, false
+	}
+	defer conn.release()
+
+	timeout := c.gm.current().timeouts.Channel(c.channelKey).Evaluate
+	data, err := evaluate(ctx, timeout, conn.Network.GetContract("qscc"), "GetTransactionByID", client.WithArguments(c.channel, txID))
+	if err != nil {
+		return 0, false
+	}
+
+	var processed peer.ProcessedTransaction
+	if err := proto.Unmarshal(data, &processed); err != nil {
+		return 0, false
+	}
+	return peer.TxValidationCode(processed.GetValidationCode()), true
+}
+
+// transactionNotFound reports whether a qscc lookup failed because the peer
+// has no transaction with the ID in its block index, rather than because the
+// lookup itself failed.
+func transactionNotFound(err error) bool {
+	messages := []string{err.Error()}
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			if d, ok := detail.(*gateway.ErrorDetail); ok {
+				messages = append(messages, d.GetMessage())
+			}
+		}
+	}
+	for _, msg := range messages {
+		if strings.Contains(msg, "no such transaction ID") {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate runs a query on a gateway contract within the given timeout.
+func evaluate(ctx context.Context, timeout time.Duration, contract *client.Contract, name string, opts ...client.ProposalOption) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return contract.EvaluateWithContext(ctx, name, opts...)
+}
+
+// CommitStatus is the validation outcome of a committed transaction.
+type CommitStatus struct {
+	TransactionID string
+	Code          peer.TxValidationCode
+	BlockNumber   *uint64
+}
+
+func (st *CommitStatus) Valid() bool {
+	return st.Code == peer.TxValidationCode_VALID
+}
+
+// PendingTransaction is a transaction accepted by the orderer whose commit
+// has not been observed yet.
+type PendingTransaction struct {
+	TransactionID string
+	Result        []byte
+	commit        *client.Commit
+	commitTimeout time.Duration
+	known         *CommitStatus
+	conn          *ChannelConnection
+}
+
+// Wait blocks until the transaction is committed, or the commit status
+// timeout of its operation expires, and returns its validation outcome. It
+// must be called once, as it releases the connection the transaction was
+// submitted on.
+func (p *PendingTransaction) Wait() (*CommitStatus, error) {
+	if p.known != nil {
+		return p.known, nil
+	}
+	defer p.conn.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.commitTimeout)
+	defer cancel()
+	st, err := p.commit.StatusWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	number := st.BlockNumber
+	return &CommitStatus{
+		TransactionID: st.TransactionID,
+		Code:          st.Code,
+		BlockNumber:   &number,
+	}, nil
+}
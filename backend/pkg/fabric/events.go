@@ -0,0 +1,25 @@
+package fabric
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// ChaincodeEvents streams the events the chaincode emits on a channel, from
+// startBlock when given and from the next block otherwise. The stream does not
+// hold a lease on the connection, so it never delays a drain: the channel is
+// closed when ctx is done or when the connection fails or is closed after a
+// reload, and the caller resumes from the block after the last event it got.
+func (gm *GatewayManager) ChaincodeEvents(ctx context.Context, channelKey string, startBlock *uint64) (<-chan *client.ChaincodeEvent, error) {
+	conn, err := gm.GetConnection(channelKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []client.ChaincodeEventsOption
+	if startBlock != nil {
+		opts = append(opts, client.WithStartBlock(*startBlock))
+	}
+	return conn.Network.ChaincodeEvents(ctx, gm.current().config.Fabric.ChaincodeName, opts...)
+}
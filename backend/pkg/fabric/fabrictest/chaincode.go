@@ -0,0 +1,194 @@
+package fabrictest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// registrationTimeout bounds how long a started chaincode takes to connect.
+const registrationTimeout = 30 * time.Second
+
+// chaincodeDir is the source of the spending contract the network runs.
+func chaincodeDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "gov-ledger", "chaincode", "spending")
+}
+
+// chaincode is the running spending contract, connected to the peer's
+// chaincode support service as a chaincode connects to a real peer.
+// Transactions run one at a time: each holds the stream until the contract
+// completes it.
+type chaincode struct {
+	mu     sync.Mutex
+	stream peer.ChaincodeSupport_RegisterServer
+	// stopped is set once the contract's process is killed.
+	stopped bool
+}
+
+// StartChaincode builds the spending contract from gov-ledger/chaincode/spending
+// and starts it. The peer starts it on the first invocation otherwise, as a
+// Fabric peer launches a chaincode; tests call it first so that building the
+// contract does not count against the backend's timeouts.
+func (n *Network) StartChaincode() error {
+	n.launch.Do(func() {
+		n.launchErr = n.startChaincode()
+	})
+	return n.launchErr
+}
+
+func (n *Network) startChaincode() error {
+	bin := filepath.Join(n.dir, "chaincode", n.chaincode)
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = chaincodeDir()
+	if out, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("build chaincode: %w\n%s", err, out)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(bin, "-peer.address="+n.Endpoint())
+	cmd.Env = append(os.Environ(),
+		"CORE_CHAINCODE_ID_NAME="+n.chaincode+":1.0",
+		"CORE_PEER_TLS_ENABLED=false",
+	)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start chaincode: %w", err)
+	}
+	n.process = cmd
+	n.exited = make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(n.exited)
+	}()
+
+	select {
+	case cc := <-n.support.registered:
+		n.cc = cc
+		return nil
+	case <-n.exited:
+		return fmt.Errorf("chaincode exited before registering: %s", output.String())
+	case <-time.After(registrationTimeout):
+		return errors.New("chaincode did not register")
+	}
+}
+
+// StopChaincode kills the spending contract, as when its container dies: the
+// peer keeps serving, but invocations of the contract fail from then on.
+func (n *Network) StopChaincode() {
+	n.stopChaincode()
+}
+
+// stopChaincode kills the contract's process, if it was started, and keeps
+// it from starting later.
+func (n *Network) stopChaincode() {
+	n.launch.Do(func() {
+		n.launchErr = errors.New("chaincode stopped")
+	})
+	if n.process == nil {
+		return
+	}
+	if n.cc != nil {
+		n.cc.mu.Lock()
+		n.cc.stopped = true
+		n.cc.mu.Unlock()
+	}
+	n.process.Process.Kill()
+	<-n.exited
+}
+
+// chaincodeSupport is the peer's ChaincodeSupport service, which chaincodes
+// connect to and register on.
+type chaincodeSupport struct {
+	peer.UnimplementedChaincodeSupportServer
+	registered chan *chaincode
+}
+
+func (s *chaincodeSupport) Register(stream peer.ChaincodeSupport_RegisterServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if msg.GetType() != peer.ChaincodeMessage_REGISTER {
+		return fmt.Errorf("chaincode sent %s before registering", msg.GetType())
+	}
+	for _, reply := range []peer.ChaincodeMessage_Type{peer.ChaincodeMessage_REGISTERED, peer.ChaincodeMessage_READY} {
+		if err := stream.Send(&peer.ChaincodeMessage{Type: reply}); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case s.registered <- &chaincode{stream: stream}:
+	default:
+		return errors.New("a chaincode is already registered")
+	}
+	// The stream is the chaincode's for as long as it runs.
+	<-stream.Context().Done()
+	return nil
+}
+
+// execute runs a transaction of the contract against a simulation, answering
+// the contract's state requests until it completes. It returns the
+// transaction's result, or its error message when the contract fails it.
+func (c *chaincode) execute(sim *simulation) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return nil, errors.New("chaincode is not running")
+	}
+
+	input, err := proto.Marshal(sim.inv.input)
+	if err != nil {
+		return nil, err
+	}
+	err = c.stream.Send(&peer.ChaincodeMessage{
+		Type:      peer.ChaincodeMessage_TRANSACTION,
+		Txid:      sim.inv.txID,
+		ChannelId: sim.inv.channel,
+		Payload:   input,
+		Proposal:  sim.inv.proposal,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("send transaction to chaincode: %w", err)
+	}
+
+	for {
+		msg, err := c.stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("chaincode stream: %w", err)
+		}
+		switch msg.GetType() {
+		case peer.ChaincodeMessage_COMPLETED:
+			var response peer.Response
+			if err := proto.Unmarshal(msg.GetPayload(), &response); err != nil {
+				return nil, fmt.Errorf("invalid chaincode response: %w", err)
+			}
+			if response.GetStatus() >= 400 {
+				return nil, errors.New(response.GetMessage())
+			}
+			sim.event = msg.GetChaincodeEvent()
+			return response.GetPayload(), nil
+		case peer.ChaincodeMessage_ERROR:
+			return nil, errors.New(string(msg.GetPayload()))
+		}
+
+		reply := &peer.ChaincodeMessage{Type: peer.ChaincodeMessage_RESPONSE, Txid: msg.GetTxid(), ChannelId: msg.GetChannelId()}
+		if reply.Payload, err = sim.handle(msg); err != nil {
+			reply.Type, reply.Payload = peer.ChaincodeMessage_ERROR, []byte(err.Error())
+		}
+		if err := c.stream.Send(reply); err != nil {
+			return nil, fmt.Errorf("answer chaincode: %w", err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package fabrictest
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Gateway methods a fault can be injected into.
+const (
+	MethodEvaluate     = "Evaluate"
+	MethodEndorse      = "Endorse"
+	MethodSubmit       = "Submit"
+	MethodCommitStatus = "CommitStatus"
+)
+
+// Fault is injected into the gateway calls it matches, to reproduce a slow
+// or failing peer.
+type Fault struct {
+	// Method is the gateway method, one of the Method constants.
+	Method string
+	// Function restricts the fault to invocations of one transaction
+	// function, e.g. "QueryDocuments"; empty matches every call.
+	Function string
+	// Delay holds the call before it is served.
+	Delay time.Duration
+	// Status fails the call with a gRPC error of this code, e.g.
+	// codes.Unavailable; codes.OK lets it succeed.
+	Status codes.Code
+	// AfterServing fails the call only once it was served, as when the
+	// peer's reply is lost: a Submit failed this way was still committed.
+	AfterServing bool
+	// Validation, on Submit, commits the transaction with this code instead
+	// of validating it, as when a conflicting transaction was ordered first.
+	// On CommitStatus it is reported in place of the committed code, as for
+	// a resent envelope. VALID leaves both as they are.
+	Validation peer.TxValidationCode
+	// Times limits the fault to that many matching calls; zero applies it to
+	// every one.
+	Times int
+}
+
+func (f Fault) matches(method, function string) bool {
+	return f.Method == method && (f.Function == "" || f.Function == function)
+}
+
+// Call is a gateway call the network received, in the order received.
+type Call struct {
+	Method   string
+	Function string
+	TxID     string
+}
+
+// Inject applies fault to matching calls from now on.
+func (n *Network) Inject(fault Fault) {
+	n.faultsMu.Lock()
+	defer n.faultsMu.Unlock()
+	n.faults = append(n.faults, fault)
+}
+
+// Calls lists the gateway calls received so far.
+func (n *Network) Calls() []Call {
+	n.faultsMu.Lock()
+	defer n.faultsMu.Unlock()
+	return append([]Call(nil), n.calls...)
+}
+
+// injected is the effect of the faults that matched a call.
+type injected struct {
+	before, after error
+	validation    peer.TxValidationCode
+}
+
+// inject records a call and applies the faults that match it: it holds the
+// call for their delays and returns the failures to serve it with.
+func (n *Network) inject(call Call) injected {
+	n.faultsMu.Lock()
+	n.calls = append(n.calls, call)
+	var delay time.Duration
+	var effect injected
+	faults := n.faults[:0]
+	for _, f := range n.faults {
+		if !f.matches(call.Method, call.Function) {
+			faults = append(faults, f)
+			continue
+		}
+		delay += f.Delay
+		if f.Status != codes.OK {
+			err := status.Errorf(f.Status, "injected fault on %s", call.Method)
+			if f.AfterServing {
+				effect.after = err
+			} else {
+				effect.before = err
+			}
+		}
+		if f.Validation != peer.TxValidationCode_VALID {
+			effect.validation = f.Validation
+		}
+		if f.Times != 1 {
+			if f.Times > 1 {
+				f.Times--
+			}
+			faults = append(faults, f)
+		}
+	}
+	n.faults = faults
+	n.faultsMu.Unlock()
+	time.Sleep(delay)
+	return effect
+}
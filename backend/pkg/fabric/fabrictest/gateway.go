@@ -0,0 +1,641 @@
+package fabrictest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer/lifecycle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gatewayServer is the peer's Gateway service. Endorse simulates a
+// transaction and keeps its read/write set until Submit, which orders and
+// commits it in a block of its own.
+type gatewayServer struct {
+	gateway.UnimplementedGatewayServer
+	network *Network
+}
+
+func (s *gatewayServer) Evaluate(ctx context.Context, req *gateway.EvaluateRequest) (*gateway.EvaluateResponse, error) {
+	inv, l, err := s.invocation(req.GetProposedTransaction())
+	if err != nil {
+		return nil, err
+	}
+	fault := s.network.inject(Call{Method: MethodEvaluate, Function: inv.fn, TxID: inv.txID})
+	if fault.before != nil {
+		return nil, fault.before
+	}
+	payload, err := s.invoke(l, inv, false)
+	if err != nil {
+		return nil, s.peerError(l, codes.Unknown, "evaluate call to endorser returned error: ", err)
+	}
+	if fault.after != nil {
+		return nil, fault.after
+	}
+	return &gateway.EvaluateResponse{Result: &peer.Response{Status: 200, Payload: payload}}, nil
+}
+
+func (s *gatewayServer) Endorse(ctx context.Context, req *gateway.EndorseRequest) (*gateway.EndorseResponse, error) {
+	inv, l, err := s.invocation(req.GetProposedTransaction())
+	if err != nil {
+		return nil, err
+	}
+	fault := s.network.inject(Call{Method: MethodEndorse, Function: inv.fn, TxID: inv.txID})
+	if fault.before != nil {
+		return nil, fault.before
+	}
+	payload, err := s.invoke(l, inv, true)
+	if err != nil {
+		return nil, s.peerError(l, codes.Aborted, "failed to endorse transaction, see attached details for more info", err)
+	}
+	if fault.after != nil {
+		return nil, fault.after
+	}
+
+	envelope, err := preparedTransaction(inv.channel, payload)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &gateway.EndorseResponse{PreparedTransaction: envelope}, nil
+}
+
+func (s *gatewayServer) Submit(ctx context.Context, req *gateway.SubmitRequest) (*gateway.SubmitResponse, error) {
+	l, ok := s.network.ledger(req.GetChannelId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "channel %s not found", req.GetChannelId())
+	}
+	fault := s.network.inject(Call{Method: MethodSubmit, TxID: req.GetTransactionId()})
+	if fault.before != nil {
+		return nil, fault.before
+	}
+	if !l.commit(req.GetTransactionId(), fault.validation) {
+		return nil, status.Errorf(codes.FailedPrecondition, "transaction %s was not endorsed through this gateway", req.GetTransactionId())
+	}
+	if fault.after != nil {
+		return nil, fault.after
+	}
+	return &gateway.SubmitResponse{}, nil
+}
+
+func (s *gatewayServer) CommitStatus(ctx context.Context, req *gateway.SignedCommitStatusRequest) (*gateway.CommitStatusResponse, error) {
+	var statusReq gateway.CommitStatusRequest
+	if err := proto.Unmarshal(req.GetRequest(), &statusReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	l, ok := s.network.ledger(statusReq.GetChannelId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "channel %s not found", statusReq.GetChannelId())
+	}
+	fault := s.network.inject(Call{Method: MethodCommitStatus, TxID: statusReq.GetTransactionId()})
+	if fault.before != nil {
+		return nil, fault.before
+	}
+	tx, ok := l.transaction(statusReq.GetTransactionId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "transaction %s was not submitted", statusReq.GetTransactionId())
+	}
+	if fault.validation != peer.TxValidationCode_VALID {
+		tx.code = fault.validation
+	}
+	if fault.after != nil {
+		return nil, fault.after
+	}
+	return &gateway.CommitStatusResponse{Result: tx.code, BlockNumber: tx.block}, nil
+}
+
+// ChaincodeEvents streams the contract's events, a block at a time, from the
+// requested block or from the next block committed. Resuming after a
+// transaction within the start block is not supported.
+func (s *gatewayServer) ChaincodeEvents(req *gateway.SignedChaincodeEventsRequest, stream gateway.Gateway_ChaincodeEventsServer) error {
+	var eventsReq gateway.ChaincodeEventsRequest
+	if err := proto.Unmarshal(req.GetRequest(), &eventsReq); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	l, ok := s.network.ledger(eventsReq.GetChannelId())
+	if !ok {
+		return status.Errorf(codes.NotFound, "channel %s not found", eventsReq.GetChannelId())
+	}
+	if eventsReq.GetChaincodeId() != s.network.chaincode {
+		return status.Errorf(codes.NotFound, "chaincode %s not found", eventsReq.GetChaincodeId())
+	}
+
+	_, height, _ := l.eventsFrom(0)
+	next := height + 1
+	switch start := eventsReq.GetStartPosition().GetType().(type) {
+	case *orderer.SeekPosition_Specified:
+		next = start.Specified.GetNumber()
+	case *orderer.SeekPosition_Oldest:
+		next = 0
+	}
+
+	for {
+		events, height, committed := l.eventsFrom(next)
+		for len(events) > 0 {
+			block := events[0].block
+			response := &gateway.ChaincodeEventsResponse{BlockNumber: block}
+			for len(events) > 0 && events[0].block == block {
+				response.Events = append(response.Events, events[0].event)
+				events = events[1:]
+			}
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+		}
+		if height >= next {
+			next = height + 1
+		}
+		select {
+		case <-committed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// invoke runs a proposal against the channel's ledger: the spending contract,
+// or the qscc and _lifecycle queries. Only endorsements keep their writes.
+func (s *gatewayServer) invoke(l *ledger, inv *invocation, endorse bool) ([]byte, error) {
+	switch inv.chaincode {
+	case "qscc":
+		return l.querySystem(inv)
+	case "_lifecycle":
+		return s.queryLifecycle(inv)
+	case s.network.chaincode:
+		if err := s.network.StartChaincode(); err != nil {
+			return nil, err
+		}
+		return l.simulate(s.network.cc, inv, endorse)
+	default:
+		return nil, fmt.Errorf("chaincode %s not found", inv.chaincode)
+	}
+}
+
+func (s *gatewayServer) queryLifecycle(inv *invocation) ([]byte, error) {
+	if inv.fn != "QueryChaincodeDefinition" || len(inv.rawArgs) != 1 {
+		return nil, fmt.Errorf("invalid invocation of _lifecycle: %s", inv.fn)
+	}
+	var args lifecycle.QueryChaincodeDefinitionArgs
+	if err := proto.Unmarshal(inv.rawArgs[0], &args); err != nil {
+		return nil, err
+	}
+	if args.GetName() != s.network.chaincode {
+		return nil, fmt.Errorf("namespace %s is not defined", args.GetName())
+	}
+	return proto.Marshal(&lifecycle.QueryChaincodeDefinitionResult{Sequence: 1, Version: "1.0"})
+}
+
+// peerError is the gateway's error for a failed invocation: the peer's
+// message, with the chaincode's response, travels in an ErrorDetail.
+func (s *gatewayServer) peerError(l *ledger, code codes.Code, message string, err error) error {
+	detail := &gateway.ErrorDetail{
+		Address: s.network.Endpoint(),
+		MspId:   l.channel.MspID,
+		Message: "chaincode response 500, " + err.Error(),
+	}
+	if code == codes.Unknown {
+		message += detail.Message
+	}
+	st, _ := status.New(code, message).WithDetails(detail)
+	return st.Err()
+}
+
+// invocation is a decoded signed proposal.
+type invocation struct {
+	txID      string
+	channel   string
+	chaincode string
+	fn        string
+	args      []string
+	rawArgs   [][]byte
+	timestamp time.Time
+	// proposal and input go to the chaincode as they came: its stub reads
+	// the creator and transient data from the proposal.
+	proposal *peer.SignedProposal
+	input    *peer.ChaincodeInput
+}
+
+func (s *gatewayServer) invocation(signed *peer.SignedProposal) (*invocation, *ledger, error) {
+	inv, err := parseProposal(signed)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	l, ok := s.network.ledger(inv.channel)
+	if !ok {
+		return nil, nil, status.Errorf(codes.NotFound, "channel %s not found", inv.channel)
+	}
+	return inv, l, nil
+}
+
+func parseProposal(signed *peer.SignedProposal) (*invocation, error) {
+	var proposal peer.Proposal
+	if err := proto.Unmarshal(signed.GetProposalBytes(), &proposal); err != nil {
+		return nil, fmt.Errorf("invalid proposal: %w", err)
+	}
+	var header common.Header
+	if err := proto.Unmarshal(proposal.GetHeader(), &header); err != nil {
+		return nil, fmt.Errorf("invalid proposal header: %w", err)
+	}
+	var channelHeader common.ChannelHeader
+	if err := proto.Unmarshal(header.GetChannelHeader(), &channelHeader); err != nil {
+		return nil, fmt.Errorf("invalid channel header: %w", err)
+	}
+
+	var payload peer.ChaincodeProposalPayload
+	if err := proto.Unmarshal(proposal.GetPayload(), &payload); err != nil {
+		return nil, fmt.Errorf("invalid proposal payload: %w", err)
+	}
+	var spec peer.ChaincodeInvocationSpec
+	if err := proto.Unmarshal(payload.GetInput(), &spec); err != nil {
+		return nil, fmt.Errorf("invalid chaincode invocation: %w", err)
+	}
+	input := spec.GetChaincodeSpec().GetInput()
+	if len(input.GetArgs()) == 0 {
+		return nil, errors.New("no transaction function")
+	}
+
+	inv := &invocation{
+		txID:      channelHeader.GetTxId(),
+		channel:   channelHeader.GetChannelId(),
+		chaincode: spec.GetChaincodeSpec().GetChaincodeId().GetName(),
+		fn:        string(input.GetArgs()[0]),
+		rawArgs:   input.GetArgs()[1:],
+		timestamp: channelHeader.GetTimestamp().AsTime(),
+		proposal:  signed,
+		input:     input,
+	}
+	for _, arg := range inv.rawArgs {
+		inv.args = append(inv.args, string(arg))
+	}
+	return inv, nil
+}
+
+// preparedTransaction is the envelope Endorse returns, reduced to what the
+// gateway client reads from it: the channel and the contract's result.
+func preparedTransaction(channel string, result []byte) (*common.Envelope, error) {
+	action, err := proto.Marshal(&peer.ChaincodeAction{Response: &peer.Response{Status: 200, Payload: result}})
+	if err != nil {
+		return nil, err
+	}
+	responsePayload, err := proto.Marshal(&peer.ProposalResponsePayload{Extension: action})
+	if err != nil {
+		return nil, err
+	}
+	actionPayload, err := proto.Marshal(&peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{ProposalResponsePayload: responsePayload},
+	})
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: actionPayload}}})
+	if err != nil {
+		return nil, err
+	}
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{ChannelId: channel})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader},
+		Data:   transaction,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &common.Envelope{Payload: payload}, nil
+}
+
+// =============================================================================
+// Ledger
+// =============================================================================
+
+// ledger is a channel's world state, key history and committed transactions.
+type ledger struct {
+	channel Channel
+
+	mu      sync.Mutex
+	state   map[string]versionedValue
+	history map[string][]keyModification
+	txs     map[string]committedTx
+	// endorsed holds the simulations waiting to be submitted, by transaction.
+	endorsed map[string]*simulation
+	height   uint64
+	// events are the chaincode events of valid transactions, in block order.
+	events []blockEvent
+	// committed is closed, and replaced, when a block is committed.
+	committed chan struct{}
+}
+
+// blockEvent is a chaincode event and the block that committed it.
+type blockEvent struct {
+	block uint64
+	event *peer.ChaincodeEvent
+}
+
+// versionedValue is a key's value and the block that last wrote it.
+type versionedValue struct {
+	value   []byte
+	version uint64
+}
+
+type keyModification struct {
+	txID      string
+	timestamp time.Time
+	value     []byte
+}
+
+type committedTx struct {
+	code  peer.TxValidationCode
+	block uint64
+}
+
+func newLedger(ch Channel) *ledger {
+	return &ledger{
+		channel:   ch,
+		state:     make(map[string]versionedValue),
+		history:   make(map[string][]keyModification),
+		txs:       make(map[string]committedTx),
+		endorsed:  make(map[string]*simulation),
+		committed: make(chan struct{}),
+	}
+}
+
+// simulate runs a contract transaction against the committed state. When
+// endorse is set its read/write set is kept for commit.
+func (l *ledger) simulate(cc *chaincode, inv *invocation, endorse bool) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sim := &simulation{
+		ledger: l,
+		inv:    inv,
+		reads:  make(map[string]uint64),
+		writes: make(map[string][]byte),
+	}
+	result, err := cc.execute(sim)
+	if err != nil {
+		return nil, err
+	}
+	if endorse {
+		l.endorsed[inv.txID] = sim
+	}
+	return result, nil
+}
+
+// commit validates an endorsed transaction in a new block and applies its
+// writes when no key it read has changed since and no range it read has
+// gained or lost a key. It reports false when the transaction was not
+// endorsed; a transaction submitted again is left as first committed. A
+// code other than VALID invalidates the transaction with it regardless.
+func (l *ledger) commit(txID string, code peer.TxValidationCode) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, committed := l.txs[txID]; committed {
+		return true
+	}
+	sim, ok := l.endorsed[txID]
+	if !ok {
+		return false
+	}
+	delete(l.endorsed, txID)
+
+	l.height++
+	for key, version := range sim.reads {
+		if code == peer.TxValidationCode_VALID && l.state[key].version != version {
+			code = peer.TxValidationCode_MVCC_READ_CONFLICT
+		}
+	}
+	for _, r := range sim.ranges {
+		if code == peer.TxValidationCode_VALID && !maps.Equal(l.versions(r.start, r.end), r.versions) {
+			code = peer.TxValidationCode_PHANTOM_READ_CONFLICT
+		}
+	}
+	if code == peer.TxValidationCode_VALID {
+		for key, value := range sim.writes {
+			l.state[key] = versionedValue{value: value, version: l.height}
+			l.history[key] = append(l.history[key], keyModification{txID: txID, timestamp: sim.inv.timestamp, value: value})
+		}
+		if sim.event != nil {
+			l.events = append(l.events, blockEvent{block: l.height, event: sim.event})
+		}
+	}
+	l.txs[txID] = committedTx{code: code, block: l.height}
+	close(l.committed)
+	l.committed = make(chan struct{})
+	return true
+}
+
+// keys lists the committed keys from start, inclusive, to end, exclusive, in
+// key order.
+func (l *ledger) keys(start, end string) []string {
+	var keys []string
+	for key := range l.state {
+		if key >= start && key < end {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// versions is the version of every committed key in a range.
+func (l *ledger) versions(start, end string) map[string]uint64 {
+	versions := make(map[string]uint64)
+	for _, key := range l.keys(start, end) {
+		versions[key] = l.state[key].version
+	}
+	return versions
+}
+
+// eventsFrom returns the chaincode events committed from block start on, the
+// current height, and a channel closed when the next block is committed.
+func (l *ledger) eventsFrom(start uint64) ([]blockEvent, uint64, <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := sort.Search(len(l.events), func(i int) bool { return l.events[i].block >= start })
+	return l.events[i:], l.height, l.committed
+}
+
+func (l *ledger) transaction(txID string) (committedTx, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tx, ok := l.txs[txID]
+	return tx, ok
+}
+
+// querySystem answers the qscc queries the backend uses to look up
+// transactions and, for readiness, the chain height.
+func (l *ledger) querySystem(inv *invocation) ([]byte, error) {
+	if inv.fn == "GetChainInfo" && len(inv.args) == 1 && inv.args[0] == l.channel.Name {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return proto.Marshal(&common.BlockchainInfo{Height: l.height})
+	}
+	if len(inv.args) != 2 || inv.args[0] != l.channel.Name {
+		return nil, fmt.Errorf("invalid invocation of qscc: %s", inv.fn)
+	}
+	tx, ok := l.transaction(inv.args[1])
+	if !ok {
+		return nil, fmt.Errorf("Failed to get transaction with id %s, error no such transaction ID [%s] in index", inv.args[1], inv.args[1])
+	}
+	switch inv.fn {
+	case "GetTransactionByID":
+		return proto.Marshal(&peer.ProcessedTransaction{ValidationCode: int32(tx.code)})
+	case "GetBlockByTxID":
+		return proto.Marshal(&common.Block{Header: &common.BlockHeader{Number: tx.block}})
+	default:
+		return nil, fmt.Errorf("requested function %s not found", inv.fn)
+	}
+}
+
+// simulation is a transaction's view of the ledger, which answers the
+// chaincode's state requests. Like a peer's, it reads committed state only: a
+// transaction does not see its own writes.
+type simulation struct {
+	ledger *ledger
+	inv    *invocation
+	reads  map[string]uint64
+	ranges []rangeRead
+	writes map[string][]byte
+	// event is the chaincode event the transaction set, if any.
+	event *peer.ChaincodeEvent
+}
+
+// rangeRead is a range query and the keys it returned, which must be the same
+// at commit.
+type rangeRead struct {
+	start, end string
+	versions   map[string]uint64
+}
+
+// handle answers a state request of the chaincode with the payload of the
+// peer's response.
+func (s *simulation) handle(msg *peer.ChaincodeMessage) ([]byte, error) {
+	switch msg.GetType() {
+	case peer.ChaincodeMessage_GET_STATE:
+		var req peer.GetState
+		if err := s.unmarshal(msg, &req); err != nil {
+			return nil, err
+		}
+		v := s.ledger.state[req.GetKey()]
+		s.reads[req.GetKey()] = v.version
+		return v.value, nil
+
+	case peer.ChaincodeMessage_PUT_STATE:
+		var req peer.PutState
+		if err := s.unmarshal(msg, &req); err != nil {
+			return nil, err
+		}
+		s.writes[req.GetKey()] = req.GetValue()
+		return nil, nil
+
+	case peer.ChaincodeMessage_GET_STATE_BY_RANGE:
+		var req peer.GetStateByRange
+		if err := s.unmarshal(msg, &req); err != nil {
+			return nil, err
+		}
+		if len(req.GetMetadata()) > 0 {
+			return nil, errors.New("paginated range queries are not supported")
+		}
+		read := rangeRead{start: req.GetStartKey(), end: req.GetEndKey(), versions: make(map[string]uint64)}
+		var results []proto.Message
+		for _, key := range s.ledger.keys(read.start, read.end) {
+			v := s.ledger.state[key]
+			read.versions[key] = v.version
+			results = append(results, &queryresult.KV{Namespace: s.inv.chaincode, Key: key, Value: v.value})
+		}
+		s.ranges = append(s.ranges, read)
+		return queryResponse(results, nil)
+
+	case peer.ChaincodeMessage_GET_QUERY_RESULT:
+		var req peer.GetQueryResult
+		if err := s.unmarshal(msg, &req); err != nil {
+			return nil, err
+		}
+		var paging peer.QueryMetadata
+		if err := proto.Unmarshal(req.GetMetadata(), &paging); err != nil {
+			return nil, fmt.Errorf("invalid query metadata: %w", err)
+		}
+		page, bookmark, err := s.ledger.query(req.GetQuery(), int(paging.GetPageSize()), paging.GetBookmark())
+		if err != nil {
+			return nil, err
+		}
+		var results []proto.Message
+		for _, key := range page {
+			results = append(results, &queryresult.KV{Namespace: s.inv.chaincode, Key: key, Value: s.ledger.state[key].value})
+		}
+		var metadata *peer.QueryResponseMetadata
+		if len(req.GetMetadata()) > 0 {
+			metadata = &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: bookmark}
+		}
+		return queryResponse(results, metadata)
+
+	case peer.ChaincodeMessage_GET_HISTORY_FOR_KEY:
+		var req peer.GetHistoryForKey
+		if err := proto.Unmarshal(msg.GetPayload(), &req); err != nil {
+			return nil, err
+		}
+		var results []proto.Message
+		for _, mod := range s.ledger.history[req.GetKey()] {
+			results = append(results, &queryresult.KeyModification{TxId: mod.txID, Value: mod.value, Timestamp: timestamppb.New(mod.timestamp)})
+		}
+		return queryResponse(results, nil)
+
+	case peer.ChaincodeMessage_QUERY_STATE_CLOSE:
+		// Every result is sent with the query, so there is nothing to release.
+		return proto.Marshal(&peer.QueryResponse{})
+
+	default:
+		return nil, fmt.Errorf("%s is not supported by the in-memory peer", msg.GetType())
+	}
+}
+
+// stateRequest is a request on the chaincode's state, public or private.
+type stateRequest interface {
+	proto.Message
+	GetCollection() string
+}
+
+// unmarshal decodes a state request, which must be for the public state.
+func (s *simulation) unmarshal(msg *peer.ChaincodeMessage, req stateRequest) error {
+	if err := proto.Unmarshal(msg.GetPayload(), req); err != nil {
+		return fmt.Errorf("invalid %s request: %w", msg.GetType(), err)
+	}
+	if req.GetCollection() != "" {
+		return errors.New("private data is not supported")
+	}
+	return nil
+}
+
+// queryResponse returns every result of a query in a single response.
+func queryResponse(results []proto.Message, metadata *peer.QueryResponseMetadata) ([]byte, error) {
+	response := &peer.QueryResponse{}
+	for _, result := range results {
+		data, err := proto.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, &peer.QueryResultBytes{ResultBytes: data})
+	}
+	if metadata != nil {
+		data, err := proto.Marshal(metadata)
+		if err != nil {
+			return nil, err
+		}
+		response.Metadata = data
+	}
+	return proto.Marshal(response)
+}
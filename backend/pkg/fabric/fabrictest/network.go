@@ -0,0 +1,217 @@
+// Package fabrictest runs an in-memory stand-in for a Fabric network: one
+// peer gateway, reached over plaintext gRPC, that serves every channel from an
+// in-memory ledger. The backend talks to it through the real GatewayManager,
+// so everything above the peer — the gateway client, retries, error decoding
+// and the HTTP API — is exercised as in production.
+//
+// Transactions run the real spending contract: the network builds it from
+// gov-ledger/chaincode/spending and starts it as a chaincode process, which
+// connects to the peer's chaincode support service as it would to a Fabric
+// peer. The stand-in provides what a peer and its state database would: the
+// endorse/submit/commit-status flow with MVCC and phantom read conflicts, key
+// history, chaincode events, CouchDB rich queries, and the qscc and _lifecycle
+// queries the backend makes. It does not check signatures or endorsement
+// policies.
+// Faults can be injected into gateway calls to reproduce a slow or failing
+// peer, and the calls received are recorded for tests to inspect.
+package fabrictest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+// Channel is a channel of the network and the organization that administers
+// it.
+type Channel struct {
+	// Key is the channel's key in the backend configuration, e.g. "union".
+	Key string
+	// Name is the Fabric channel name, e.g. "union-channel".
+	Name  string
+	MspID string
+	// Domain is the organization's domain, e.g. "union.gov.br", under which
+	// its identities are written in crypto-config.
+	Domain string
+}
+
+// Users are the identities written for every organization.
+var Users = []string{"Admin", "User1"}
+
+// Network is a running stand-in network.
+type Network struct {
+	dir       string
+	chaincode string
+	channels  []Channel
+	ledgers   map[string]*ledger
+	listener  net.Listener
+	server    *grpc.Server
+	support   *chaincodeSupport
+
+	launch    sync.Once
+	launchErr error
+	cc        *chaincode
+	process   *exec.Cmd
+	// exited is closed when the chaincode's process has exited.
+	exited chan struct{}
+
+	faultsMu sync.Mutex
+	faults   []Fault
+	calls    []Call
+}
+
+// NewNetwork writes the organizations' identities under dir, in the
+// crypto-config layout the backend reads, and starts the peer gateway on a
+// local port. chaincodeName is the name the spending contract is deployed
+// under; it is started on first use, or by StartChaincode.
+func NewNetwork(dir, chaincodeName string, channels ...Channel) (*Network, error) {
+	n := &Network{
+		dir:       dir,
+		chaincode: chaincodeName,
+		channels:  channels,
+		ledgers:   make(map[string]*ledger),
+	}
+	for _, ch := range channels {
+		if err := writeIdentities(dir, ch.Domain); err != nil {
+			return nil, fmt.Errorf("write %s identities: %w", ch.Domain, err)
+		}
+		n.ledgers[ch.Name] = newLedger(ch)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	n.listener = lis
+	n.server = grpc.NewServer()
+	n.support = &chaincodeSupport{registered: make(chan *chaincode, 1)}
+	gateway.RegisterGatewayServer(n.server, &gatewayServer{network: n})
+	peer.RegisterChaincodeSupportServer(n.server, n.support)
+	go n.server.Serve(lis)
+	return n, nil
+}
+
+// Endpoint is the address of the peer gateway.
+func (n *Network) Endpoint() string {
+	return n.listener.Addr().String()
+}
+
+// Config returns the fabric configuration of a backend instance that
+// administers adminChannel: it uses the organization's Admin identity on that
+// channel and User1 on the others, which it can then only read.
+func (n *Network) Config(adminChannel string) config.FabricConfig {
+	plaintext := false
+	cfg := config.FabricConfig{
+		NetworkPath:   n.dir,
+		ChaincodeName: n.chaincode,
+		Channels:      make(map[string]config.ChannelConfig, len(n.channels)),
+	}
+	for _, ch := range n.channels {
+		userName := "User1"
+		if ch.Key == adminChannel {
+			userName = "Admin"
+		}
+		cfg.Channels[ch.Key] = config.ChannelConfig{
+			Name:         ch.Name,
+			MspID:        ch.MspID,
+			PeerEndpoint: n.Endpoint(),
+			CryptoPath:   filepath.Join("peerOrganizations", ch.Domain),
+			TLSEnabled:   &plaintext,
+			UserName:     userName,
+		}
+	}
+	return cfg
+}
+
+// Close stops the peer gateway and the chaincode.
+func (n *Network) Close() {
+	n.server.Stop()
+	n.stopChaincode()
+}
+
+func (n *Network) ledger(channelName string) (*ledger, bool) {
+	l, ok := n.ledgers[channelName]
+	return l, ok
+}
+
+// writeIdentities writes a CA and, signed by it, the certificate and key of
+// every user of the organization.
+func writeIdentities(dir, domain string) error {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	caTemplate := certificateTemplate(pkix.Name{CommonName: "ca." + domain, Organization: []string{domain}})
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range Users {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s@%s", user, domain)
+		template := certificateTemplate(pkix.Name{CommonName: name, OrganizationalUnit: []string{"client"}, Organization: []string{domain}})
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			return err
+		}
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return err
+		}
+
+		mspDir := filepath.Join(dir, "crypto-config", "peerOrganizations", domain, "users", name, "msp")
+		if err := writePEM(filepath.Join(mspDir, "signcerts", name+"-cert.pem"), "CERTIFICATE", der); err != nil {
+			return err
+		}
+		if err := writePEM(filepath.Join(mspDir, "keystore", "priv_sk"), "PRIVATE KEY", keyDER); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func certificateTemplate(subject pkix.Name) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+}
+
+func writePEM(path, blockType string, der []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+}
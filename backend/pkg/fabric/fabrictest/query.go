@@ -0,0 +1,313 @@
+package fabrictest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The in-memory state database. Rich queries are CouchDB Mango queries; the
+// stand-in evaluates their selector and sort on the committed JSON values,
+// with CouchDB's collation, where a peer would send them to CouchDB.
+
+// mangoQuery is the part of a Mango query the stand-in evaluates. Index hints
+// are ignored: every query scans the whole state.
+type mangoQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Sort     []map[string]string    `json:"sort"`
+}
+
+// query runs a rich query on the committed state and returns the keys of one
+// page of matches and the bookmark of the next. A page size of 0 returns
+// every match.
+func (l *ledger) query(queryJSON string, pageSize int, bookmark string) ([]string, string, error) {
+	var q mangoQuery
+	if err := json.Unmarshal([]byte(queryJSON), &q); err != nil {
+		return nil, "", fmt.Errorf("invalid query: %w", err)
+	}
+	if q.Selector == nil {
+		return nil, "", errors.New("invalid query: a selector is required")
+	}
+	sortField, descending, err := q.sortOrder()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var matches []queryMatch
+	for key, v := range l.state {
+		var doc map[string]interface{}
+		if json.Unmarshal(v.value, &doc) != nil {
+			continue
+		}
+		ok, err := matchesSelector(q.Selector, doc)
+		if err != nil {
+			return nil, "", err
+		}
+		if ok {
+			match := queryMatch{key: key}
+			if sortField != "" {
+				match.sortValue, _ = lookupField(doc, sortField)
+			}
+			matches = append(matches, match)
+		}
+	}
+	// An index orders equal values by document ID.
+	sort.Slice(matches, func(i, j int) bool {
+		c := matches[i].compare(matches[j])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	start := 0
+	if bookmark != "" {
+		after, err := decodeBookmark(bookmark)
+		if err != nil {
+			return nil, "", errors.New("invalid bookmark")
+		}
+		for start < len(matches) {
+			c := matches[start].compare(after)
+			if (descending && c < 0) || (!descending && c > 0) {
+				break
+			}
+			start++
+		}
+	}
+	end := len(matches)
+	if pageSize > 0 {
+		end = min(start+pageSize, end)
+	}
+
+	keys := make([]string, 0, end-start)
+	for _, match := range matches[start:end] {
+		keys = append(keys, match.key)
+	}
+	if end > start {
+		bookmark = matches[end-1].bookmark()
+	}
+	return keys, bookmark, nil
+}
+
+// sortOrder returns the field a query sorts on, if any, and its direction.
+func (q mangoQuery) sortOrder() (string, bool, error) {
+	switch len(q.Sort) {
+	case 0:
+		return "", false, nil
+	case 1:
+	default:
+		return "", false, errors.New("the in-memory state database sorts on a single field")
+	}
+	for field, order := range q.Sort[0] {
+		if order != "asc" && order != "desc" {
+			return "", false, fmt.Errorf("invalid sort order %q", order)
+		}
+		if _, ok := q.Selector[field]; !ok {
+			return "", false, fmt.Errorf("no index exists for this sort: %s is not in the selector", field)
+		}
+		return field, order == "desc", nil
+	}
+	return "", false, errors.New("invalid sort")
+}
+
+// matchesSelector evaluates a Mango selector on a document.
+func matchesSelector(selector map[string]interface{}, doc map[string]interface{}) (bool, error) {
+	for field, cond := range selector {
+		var ok bool
+		var err error
+		switch field {
+		case "$and", "$or":
+			ok, err = matchesCombination(field, cond, doc)
+		default:
+			value, present := lookupField(doc, field)
+			ok, err = matchesCondition(cond, value, present)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchesCombination(op string, cond interface{}, doc map[string]interface{}) (bool, error) {
+	clauses, ok := cond.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("invalid selector: %s requires an array", op)
+	}
+	for _, clause := range clauses {
+		selector, ok := clause.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid selector: %s requires an array of selectors", op)
+		}
+		matched, err := matchesSelector(selector, doc)
+		if err != nil {
+			return false, err
+		}
+		if matched == (op == "$or") {
+			return matched, nil
+		}
+	}
+	return op == "$and", nil
+}
+
+// matchesCondition evaluates a field's condition: a value it must equal, or
+// an object of operators and subfields.
+func matchesCondition(cond, value interface{}, present bool) (bool, error) {
+	ops, ok := cond.(map[string]interface{})
+	if !ok {
+		return present && collate(value, cond) == 0, nil
+	}
+	for op, arg := range ops {
+		var matched bool
+		var err error
+		if strings.HasPrefix(op, "$") {
+			matched, err = matchesOperator(op, arg, value, present)
+		} else {
+			sub, isObject := value.(map[string]interface{})
+			subValue, subPresent := lookupField(sub, op)
+			matched, err = matchesCondition(arg, subValue, isObject && subPresent)
+		}
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+var comparisons = map[string]func(int) bool{
+	"$eq":  func(c int) bool { return c == 0 },
+	"$ne":  func(c int) bool { return c != 0 },
+	"$gt":  func(c int) bool { return c > 0 },
+	"$gte": func(c int) bool { return c >= 0 },
+	"$lt":  func(c int) bool { return c < 0 },
+	"$lte": func(c int) bool { return c <= 0 },
+}
+
+// matchesOperator evaluates one operator. As in CouchDB, a missing field
+// matches nothing but {"$exists": false}.
+func matchesOperator(op string, arg, value interface{}, present bool) (bool, error) {
+	switch op {
+	case "$exists":
+		want, ok := arg.(bool)
+		if !ok {
+			return false, errors.New("invalid selector: $exists requires a boolean")
+		}
+		return present == want, nil
+	case "$in", "$nin":
+		values, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid selector: %s requires an array", op)
+		}
+		if !present {
+			return false, nil
+		}
+		found := false
+		for _, v := range values {
+			if collate(value, v) == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (op == "$in"), nil
+	}
+	compare, ok := comparisons[op]
+	if !ok {
+		return false, fmt.Errorf("invalid selector: unsupported operator %s", op)
+	}
+	return present && compare(collate(value, arg)), nil
+}
+
+// lookupField resolves a dotted field path in a document.
+func lookupField(doc map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// collate orders JSON values as CouchDB does: null, false, true, numbers,
+// strings, arrays, then objects. Strings compare by code point, where CouchDB
+// uses ICU collation.
+func collate(a, b interface{}) int {
+	ra, rb := collationRank(a), collationRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+	switch a := a.(type) {
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+	return 0
+}
+
+func collationRank(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// queryMatch is a matching document's position in the query's sort order.
+type queryMatch struct {
+	key       string
+	sortValue interface{}
+}
+
+func (m queryMatch) compare(other queryMatch) int {
+	if c := collate(m.sortValue, other.sortValue); c != 0 {
+		return c
+	}
+	return strings.Compare(m.key, other.key)
+}
+
+// bookmark encodes the position after which the next page starts.
+func (m queryMatch) bookmark() string {
+	data, _ := json.Marshal(map[string]interface{}{"k": m.key, "v": m.sortValue})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeBookmark(bookmark string) (queryMatch, error) {
+	data, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return queryMatch{}, err
+	}
+	var position struct {
+		K string      `json:"k"`
+		V interface{} `json:"v"`
+	}
+	if err := json.Unmarshal(data, &position); err != nil {
+		return queryMatch{}, err
+	}
+	return queryMatch{key: position.K, sortValue: position.V}, nil
+}
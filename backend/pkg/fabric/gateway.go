@@ -7,69 +7,199 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric-gateway/pkg/client"
 	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/connectivity"
 
 	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/metrics"
 )
 
 type GatewayManager struct {
-	config      *config.Config
+	settings    atomic.Pointer[gatewaySettings]
 	connections map[string]*ChannelConnection
+	contracts   map[string]*Contract
+	nextPeer    map[string]int
+	dialing     map[string]*dial
+	retired     map[*ChannelConnection]struct{}
 	mu          sync.RWMutex
+
+	statesMu sync.RWMutex
+	states   map[string]*ConnectionState
 }
 
 type ChannelConnection struct {
-	Gateway    *client.Gateway
-	GrpcConn   *grpc.ClientConn
-	Contract   *client.Contract
-	Network    *client.Network
-	ChannelCfg config.ChannelConfig
+	Gateway     *client.Gateway
+	GrpcConn    *grpc.ClientConn
+	Contract    *client.Contract
+	Network     *client.Network
+	ChannelCfg  config.ChannelConfig
+	Sign        identity.Sign
+	Certificate *x509.Certificate
+	Endpoint    string
+	peerIndex   int
+
+	// Calls in flight hold a lease on the connection. A retired connection
+	// takes no new leases and is closed once the last one is released.
+	leaseMu sync.Mutex
+	leases  int
+	retired bool
+	evicted bool
+	drained chan struct{}
+}
+
+// dial is a channel's connection attempt in progress. Callers that need the
+// channel meanwhile wait on done instead of dialing the peers themselves.
+type dial struct {
+	done chan struct{}
+	conn *ChannelConnection
+	err  error
+}
+
+// gatewaySettings is the configuration the manager works with. Reload swaps
+// it as a whole.
+type gatewaySettings struct {
+	config   *config.Config
+	retry    *RetryPolicies
+	timeouts *TimeoutPolicies
+}
+
+func newGatewaySettings(cfg *config.Config) *gatewaySettings {
+	return &gatewaySettings{
+		config:   cfg,
+		retry:    NewRetryPolicies(cfg.Fabric.Retry),
+		timeouts: NewTimeoutPolicies(cfg),
+	}
 }
 
+// ConnectionState is the last observed state of a channel's peer connection.
+type ConnectionState struct {
+	Channel   string
+	State     string
+	Endpoint  string
+	LastError string
+	Failovers int
+	Since     time.Time
+}
+
+const (
+	StateNotConnected = "NOT_CONNECTED"
+	StateDisconnected = "DISCONNECTED"
+
+	defaultConnectTimeout = 5 * time.Second
+	defaultFailoverAfter  = 10 * time.Second
+	defaultDrainTimeout   = 2 * time.Minute
+)
+
 func NewGatewayManager(cfg *config.Config) *GatewayManager {
-	return &GatewayManager{
-		config:      cfg,
+	gm := &GatewayManager{
 		connections: make(map[string]*ChannelConnection),
+		contracts:   make(map[string]*Contract),
+		nextPeer:    make(map[string]int),
+		dialing:     make(map[string]*dial),
+		retired:     make(map[*ChannelConnection]struct{}),
+		states:      make(map[string]*ConnectionState),
 	}
+	gm.settings.Store(newGatewaySettings(cfg))
+	return gm
+}
+
+// current returns the settings in effect.
+func (gm *GatewayManager) current() *gatewaySettings {
+	return gm.settings.Load()
 }
 
+// GetConnection returns the channel's connection, connecting first if there is
+// none. Peers are dialed without holding the manager's lock, so a channel
+// whose peers are unreachable only delays the callers that need that channel.
 func (gm *GatewayManager) GetConnection(channelKey string) (*ChannelConnection, error) {
-	gm.mu.RLock()
-	conn, exists := gm.connections[channelKey]
-	gm.mu.RUnlock()
+	return gm.connection(context.Background(), channelKey)
+}
+
+// connection is GetConnection for a caller that stops waiting when ctx is
+// done. The dial itself carries on, bounded by the connect timeout, and its
+// connection is kept for the next caller.
+func (gm *GatewayManager) connection(ctx context.Context, channelKey string) (*ChannelConnection, error) {
+	for {
+		gm.mu.RLock()
+		conn, exists := gm.connections[channelKey]
+		gm.mu.RUnlock()
+
+		if exists {
+			return conn, nil
+		}
+
+		gm.mu.Lock()
+		if conn, exists = gm.connections[channelKey]; exists {
+			gm.mu.Unlock()
+			return conn, nil
+		}
+
+		d, dialing := gm.dialing[channelKey]
+		if !dialing {
+			channelCfg, ok := gm.current().config.GetChannelConfig(channelKey)
+			if !ok {
+				gm.mu.Unlock()
+				return nil, fmt.Errorf("unknown channel: %s", channelKey)
+			}
+			d = &dial{done: make(chan struct{})}
+			gm.dialing[channelKey] = d
+			go gm.connect(channelKey, channelCfg, gm.nextPeer[channelKey], d)
+		}
+		gm.mu.Unlock()
 
-	if exists {
-		return conn, nil
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("connecting to %s: %w", channelKey, ctx.Err())
+		}
+
+		if d.err != nil || d.conn != nil {
+			return d.conn, d.err
+		}
+		// A reload superseded the dial; connect again with the new settings.
 	}
+}
+
+// connect dials the channel's peers and publishes the result to d's waiters.
+// A dial that Reload or Close superseded while it ran is discarded, leaving
+// both the connection and the error of d unset.
+func (gm *GatewayManager) connect(channelKey string, channelCfg config.ChannelConfig, start int, d *dial) {
+	defer close(d.done)
+	conn, err := gm.createConnection(channelKey, channelCfg, start)
 
 	gm.mu.Lock()
 	defer gm.mu.Unlock()
 
-	if conn, exists = gm.connections[channelKey]; exists {
-		return conn, nil
-	}
-
-	channelCfg, ok := gm.config.GetChannelConfig(channelKey)
-	if !ok {
-		return nil, fmt.Errorf("unknown channel: %s", channelKey)
+	if gm.dialing[channelKey] != d {
+		if conn != nil {
+			conn.close()
+		}
+		return
 	}
+	delete(gm.dialing, channelKey)
 
-	conn, err := gm.createConnection(channelCfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create connection for %s: %w", channelKey, err)
+		d.err = fmt.Errorf("failed to create connection for %s: %w", channelKey, err)
+		return
 	}
-
+	conn.drained = make(chan struct{})
+	gm.nextPeer[channelKey] = conn.peerIndex
 	gm.connections[channelKey] = conn
-	return conn, nil
+	d.conn = conn
+	go gm.monitor(channelKey, conn)
 }
 
-func (gm *GatewayManager) createConnection(channelCfg config.ChannelConfig) (*ChannelConnection, error) {
-	networkPath := gm.config.Fabric.NetworkPath
+// createConnection connects to the first reachable peer of the channel's
+// organization, starting with the peer at index start.
+func (gm *GatewayManager) createConnection(channelKey string, channelCfg config.ChannelConfig, start int) (*ChannelConnection, error) {
+	networkPath := gm.current().config.Fabric.NetworkPath
 	domain := getDomain(channelCfg.CryptoPath)
 
 	userName := channelCfg.UserName
@@ -81,7 +211,7 @@ func (gm *GatewayManager) createConnection(channelCfg config.ChannelConfig) (*Ch
 		fmt.Sprintf("users/%s@%s/msp/signcerts", userName, domain))
 	cert, err := loadCertificate(certPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load certificate from %s: %w", certPath, err)
+		return nil, &CertificateError{File: certPath, Problem: CertUnreadable, Err: err}
 	}
 
 	id, err := identity.NewX509Identity(channelCfg.MspID, cert)
@@ -101,59 +231,258 @@ func (gm *GatewayManager) createConnection(channelCfg config.ChannelConfig) (*Ch
 		return nil, fmt.Errorf("failed to create signer: %w", err)
 	}
 
-	grpcConn, err := gm.createGrpcConnection(channelCfg)
+	peers := peerEndpoints(channelCfg)
+	start %= len(peers)
+
+	var lastErr error
+	for i := range peers {
+		index := (start + i) % len(peers)
+		peer := peers[index]
+
+		handshake := &handshakeError{}
+		grpcConn, err := gm.createGrpcConnection(channelCfg, peer, handshake)
+		if err == nil {
+			err = gm.awaitReady(grpcConn, handshake)
+			if err != nil {
+				grpcConn.Close()
+			}
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("peer %s: %w", peer.Endpoint, err)
+			log.Warn().
+				Err(err).
+				Str("channel", channelKey).
+				Str("endpoint", peer.Endpoint).
+				Msg("Failed to connect to peer")
+			gm.setState(channelKey, peer.Endpoint, StateDisconnected, err)
+			continue
+		}
+
+		timeouts := gm.current().timeouts.Channel(channelKey)
+		gateway, err := client.Connect(
+			id,
+			client.WithSign(sign),
+			client.WithClientConnection(grpcConn),
+			client.WithEvaluateTimeout(timeouts.Evaluate),
+			client.WithEndorseTimeout(timeouts.Endorse),
+			client.WithSubmitTimeout(timeouts.Submit),
+			client.WithCommitStatusTimeout(timeouts.CommitStatus),
+		)
+		if err != nil {
+			grpcConn.Close()
+			return nil, fmt.Errorf("failed to connect gateway: %w", err)
+		}
+
+		network := gateway.GetNetwork(channelCfg.Name)
+		contract := network.GetContract(gm.current().config.Fabric.ChaincodeName)
+
+		if index != start {
+			gm.recordFailover(channelKey)
+		}
+		gm.setState(channelKey, peer.Endpoint, grpcConn.GetState().String(), nil)
+
+		log.Info().
+			Str("channel", channelKey).
+			Str("endpoint", peer.Endpoint).
+			Bool("tls", channelCfg.UsesTLS()).
+			Bool("mutualTLS", channelCfg.UsesTLS() && channelCfg.TLS.ClientCert != "").
+			Msg("Connected to peer")
+
+		return &ChannelConnection{
+			Gateway:     gateway,
+			GrpcConn:    grpcConn,
+			Contract:    contract,
+			Network:     network,
+			ChannelCfg:  channelCfg,
+			Sign:        sign,
+			Certificate: cert,
+			Endpoint:    peer.Endpoint,
+			peerIndex:   index,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no reachable peer (%d tried): %w", len(peers), lastErr)
+}
+
+func (gm *GatewayManager) createGrpcConnection(channelCfg config.ChannelConfig, peer config.PeerConfig, handshake *handshakeError) (*grpc.ClientConn, error) {
+	transportCredentials, err := gm.transportCredentials(channelCfg, peer, handshake)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+		return nil, err
 	}
 
-	gateway, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(grpcConn),
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
+	return grpc.Dial(
+		peer.Endpoint,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
-	if err != nil {
-		grpcConn.Close()
-		return nil, fmt.Errorf("failed to connect gateway: %w", err)
+}
+
+// awaitReady blocks until the connection is established or the connect
+// timeout expires. A peer certificate that failed verification is reported
+// rather than the timeout.
+func (gm *GatewayManager) awaitReady(conn *grpc.ClientConn, handshake *handshakeError) error {
+	timeout := gm.current().config.Fabric.Connection.ConnectTimeout
+	if timeout <= 0 {
+		timeout = defaultConnectTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			if err := handshake.get(); err != nil {
+				return err
+			}
+			return fmt.Errorf("not ready after %s (state %s)", timeout, state)
+		}
 	}
+}
 
-	network := gateway.GetNetwork(channelCfg.Name)
-	contract := network.GetContract(gm.config.Fabric.ChaincodeName)
+// monitor follows a connection's gRPC state. gRPC reconnects to the same peer
+// by itself; if the connection stays in failure for longer than the failover
+// window it is evicted, so the next request connects to another peer.
+func (gm *GatewayManager) monitor(channelKey string, conn *ChannelConnection) {
+	failoverAfter := gm.current().config.Fabric.Connection.FailoverAfter
+	if failoverAfter <= 0 {
+		failoverAfter = defaultFailoverAfter
+	}
 
-	return &ChannelConnection{
-		Gateway:    gateway,
-		GrpcConn:   grpcConn,
-		Contract:   contract,
-		Network:    network,
-		ChannelCfg: channelCfg,
-	}, nil
+	var failingSince time.Time
+	for {
+		state := conn.GrpcConn.GetState()
+		if !gm.isCurrent(channelKey, conn) {
+			return
+		}
+		gm.setState(channelKey, conn.Endpoint, state.String(), nil)
+
+		switch state {
+		case connectivity.Shutdown:
+			return
+		case connectivity.Ready, connectivity.Idle:
+			failingSince = time.Time{}
+		case connectivity.TransientFailure:
+			if failingSince.IsZero() {
+				failingSince = time.Now()
+			}
+		}
+
+		ctx := context.Background()
+		cancel := context.CancelFunc(func() {})
+		if !failingSince.IsZero() {
+			remaining := failoverAfter - time.Since(failingSince)
+			if remaining <= 0 {
+				gm.evict(channelKey, conn, fmt.Errorf("peer %s unreachable for %s", conn.Endpoint, failoverAfter))
+				return
+			}
+			ctx, cancel = context.WithTimeout(ctx, remaining)
+		}
+
+		changed := conn.GrpcConn.WaitForStateChange(ctx, state)
+		cancel()
+		if !changed && failingSince.IsZero() {
+			return
+		}
+	}
+}
+
+// evict closes a broken connection and removes it from the cache if it is
+// still the channel's current connection.
+func (gm *GatewayManager) evict(channelKey string, conn *ChannelConnection, reason error) {
+	gm.mu.Lock()
+	current, ok := gm.connections[channelKey]
+	if ok && current == conn {
+		delete(gm.connections, channelKey)
+		gm.nextPeer[channelKey] = conn.peerIndex + 1
+	}
+	gm.mu.Unlock()
+
+	if !ok || current != conn {
+		return
+	}
+
+	log.Warn().
+		Err(reason).
+		Str("channel", channelKey).
+		Str("endpoint", conn.Endpoint).
+		Msg("Evicting peer connection")
+
+	metrics.ConnectionEvicted(channelKey)
+	gm.setState(channelKey, conn.Endpoint, StateDisconnected, reason)
+	conn.leaseMu.Lock()
+	conn.evicted = true
+	conn.leaseMu.Unlock()
+	conn.close()
+}
+
+func (gm *GatewayManager) isCurrent(channelKey string, conn *ChannelConnection) bool {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+	return gm.connections[channelKey] == conn
 }
 
-func (gm *GatewayManager) createGrpcConnection(channelCfg config.ChannelConfig) (*grpc.ClientConn, error) {
-	networkPath := gm.config.Fabric.NetworkPath
+// reportUnavailable is called when a request to the channel failed with a
+// transport error. The connection is evicted unless gRPC still considers it
+// ready, in which case the monitor decides.
+func (gm *GatewayManager) reportUnavailable(channelKey string, conn *ChannelConnection, err error) {
+	if conn.GrpcConn.GetState() == connectivity.Ready {
+		return
+	}
+	gm.evict(channelKey, conn, err)
+}
 
-	tlsCertPath := filepath.Join(networkPath, "crypto-config", channelCfg.CryptoPath,
-		fmt.Sprintf("peers/%s/tls/ca.crt", channelCfg.PeerHostAlias))
+func (gm *GatewayManager) setState(channelKey, endpoint, state string, err error) {
+	gm.statesMu.Lock()
+	defer gm.statesMu.Unlock()
 
-	tlsCert, err := os.ReadFile(tlsCertPath)
+	st, ok := gm.states[channelKey]
+	if !ok {
+		st = &ConnectionState{Channel: channelKey}
+		gm.states[channelKey] = st
+	}
+	if st.State != state || st.Endpoint != endpoint {
+		st.Since = time.Now().UTC()
+	}
+	st.State = state
+	st.Endpoint = endpoint
+	metrics.SetConnectionState(channelKey, state)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read TLS certificate: %w", err)
+		st.LastError = err.Error()
 	}
+}
+
+func (gm *GatewayManager) recordFailover(channelKey string) {
+	gm.statesMu.Lock()
+	defer gm.statesMu.Unlock()
 
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(tlsCert) {
-		return nil, fmt.Errorf("failed to add TLS certificate to pool")
+	st, ok := gm.states[channelKey]
+	if !ok {
+		st = &ConnectionState{Channel: channelKey}
+		gm.states[channelKey] = st
 	}
+	st.Failovers++
+	metrics.ConnectionFailover(channelKey)
+}
 
-	transportCredentials := credentials.NewClientTLSFromCert(certPool, channelCfg.PeerHostAlias)
+// ConnectionStates returns the connection state of every configured channel.
+func (gm *GatewayManager) ConnectionStates() []ConnectionState {
+	gm.statesMu.RLock()
+	defer gm.statesMu.RUnlock()
 
-	return grpc.Dial(
-		channelCfg.PeerEndpoint,
-		grpc.WithTransportCredentials(transportCredentials),
-	)
+	channels := gm.current().config.ValidChannels()
+	states := make([]ConnectionState, 0, len(channels))
+	for _, channelKey := range channels {
+		if st, ok := gm.states[channelKey]; ok {
+			states = append(states, *st)
+			continue
+		}
+		states = append(states, ConnectionState{Channel: channelKey, State: StateNotConnected})
+	}
+	return states
 }
 
 func (gm *GatewayManager) Close() {
@@ -161,18 +490,57 @@ func (gm *GatewayManager) Close() {
 	defer gm.mu.Unlock()
 
 	for key, conn := range gm.connections {
-		conn.Gateway.Close()
-		conn.GrpcConn.Close()
+		conn.close()
 		delete(gm.connections, key)
 	}
+	for key := range gm.dialing {
+		delete(gm.dialing, key)
+	}
+	for conn := range gm.retired {
+		conn.close()
+		delete(gm.retired, conn)
+	}
 }
 
-func (gm *GatewayManager) GetContract(channelKey string) (*client.Contract, error) {
+func (gm *GatewayManager) GetContract(channelKey string) (*Contract, error) {
+	if _, err := gm.GetConnection(channelKey); err != nil {
+		return nil, err
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	channelCfg, ok := gm.current().config.GetChannelConfig(channelKey)
+	if !ok {
+		return nil, fmt.Errorf("unknown channel: %s", channelKey)
+	}
+	contract, ok := gm.contracts[channelKey]
+	if !ok {
+		contract = newContract(gm, channelKey, channelCfg.Name)
+		gm.contracts[channelKey] = contract
+	}
+	return contract, nil
+}
+
+// Sign signs a digest with the identity this instance uses on the channel and
+// returns the signature with the PEM certificate needed to verify it.
+func (gm *GatewayManager) Sign(channelKey string, digest []byte) ([]byte, []byte, error) {
 	conn, err := gm.GetConnection(channelKey)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	signature, err := conn.Sign(digest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign digest: %w", err)
+	}
+
+	certPEM, err := identity.CertificateToPEM(conn.Certificate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode certificate: %w", err)
 	}
-	return conn.Contract, nil
+
+	return signature, certPEM, nil
 }
 
 // =============================================================================
@@ -221,6 +589,21 @@ func loadPrivateKey(keyDir string) (interface{}, error) {
 	return nil, fmt.Errorf("no private key found in %s", keyDir)
 }
 
+// peerEndpoints lists the channel's primary peer followed by its failover peers.
+func peerEndpoints(channelCfg config.ChannelConfig) []config.PeerConfig {
+	peers := []config.PeerConfig{{
+		Endpoint:  channelCfg.PeerEndpoint,
+		HostAlias: channelCfg.PeerHostAlias,
+	}}
+	for _, peer := range channelCfg.Peers {
+		if peer.Endpoint == "" || peer.Endpoint == channelCfg.PeerEndpoint {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
 func getDomain(cryptoPath string) string {
 	return filepath.Base(cryptoPath)
 }
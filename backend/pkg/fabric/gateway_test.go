@@ -0,0 +1,118 @@
+package fabric
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+// blackhole accepts TCP connections and never answers, like a peer whose
+// host is up but whose gRPC server hangs.
+func blackhole(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func TestGetConnectionDoesNotBlockOtherChannels(t *testing.T) {
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+		fabrictest.Channel{Key: "state", Name: "state-channel", MspID: "StateMSP", Domain: "state.gov.br"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(network.Close)
+
+	cfg := &config.Config{Fabric: network.Config("union")}
+	cfg.Fabric.Connection.ConnectTimeout = 2 * time.Second
+	state := cfg.Fabric.Channels["state"]
+	state.PeerEndpoint = blackhole(t)
+	cfg.Fabric.Channels["state"] = state
+
+	gm := NewGatewayManager(cfg)
+	t.Cleanup(gm.Close)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = gm.GetConnection("state")
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	started := time.Now()
+	if _, err := gm.GetConnection("union"); err != nil {
+		t.Fatalf("union: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("union connected after %s, behind the state channel's dial", elapsed)
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		if err == nil {
+			t.Error("the unreachable state peer connected")
+		}
+	}
+	if len(gm.dialing) != 0 {
+		t.Errorf("dials left in progress: %v", gm.dialing)
+	}
+}
+
+func TestReloadSupersedesADialInProgress(t *testing.T) {
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(network.Close)
+
+	reachable := &config.Config{Fabric: network.Config("union")}
+	unreachable := &config.Config{Fabric: network.Config("union")}
+	unreachable.Fabric.Connection.ConnectTimeout = time.Second
+	union := unreachable.Fabric.Channels["union"]
+	union.PeerEndpoint = blackhole(t)
+	unreachable.Fabric.Channels["union"] = union
+
+	gm := NewGatewayManager(unreachable)
+	t.Cleanup(gm.Close)
+
+	done := make(chan error, 1)
+	go func() {
+		conn, err := gm.GetConnection("union")
+		if err == nil && conn.Endpoint != network.Endpoint() {
+			t.Errorf("connected to %s, want the reloaded endpoint %s", conn.Endpoint, network.Endpoint())
+		}
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	gm.Reload(reachable)
+
+	// The superseded dial fails on the old endpoint; its caller then connects
+	// with the reloaded settings instead of reporting that failure.
+	if err := <-done; err != nil {
+		t.Fatalf("GetConnection across a reload: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer/lifecycle"
+	"google.golang.org/protobuf/proto"
+
+	apperrors "github.com/gov-spending/backend/internal/errors"
+	"github.com/gov-spending/backend/internal/logging"
+)
+
+// Readiness checks, in the order they run. A probe stops at the first failure.
+const (
+	CheckConnection  = "connection"
+	CheckCertificate = "certificate"
+	CheckChaincode   = "chaincode"
+	CheckQuery       = "query"
+
+	// CheckTimeout is reported instead when the probe as a whole timed out.
+	CheckTimeout = "timeout"
+)
+
+var ProbeChecks = []string{CheckConnection, CheckCertificate, CheckChaincode, CheckQuery}
+
+const (
+	defaultProbeTimeout = 3 * time.Second
+	certExpiryWarning   = 30 * 24 * time.Hour
+
+	// probeTypeID names no document type, so the probe query is a point read
+	// the contract answers with NOT_FOUND, whatever the ledger holds.
+	probeTypeID = "__readiness_probe__"
+)
+
+// ProbeResult is the outcome of a readiness probe against one channel.
+// Latency is the round trip of the chaincode query.
+type ProbeResult struct {
+	Channel              string
+	Required             bool
+	Endpoint             string
+	Latency              time.Duration
+	CertificateExpiresAt time.Time
+	ChaincodeVersion     string
+	ChaincodeSequence    int64
+	Passed               []string
+	Failed               string
+	Err                  error
+}
+
+func (r *ProbeResult) Ready() bool {
+	return r.Err == nil
+}
+
+func (r *ProbeResult) fail(check string, err error) *ProbeResult {
+	r.Failed = check
+	r.Err = err
+	return r
+}
+
+// ProbeTimeout is the time allowed for a single channel's readiness probe.
+func (gm *GatewayManager) ProbeTimeout() time.Duration {
+	if timeout := gm.current().config.Fabric.Health.Timeout; timeout > 0 {
+		return timeout
+	}
+	return defaultProbeTimeout
+}
+
+// Probe checks that a channel can serve requests: a peer connection can be
+// established, the identity certificate is valid, the chaincode is defined on
+// the channel and the chaincode answers a query. Every step stops when ctx is
+// done; the probe then reports the timeout, after the checks that passed.
+func (gm *GatewayManager) Probe(ctx context.Context, channelKey string) *ProbeResult {
+	channelCfg, _ := gm.current().config.GetChannelConfig(channelKey)
+
+	result := gm.probe(ctx, channelKey)
+	result.Required = !channelCfg.Optional
+	if result.Err != nil && ctx.Err() != nil {
+		result.fail(CheckTimeout, fmt.Errorf("readiness probe did not complete: %w", ctx.Err()))
+	}
+	return result
+}
+
+func (gm *GatewayManager) probe(ctx context.Context, channelKey string) *ProbeResult {
+	result := &ProbeResult{Channel: channelKey}
+
+	conn, err := gm.hold(ctx, channelKey, nil)
+	if err != nil {
+		return result.fail(CheckConnection, err)
+	}
+	defer conn.release()
+	result.Endpoint = conn.Endpoint
+	result.Passed = append(result.Passed, CheckConnection)
+
+	cert := conn.Certificate
+	result.CertificateExpiresAt = cert.NotAfter.UTC()
+	now := time.Now()
+	switch {
+	case now.Before(cert.NotBefore):
+		return result.fail(CheckCertificate, fmt.Errorf("identity certificate not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339)))
+	case now.After(cert.NotAfter):
+		return result.fail(CheckCertificate, fmt.Errorf("identity certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339)))
+	case cert.NotAfter.Sub(now) < certExpiryWarning:
+		logging.Ctx(ctx).Warn().
+			Str("channel", channelKey).
+			Time("expiresAt", cert.NotAfter).
+			Msg("Identity certificate expires soon")
+	}
+	result.Passed = append(result.Passed, CheckCertificate)
+
+	definition, err := gm.chaincodeDefinition(ctx, conn)
+	if err != nil {
+		return result.fail(CheckChaincode, err)
+	}
+	result.ChaincodeVersion = definition.GetVersion()
+	result.ChaincodeSequence = definition.GetSequence()
+	result.Passed = append(result.Passed, CheckChaincode)
+
+	// A running contract answers NOT_FOUND; any other failure means it cannot
+	// serve requests.
+	start := time.Now()
+	_, err = conn.Contract.EvaluateWithContext(ctx, "GetDocumentType", client.WithArguments(probeTypeID))
+	result.Latency = time.Since(start)
+	if payload, ok := apperrors.DecodeChaincodeError(err); ok && payload.Code == string(apperrors.ErrCodeNotFound) {
+		err = nil
+	}
+	if err != nil {
+		if isRetriableStatus(err) {
+			gm.reportUnavailable(channelKey, conn, err)
+		}
+		return result.fail(CheckQuery, err)
+	}
+	result.Passed = append(result.Passed, CheckQuery)
+
+	return result
+}
+
+// chaincodeDefinition reads the committed definition of the configured
+// chaincode from the channel's lifecycle system chaincode.
+func (gm *GatewayManager) chaincodeDefinition(ctx context.Context, conn *ChannelConnection) (*lifecycle.QueryChaincodeDefinitionResult, error) {
+	name := gm.current().config.Fabric.ChaincodeName
+	args, err := proto.Marshal(&lifecycle.QueryChaincodeDefinitionArgs{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := conn.Network.GetContract("_lifecycle").EvaluateWithContext(ctx, "QueryChaincodeDefinition", client.WithBytesArguments(args))
+	if err != nil {
+		return nil, fmt.Errorf("chaincode %s is not defined on channel %s: %w", name, conn.ChannelCfg.Name, err)
+	}
+
+	var definition lifecycle.QueryChaincodeDefinitionResult
+	if err := proto.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("failed to decode chaincode definition: %w", err)
+	}
+	return &definition, nil
+}
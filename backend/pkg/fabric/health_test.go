@@ -0,0 +1,70 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+func TestProbe(t *testing.T) {
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+		fabrictest.Channel{Key: "state", Name: "state-channel", MspID: "StateMSP", Domain: "state.gov.br"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Fabric: network.Config("union")}
+	cfg.Fabric.Connection.ConnectTimeout = 5 * time.Second
+	state := cfg.Fabric.Channels["state"]
+	state.PeerEndpoint = blackhole(t)
+	cfg.Fabric.Channels["state"] = state
+
+	gm := NewGatewayManager(cfg)
+	t.Cleanup(gm.Close)
+
+	t.Run("ready", func(t *testing.T) {
+		result := gm.Probe(context.Background(), "union")
+		if !result.Ready() || !slices.Equal(result.Passed, ProbeChecks) || !result.Required {
+			t.Fatalf("probe = %+v, want every check passed", result)
+		}
+		if result.Endpoint != network.Endpoint() || result.CertificateExpiresAt.IsZero() {
+			t.Errorf("probe = %+v, want the endpoint and certificate expiry", result)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		started := time.Now()
+		result := gm.Probe(ctx, "state")
+		// The dial to the silent peer takes the full connect timeout; the
+		// probe must not wait for it.
+		if elapsed := time.Since(started); elapsed > time.Second {
+			t.Errorf("probe returned after %s, want it to stop at its deadline", elapsed)
+		}
+		if result.Failed != CheckTimeout || !errors.Is(result.Err, context.DeadlineExceeded) || len(result.Passed) != 0 {
+			t.Errorf("probe = %+v, want a timeout before any check passed", result)
+		}
+	})
+	t.Run("chaincode down", func(t *testing.T) {
+		network.StopChaincode()
+
+		result := gm.Probe(context.Background(), "union")
+		want := []string{CheckConnection, CheckCertificate, CheckChaincode}
+		if result.Ready() || result.Failed != CheckQuery || !slices.Equal(result.Passed, want) {
+			t.Errorf("probe = %+v, want the chaincode query to fail", result)
+		}
+	})
+}
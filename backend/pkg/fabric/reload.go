@@ -0,0 +1,175 @@
+package fabric
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+// acquire takes a lease on the connection. It fails once the connection has
+// been retired or evicted; the caller then asks the manager for the current one.
+func (conn *ChannelConnection) acquire() bool {
+	conn.leaseMu.Lock()
+	defer conn.leaseMu.Unlock()
+	if conn.retired || conn.evicted {
+		return false
+	}
+	conn.leases++
+	return true
+}
+
+// release returns a lease taken by acquire. It is a no-op on nil, so callers
+// can defer it before knowing whether they got a connection.
+func (conn *ChannelConnection) release() {
+	if conn == nil {
+		return
+	}
+	conn.leaseMu.Lock()
+	defer conn.leaseMu.Unlock()
+	conn.leases--
+	if conn.retired && conn.leases == 0 {
+		close(conn.drained)
+	}
+}
+
+// retire stops the connection from taking new leases and returns a channel
+// that is closed once the leases already taken are released.
+func (conn *ChannelConnection) retire() <-chan struct{} {
+	conn.leaseMu.Lock()
+	defer conn.leaseMu.Unlock()
+	if !conn.retired {
+		conn.retired = true
+		if conn.leases == 0 {
+			close(conn.drained)
+		}
+	}
+	return conn.drained
+}
+
+func (conn *ChannelConnection) close() {
+	conn.Gateway.Close()
+	conn.GrpcConn.Close()
+}
+
+// hold returns the connection a call should use for its next attempt: the one
+// it already holds, so a call stays on the connection it started with, unless
+// that connection was evicted after a peer failure. Otherwise the lease on held
+// is released and one is taken on the channel's current connection. On error
+// the call holds nothing.
+func (gm *GatewayManager) hold(ctx context.Context, channelKey string, held *ChannelConnection) (*ChannelConnection, error) {
+	if held != nil {
+		held.leaseMu.Lock()
+		evicted := held.evicted
+		held.leaseMu.Unlock()
+		if !evicted {
+			return held, nil
+		}
+		held.release()
+	}
+
+	for {
+		conn, err := gm.connection(ctx, channelKey)
+		if err != nil {
+			return nil, err
+		}
+		if conn.acquire() {
+			return conn, nil
+		}
+	}
+}
+
+// Reload applies a new, already validated, configuration. Timeouts and retry
+// policies take effect for the next call. Connections of channels that were
+// removed or whose connection settings changed are retired: new calls connect
+// with the new settings, while calls in flight finish on the old connection,
+// which is closed once they are done or the drain timeout expires. It returns
+// the keys of the channels whose connection was replaced.
+func (gm *GatewayManager) Reload(cfg *config.Config) []string {
+	gm.mu.Lock()
+	old := gm.current().config
+	gm.settings.Store(newGatewaySettings(cfg))
+
+	reconnectAll := old.Fabric.NetworkPath != cfg.Fabric.NetworkPath ||
+		old.Fabric.ChaincodeName != cfg.Fabric.ChaincodeName
+
+	var replaced []string
+	retired := make(map[string]*ChannelConnection)
+	for _, channelKey := range old.ValidChannels() {
+		channelCfg, ok := cfg.GetChannelConfig(channelKey)
+		if ok && !reconnectAll && !connectionChanged(old.Fabric.Channels[channelKey], channelCfg) {
+			continue
+		}
+		// Contracts carry the channel name; recreate them on next use.
+		delete(gm.contracts, channelKey)
+		delete(gm.nextPeer, channelKey)
+		delete(gm.dialing, channelKey)
+		if conn, exists := gm.connections[channelKey]; exists {
+			delete(gm.connections, channelKey)
+			conn.retire()
+			gm.retired[conn] = struct{}{}
+			retired[channelKey] = conn
+			replaced = append(replaced, channelKey)
+		}
+	}
+	gm.mu.Unlock()
+
+	gm.statesMu.Lock()
+	for channelKey := range gm.states {
+		if _, ok := cfg.GetChannelConfig(channelKey); !ok {
+			delete(gm.states, channelKey)
+		}
+	}
+	gm.statesMu.Unlock()
+
+	drainTimeout := cfg.Fabric.Connection.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+	for channelKey, conn := range retired {
+		go gm.drain(channelKey, conn, drainTimeout)
+	}
+	return replaced
+}
+
+// connectionChanged reports whether a channel needs a new connection to apply
+// its new settings. Timeouts, writability and optionality apply without one.
+func connectionChanged(old, updated config.ChannelConfig) bool {
+	old.Timeouts, updated.Timeouts = config.TimeoutsConfig{}, config.TimeoutsConfig{}
+	old.Writable, updated.Writable = nil, nil
+	old.Optional, updated.Optional = false, false
+	return !reflect.DeepEqual(old, updated)
+}
+
+// drain closes a retired connection once its calls in flight are done, or
+// when the timeout expires, whichever comes first.
+func (gm *GatewayManager) drain(channelKey string, conn *ChannelConnection, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	logger := log.With().
+		Str("channel", channelKey).
+		Str("endpoint", conn.Endpoint).
+		Logger()
+
+	select {
+	case <-conn.retire():
+		logger.Info().Msg("Retired peer connection drained")
+	case <-timer.C:
+		conn.leaseMu.Lock()
+		leases := conn.leases
+		conn.leaseMu.Unlock()
+		logger.Warn().
+			Int("inFlight", leases).
+			Dur("drainTimeout", timeout).
+			Msg("Closing retired peer connection with calls still in flight")
+	}
+
+	gm.mu.Lock()
+	delete(gm.retired, conn)
+	gm.mu.Unlock()
+	conn.close()
+}
@@ -0,0 +1,217 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// eventually polls cond until it holds or a second has passed.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConnectionLeases(t *testing.T) {
+	conn := &ChannelConnection{drained: make(chan struct{})}
+
+	if !conn.acquire() || !conn.acquire() {
+		t.Fatal("a live connection refused a lease")
+	}
+	drained := conn.retire()
+	if conn.acquire() {
+		t.Error("a retired connection took a new lease")
+	}
+
+	conn.release()
+	if isClosed(drained) {
+		t.Fatal("drained with a lease still held")
+	}
+	conn.release()
+	if !isClosed(drained) {
+		t.Fatal("not drained after the last lease was released")
+	}
+	if conn.retire() != drained {
+		t.Error("retiring twice returned another channel")
+	}
+
+	idle := &ChannelConnection{drained: make(chan struct{})}
+	if !isClosed(idle.retire()) {
+		t.Error("an idle connection was not drained on retirement")
+	}
+
+	evicted := &ChannelConnection{drained: make(chan struct{}), evicted: true}
+	if evicted.acquire() {
+		t.Error("an evicted connection took a new lease")
+	}
+
+	var none *ChannelConnection
+	none.release()
+}
+
+// newReloadNetwork starts a one-channel network and a manager connected to it.
+func newReloadNetwork(t *testing.T, drainTimeout time.Duration) (*fabrictest.Network, *GatewayManager, *config.Config) {
+	t.Helper()
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(network.Close)
+
+	cfg := &config.Config{Fabric: network.Config("union")}
+	cfg.Fabric.Connection.DrainTimeout = drainTimeout
+	gm := NewGatewayManager(cfg)
+	t.Cleanup(gm.Close)
+	return network, gm, cfg
+}
+
+// reconnecting returns a copy of cfg whose union channel needs a new
+// connection: it lists the primary peer again as a failover peer, which
+// changes the settings without changing where the channel connects.
+func reconnecting(network *fabrictest.Network, cfg *config.Config) *config.Config {
+	updated := &config.Config{Fabric: network.Config("union")}
+	updated.Fabric.Connection = cfg.Fabric.Connection
+	union := updated.Fabric.Channels["union"]
+	union.Peers = []config.PeerConfig{{Endpoint: union.PeerEndpoint, HostAlias: union.PeerHostAlias}}
+	updated.Fabric.Channels["union"] = union
+	return updated
+}
+
+func getChainInfo(conn *ChannelConnection) error {
+	_, err := conn.Network.GetContract("qscc").Evaluate("GetChainInfo", client.WithArguments(conn.ChannelCfg.Name))
+	return err
+}
+
+func TestReloadKeepsCallsInFlightOnTheirConnection(t *testing.T) {
+	network, gm, cfg := newReloadNetwork(t, time.Minute)
+	ctx := context.Background()
+
+	old, err := gm.hold(ctx, "union", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Settings that apply without a new connection keep it.
+	unchanged := &config.Config{Fabric: network.Config("union")}
+	unchanged.Fabric.Connection = cfg.Fabric.Connection
+	unchanged.Fabric.Timeouts.Evaluate = time.Minute
+	if replaced := gm.Reload(unchanged); len(replaced) != 0 {
+		t.Fatalf("a timeout change replaced %v", replaced)
+	}
+
+	if replaced := gm.Reload(reconnecting(network, cfg)); !slices.Equal(replaced, []string{"union"}) {
+		t.Fatalf("replaced = %v, want [union]", replaced)
+	}
+
+	if held, err := gm.hold(ctx, "union", old); err != nil || held != old {
+		t.Fatalf("a call in flight moved off its connection: %v", err)
+	}
+	if err := getChainInfo(old); err != nil {
+		t.Fatalf("the retired connection failed a call in flight: %v", err)
+	}
+
+	current, err := gm.hold(ctx, "union", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer current.release()
+	if current == old {
+		t.Fatal("a new call was given the retired connection")
+	}
+
+	old.release()
+	eventually(t, "the drained connection is closed", func() bool {
+		return old.GrpcConn.GetState() == connectivity.Shutdown
+	})
+	gm.mu.RLock()
+	retired := len(gm.retired)
+	gm.mu.RUnlock()
+	if retired != 0 {
+		t.Errorf("%d retired connections left after draining", retired)
+	}
+	if err := getChainInfo(current); err != nil {
+		t.Errorf("the new connection: %v", err)
+	}
+}
+
+func TestDrainTimeoutClosesBusyConnections(t *testing.T) {
+	network, gm, cfg := newReloadNetwork(t, 50*time.Millisecond)
+
+	old, err := gm.hold(context.Background(), "union", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gm.Reload(reconnecting(network, cfg))
+
+	eventually(t, "the drain timeout closes the connection", func() bool {
+		return old.GrpcConn.GetState() == connectivity.Shutdown
+	})
+	if isClosed(old.drained) {
+		t.Error("reported drained with a call still in flight")
+	}
+
+	// The call finishing late releases its lease without closing anything twice.
+	old.release()
+	if !isClosed(old.drained) {
+		t.Error("not drained after the last lease was released")
+	}
+}
+
+func TestEvictionDuringDrain(t *testing.T) {
+	network, gm, cfg := newReloadNetwork(t, time.Minute)
+	ctx := context.Background()
+
+	old, err := gm.hold(ctx, "union", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gm.Reload(reconnecting(network, cfg))
+
+	current, err := gm.GetConnection("union")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A transport failure on the retired connection must neither close it under
+	// its call in flight nor evict the channel's current connection.
+	gm.evict("union", old, errors.New("peer unreachable"))
+	if old.GrpcConn.GetState() == connectivity.Shutdown {
+		t.Fatal("eviction closed a draining connection")
+	}
+	if held, err := gm.hold(ctx, "union", old); err != nil || held != old {
+		t.Fatalf("the call in flight lost its connection: %v", err)
+	}
+	if conn, err := gm.GetConnection("union"); err != nil || conn != current {
+		t.Fatalf("eviction of a retired connection replaced the current one: %v", err)
+	}
+
+	old.release()
+	eventually(t, "the drained connection is closed", func() bool {
+		return old.GrpcConn.GetState() == connectivity.Shutdown
+	})
+}
@@ -0,0 +1,277 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/internal/logging"
+)
+
+// RetryPolicy bounds how often and for how long a Fabric operation is retried.
+// Budget caps the total time spent across all attempts, including backoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Budget         time.Duration
+}
+
+var (
+	DefaultEvaluatePolicy = RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Budget:         10 * time.Second,
+	}
+	DefaultSubmitPolicy = RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Budget:         90 * time.Second,
+	}
+)
+
+// withOverrides returns a copy of p with the non-zero fields of cfg applied.
+func (p RetryPolicy) withOverrides(cfg config.RetryPolicyConfig) RetryPolicy {
+	if cfg.MaxAttempts > 0 {
+		p.MaxAttempts = cfg.MaxAttempts
+	}
+	if cfg.InitialBackoff > 0 {
+		p.InitialBackoff = cfg.InitialBackoff
+	}
+	if cfg.MaxBackoff > 0 {
+		p.MaxBackoff = cfg.MaxBackoff
+	}
+	if cfg.Budget > 0 {
+		p.Budget = cfg.Budget
+	}
+	return p
+}
+
+// backoff returns a full-jitter delay before the given retry (1-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	ceiling := p.MaxBackoff
+	if shift := retry - 1; shift < 16 {
+		if d := p.InitialBackoff << shift; d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
+}
+
+// RetryPolicies resolves the policy for a transaction, applying per-operation
+// overrides keyed by transaction name. Keys are matched case-insensitively
+// because viper lowercases map keys.
+type RetryPolicies struct {
+	Evaluate   RetryPolicy
+	Submit     RetryPolicy
+	Operations map[string]config.RetryPolicyConfig
+}
+
+func NewRetryPolicies(cfg config.RetryConfig) *RetryPolicies {
+	operations := make(map[string]config.RetryPolicyConfig, len(cfg.Operations))
+	for name, override := range cfg.Operations {
+		operations[strings.ToLower(name)] = override
+	}
+
+	return &RetryPolicies{
+		Evaluate:   DefaultEvaluatePolicy.withOverrides(cfg.Evaluate),
+		Submit:     DefaultSubmitPolicy.withOverrides(cfg.Submit),
+		Operations: operations,
+	}
+}
+
+func (rp *RetryPolicies) evaluate(transaction string) RetryPolicy {
+	return rp.Evaluate.withOverrides(rp.Operations[strings.ToLower(transaction)])
+}
+
+func (rp *RetryPolicies) submit(transaction string) RetryPolicy {
+	return rp.Submit.withOverrides(rp.Operations[strings.ToLower(transaction)])
+}
+
+// RetryError wraps the final error of an operation that was attempted more
+// than once, so callers can report how many attempts were made.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RetryError) RetryAttempts() int {
+	return e.Attempts
+}
+
+// CommitError reports a transaction that was ordered but failed validation.
+type CommitError struct {
+	TransactionID string
+	Code          peer.TxValidationCode
+}
+
+func (e *CommitError) Error() string {
+	return fmt.Sprintf("transaction %s failed to commit with status code %d (%s)",
+		e.TransactionID, int32(e.Code), e.Code.String())
+}
+
+func (e *CommitError) TxID() string {
+	return e.TransactionID
+}
+
+func (e *CommitError) ValidationCode() string {
+	return e.Code.String()
+}
+
+// isRetriableStatus reports transport-level failures that are worth another
+// attempt: unreachable peers, overload and endorsement/evaluation timeouts.
+func isRetriableStatus(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// isRetriableValidation reports commit failures caused by concurrent writes.
+// They need a fresh endorsement against the updated state.
+func isRetriableValidation(code peer.TxValidationCode) bool {
+	return code == peer.TxValidationCode_MVCC_READ_CONFLICT ||
+		code == peer.TxValidationCode_PHANTOM_READ_CONFLICT
+}
+
+type retrier struct {
+	ctx         context.Context
+	kind        string
+	channel     string
+	transaction string
+	policy      RetryPolicy
+	deadline    time.Time
+	attempt     int
+}
+
+func newRetrier(ctx context.Context, kind, channel, transaction string, policy RetryPolicy) *retrier {
+	return &retrier{
+		ctx:         ctx,
+		kind:        kind,
+		channel:     channel,
+		transaction: transaction,
+		policy:      policy,
+		deadline:    time.Now().Add(policy.Budget),
+		attempt:     1,
+	}
+}
+
+// wait sleeps before the next attempt. It returns false when the attempt or
+// time budget is exhausted, or the caller's context is done.
+func (r *retrier) wait(err error) bool {
+	if r.attempt >= r.policy.MaxAttempts || r.ctx.Err() != nil {
+		return false
+	}
+	delay := r.policy.backoff(r.attempt)
+	if time.Now().Add(delay).After(r.deadline) {
+		return false
+	}
+	if deadline, ok := r.ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return false
+	}
+
+	logging.Ctx(r.ctx).Warn().
+		Err(err).
+		Str("kind", r.kind).
+		Str("channel", r.channel).
+		Str("transaction", r.transaction).
+		Int("attempt", r.attempt).
+		Dur("backoff", delay).
+		Msg("Retrying Fabric operation")
+
+	trace.SpanFromContext(r.ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("attempt", r.attempt),
+		attribute.String("error", err.Error()),
+		attribute.Int64("backoff_ms", delay.Milliseconds()),
+	))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.ctx.Done():
+		return false
+	}
+	r.attempt++
+	return true
+}
+
+func (r *retrier) succeeded() {
+	trace.SpanFromContext(r.ctx).SetAttributes(attribute.Int("fabric.attempts", r.attempt))
+	if counter, ok := r.ctx.Value(attemptCounterKey{}).(*AttemptCounter); ok {
+		counter.record(r.attempt)
+	}
+	if r.attempt > 1 {
+		logging.Ctx(r.ctx).Info().
+			Str("kind", r.kind).
+			Str("channel", r.channel).
+			Str("transaction", r.transaction).
+			Int("attempts", r.attempt).
+			Msg("Fabric operation succeeded after retry")
+	}
+}
+
+func (r *retrier) failed(err error) error {
+	trace.SpanFromContext(r.ctx).SetAttributes(attribute.Int("fabric.attempts", r.attempt))
+	if counter, ok := r.ctx.Value(attemptCounterKey{}).(*AttemptCounter); ok {
+		counter.record(r.attempt)
+	}
+	if r.attempt > 1 {
+		return &RetryError{Attempts: r.attempt, Err: err}
+	}
+	return err
+}
+
+type attemptCounterKey struct{}
+
+// AttemptCounter records the most attempts any one Fabric call made on behalf
+// of a request, so the API can report retries on successful responses too.
+type AttemptCounter struct {
+	max atomic.Int64
+}
+
+// WithAttemptCounter returns a context whose Fabric calls report their
+// attempts to the returned counter.
+func WithAttemptCounter(ctx context.Context) (context.Context, *AttemptCounter) {
+	counter := &AttemptCounter{}
+	return context.WithValue(ctx, attemptCounterKey{}, counter), counter
+}
+
+// Attempts returns the most attempts a single call needed: 0 when no Fabric
+// call was made, 1 when none was retried.
+func (c *AttemptCounter) Attempts() int {
+	return int(c.max.Load())
+}
+
+func (c *AttemptCounter) record(attempts int) {
+	for {
+		current := c.max.Load()
+		if int64(attempts) <= current || c.max.CompareAndSwap(current, int64(attempts)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
+
+	"github.com/gov-spending/backend/internal/config"
+	"github.com/gov-spending/backend/pkg/fabric/fabrictest"
+)
+
+func TestAttemptCounterKeepsTheMostAttempts(t *testing.T) {
+	ctx, counter := WithAttemptCounter(context.Background())
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Budget: time.Second}
+	transient := errors.New("unavailable")
+
+	if counter.Attempts() != 0 {
+		t.Fatalf("attempts before any call = %d, want 0", counter.Attempts())
+	}
+
+	first := newRetrier(ctx, "evaluate", "union", "GetDocument", policy)
+	first.succeeded()
+	if counter.Attempts() != 1 {
+		t.Fatalf("attempts after a first-try success = %d, want 1", counter.Attempts())
+	}
+
+	retried := newRetrier(ctx, "submit", "union", "CreateDocument", policy)
+	for retried.wait(transient) {
+	}
+	if err := retried.failed(transient); !errors.Is(err, transient) {
+		t.Fatalf("failed() = %v, want it to wrap the last error", err)
+	}
+	if counter.Attempts() != 3 {
+		t.Fatalf("attempts after exhausting retries = %d, want 3", counter.Attempts())
+	}
+
+	newRetrier(ctx, "evaluate", "union", "GetDocument", policy).succeeded()
+	if counter.Attempts() != 3 {
+		t.Errorf("a later first-try call lowered the attempts to %d", counter.Attempts())
+	}
+
+	// Calls outside a counted request record nowhere.
+	newRetrier(context.Background(), "evaluate", "union", "GetDocument", policy).succeeded()
+}
+
+// newRetryNetwork starts a one-channel network running the contract and
+// returns the channel's contract, which retries submits without backing off.
+func newRetryNetwork(t *testing.T) (*fabrictest.Network, *Contract) {
+	t.Helper()
+	network, err := fabrictest.NewNetwork(t.TempDir(), "spending",
+		fabrictest.Channel{Key: "union", Name: "union-channel", MspID: "UnionMSP", Domain: "union.gov.br"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(network.Close)
+	if err := network.StartChaincode(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Fabric: network.Config("union")}
+	cfg.Fabric.Retry.Submit = config.RetryPolicyConfig{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	gm := NewGatewayManager(cfg)
+	t.Cleanup(gm.Close)
+	contract, err := gm.GetContract("union")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network, contract
+}
+
+// typeArgs are RegisterDocumentType's arguments for a new type.
+func typeArgs(id string) []string {
+	return []string{id, "Federal Expense", "Direct federal spending", `["category"]`, `["vendor"]`}
+}
+
+// callsTo lists, in order, the transaction IDs of the network's calls to a
+// gateway method, restricted to one function when it is set.
+func callsTo(network *fabrictest.Network, method, function string) []string {
+	var txIDs []string
+	for _, call := range network.Calls() {
+		if call.Method == method && (function == "" || call.Function == function) {
+			txIDs = append(txIDs, call.TxID)
+		}
+	}
+	return txIDs
+}
+
+// lookedUpBetweenSubmits reports whether the transaction was looked up after
+// its first submit and before any later one.
+func lookedUpBetweenSubmits(network *fabrictest.Network) bool {
+	submits := 0
+	for _, call := range network.Calls() {
+		switch {
+		case call.Method == fabrictest.MethodSubmit:
+			if submits++; submits > 1 {
+				return false
+			}
+		case call.Function == "GetTransactionByID":
+			return submits == 1
+		}
+	}
+	return false
+}
+
+// committedCode is the code a transaction was committed with.
+func committedCode(t *testing.T, contract *Contract, txID string) peer.TxValidationCode {
+	t.Helper()
+	st, found, err := contract.CommitStatus(context.Background(), txID)
+	if err != nil || !found {
+		t.Fatalf("transaction %s: found = %v, err = %v", txID, found, err)
+	}
+	return st.Code
+}
+
+func TestSubmitResendsTheSameEnvelopeWhenOrderingFails(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Status: codes.Unavailable, Times: 1})
+
+	if _, err := contract.SubmitTransaction(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	endorsed := callsTo(network, fabrictest.MethodEndorse, "RegisterDocumentType")
+	if len(endorsed) != 1 {
+		t.Fatalf("endorsements = %v, want one", endorsed)
+	}
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); !slices.Equal(submitted, []string{endorsed[0], endorsed[0]}) {
+		t.Errorf("submits = %v, want %s sent twice", submitted, endorsed[0])
+	}
+	if !lookedUpBetweenSubmits(network) {
+		t.Error("the envelope was resent without looking the transaction up first")
+	}
+	if code := committedCode(t, contract, endorsed[0]); code != peer.TxValidationCode_VALID {
+		t.Errorf("committed as %s", code)
+	}
+}
+
+func TestSubmitFindsATransactionCommittedBeforeItsReplyWasLost(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Status: codes.Unavailable, AfterServing: true, Times: 1})
+
+	if _, err := contract.SubmitTransaction(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	endorsed := callsTo(network, fabrictest.MethodEndorse, "RegisterDocumentType")
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); len(endorsed) != 1 || !slices.Equal(submitted, endorsed) {
+		t.Errorf("endorsements = %v, submits = %v, want one of each", endorsed, submitted)
+	}
+	if len(callsTo(network, fabrictest.MethodEvaluate, "GetTransactionByID")) == 0 {
+		t.Error("the transaction was not looked up")
+	}
+}
+
+func TestSubmitTreatsADuplicateOfACommittedTransactionAsSuccess(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	// The first copy is committed but its reply lost, and the lookup fails
+	// too: the envelope is resent and reported as a duplicate.
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Status: codes.Unavailable, AfterServing: true, Times: 1})
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodEvaluate, Function: "GetTransactionByID", Status: codes.Unavailable, Times: 1})
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodCommitStatus, Validation: peer.TxValidationCode_DUPLICATE_TXID, Times: 1})
+
+	if _, err := contract.SubmitTransaction(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	endorsed := callsTo(network, fabrictest.MethodEndorse, "RegisterDocumentType")
+	if len(endorsed) != 1 {
+		t.Fatalf("endorsements = %v, want one", endorsed)
+	}
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); !slices.Equal(submitted, []string{endorsed[0], endorsed[0]}) {
+		t.Errorf("submits = %v, want %s sent twice", submitted, endorsed[0])
+	}
+	if code := committedCode(t, contract, endorsed[0]); code != peer.TxValidationCode_VALID {
+		t.Errorf("committed as %s", code)
+	}
+}
+
+func TestSubmitReendorsesAfterAReadConflict(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Validation: peer.TxValidationCode_MVCC_READ_CONFLICT, Times: 1})
+
+	if _, err := contract.SubmitTransaction(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	endorsed := callsTo(network, fabrictest.MethodEndorse, "RegisterDocumentType")
+	if len(endorsed) != 2 || endorsed[0] == endorsed[1] {
+		t.Fatalf("endorsements = %v, want two transactions", endorsed)
+	}
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); !slices.Equal(submitted, endorsed) {
+		t.Errorf("submits = %v, want each endorsement sent once", submitted)
+	}
+	if code := committedCode(t, contract, endorsed[0]); code != peer.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Errorf("first transaction committed as %s, want MVCC_READ_CONFLICT", code)
+	}
+	if code := committedCode(t, contract, endorsed[1]); code != peer.TxValidationCode_VALID {
+		t.Errorf("second transaction committed as %s, want VALID", code)
+	}
+}
+
+func TestSubmitAsyncResendsTheSameEnvelopeWhenOrderingFails(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Status: codes.Unavailable, Times: 1})
+
+	pending, err := contract.SubmitAsync(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	st, err := pending.Wait()
+	if err != nil || !st.Valid() {
+		t.Fatalf("commit = %+v, %v; want VALID", st, err)
+	}
+
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); !slices.Equal(submitted, []string{pending.TransactionID, pending.TransactionID}) {
+		t.Errorf("submits = %v, want %s sent twice", submitted, pending.TransactionID)
+	}
+	if !lookedUpBetweenSubmits(network) {
+		t.Error("the envelope was resent without looking the transaction up first")
+	}
+}
+
+func TestSubmitAsyncFindsATransactionCommittedBeforeItsReplyWasLost(t *testing.T) {
+	network, contract := newRetryNetwork(t)
+	network.Inject(fabrictest.Fault{Method: fabrictest.MethodSubmit, Status: codes.Unavailable, AfterServing: true, Times: 1})
+
+	pending, err := contract.SubmitAsync(context.Background(), "RegisterDocumentType", typeArgs("federal-expense")...)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	st, err := pending.Wait()
+	if err != nil || !st.Valid() {
+		t.Fatalf("commit = %+v, %v; want VALID", st, err)
+	}
+
+	if submitted := callsTo(network, fabrictest.MethodSubmit, ""); !slices.Equal(submitted, []string{pending.TransactionID}) {
+		t.Errorf("submits = %v, want %s sent once", submitted, pending.TransactionID)
+	}
+}
@@ -0,0 +1,87 @@
+package fabric
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+// Timeouts bounds each gateway call of a transaction. They apply per attempt;
+// the retry budget bounds the whole operation.
+type Timeouts struct {
+	Evaluate     time.Duration
+	Endorse      time.Duration
+	Submit       time.Duration
+	CommitStatus time.Duration
+}
+
+var DefaultTimeouts = Timeouts{
+	Evaluate:     5 * time.Second,
+	Endorse:      15 * time.Second,
+	Submit:       5 * time.Second,
+	CommitStatus: 1 * time.Minute,
+}
+
+// withOverrides returns a copy of t with the non-zero fields of cfg applied.
+func (t Timeouts) withOverrides(cfg config.TimeoutConfig) Timeouts {
+	if cfg.Evaluate > 0 {
+		t.Evaluate = cfg.Evaluate
+	}
+	if cfg.Endorse > 0 {
+		t.Endorse = cfg.Endorse
+	}
+	if cfg.Submit > 0 {
+		t.Submit = cfg.Submit
+	}
+	if cfg.CommitStatus > 0 {
+		t.CommitStatus = cfg.CommitStatus
+	}
+	return t
+}
+
+// TimeoutPolicies resolves the timeouts of a transaction on a channel. From
+// least to most specific: defaults, fabric.timeouts, its per-operation entry,
+// the channel's timeouts and the channel's per-operation entry. Operation keys
+// are matched case-insensitively because viper lowercases map keys.
+type TimeoutPolicies struct {
+	global   config.TimeoutsConfig
+	channels map[string]config.TimeoutsConfig
+}
+
+func NewTimeoutPolicies(cfg *config.Config) *TimeoutPolicies {
+	channels := make(map[string]config.TimeoutsConfig, len(cfg.Fabric.Channels))
+	for key, channelCfg := range cfg.Fabric.Channels {
+		channels[key] = lowerOperations(channelCfg.Timeouts)
+	}
+	return &TimeoutPolicies{
+		global:   lowerOperations(cfg.Fabric.Timeouts),
+		channels: channels,
+	}
+}
+
+func lowerOperations(cfg config.TimeoutsConfig) config.TimeoutsConfig {
+	operations := make(map[string]config.TimeoutConfig, len(cfg.Operations))
+	for name, override := range cfg.Operations {
+		operations[strings.ToLower(name)] = override
+	}
+	cfg.Operations = operations
+	return cfg
+}
+
+// Channel returns the channel's timeouts without operation overrides, used as
+// the gateway defaults for calls made outside the Contract wrapper.
+func (tp *TimeoutPolicies) Channel(channelKey string) Timeouts {
+	return tp.Resolve(channelKey, "")
+}
+
+func (tp *TimeoutPolicies) Resolve(channelKey, transaction string) Timeouts {
+	transaction = strings.ToLower(transaction)
+	channel := tp.channels[channelKey]
+
+	return DefaultTimeouts.
+		withOverrides(tp.global.TimeoutConfig).
+		withOverrides(tp.global.Operations[transaction]).
+		withOverrides(channel.TimeoutConfig).
+		withOverrides(channel.Operations[transaction])
+}
@@ -0,0 +1,331 @@
+package fabric
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/gov-spending/backend/internal/config"
+)
+
+// Problems reported by CertificateError.
+const (
+	CertUnreadable       = "unreadable"
+	CertExpired          = "expired"
+	CertNotYetValid      = "not_yet_valid"
+	CertKeyMismatch      = "key_mismatch"
+	CertUnknownAuthority = "unknown_authority"
+	CertNameMismatch     = "name_mismatch"
+	CertInvalid          = "invalid"
+)
+
+// CertificateError reports a certificate that failed validation: one of the
+// files configured for a channel, or the certificate a peer presented, in
+// which case Peer is set and File is the CA bundle it was checked against.
+type CertificateError struct {
+	File      string
+	Peer      string
+	Problem   string
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// ExpectedName and Names are set on a name mismatch: the peer host alias
+	// dialed and the names the peer's certificate is valid for.
+	ExpectedName string
+	Names        []string
+	Err          error
+}
+
+func (e *CertificateError) Error() string {
+	var b strings.Builder
+	if e.Peer != "" {
+		fmt.Fprintf(&b, "certificate of peer %s (checked against %s): ", e.Peer, e.File)
+	} else {
+		fmt.Fprintf(&b, "certificate %s: ", e.File)
+	}
+	b.WriteString(strings.ReplaceAll(e.Problem, "_", " "))
+	switch e.Problem {
+	case CertExpired:
+		fmt.Fprintf(&b, " at %s", e.NotAfter.UTC().Format(time.RFC3339))
+	case CertNotYetValid:
+		fmt.Fprintf(&b, " before %s", e.NotBefore.UTC().Format(time.RFC3339))
+	case CertNameMismatch:
+		fmt.Fprintf(&b, ": %s is not one of [%s]", e.ExpectedName, strings.Join(e.Names, ", "))
+	}
+	if e.Subject != "" {
+		fmt.Fprintf(&b, " (subject %s)", e.Subject)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	return b.String()
+}
+
+func (e *CertificateError) Unwrap() error {
+	return e.Err
+}
+
+// CertificateContext returns the diagnostics to attach to the API error.
+func (e *CertificateError) CertificateContext() map[string]interface{} {
+	ctx := map[string]interface{}{
+		"certificateFile":    e.File,
+		"certificateProblem": e.Problem,
+	}
+	if e.Peer != "" {
+		ctx["certificatePeer"] = e.Peer
+	}
+	if !e.NotAfter.IsZero() {
+		ctx["certificateExpiresAt"] = e.NotAfter.UTC().Format(time.RFC3339)
+	}
+	if e.ExpectedName != "" {
+		ctx["expectedName"] = e.ExpectedName
+		ctx["certificateNames"] = e.Names
+	}
+	return ctx
+}
+
+// transportCredentials returns the credentials to dial a peer with:
+// plaintext, TLS against the configured CA bundle or the peer's crypto-config
+// CA, and the client certificate for mutual TLS when one is configured. Peer
+// certificates are verified by verifyPeer, which records why a handshake
+// failed in handshake, since gRPC only reports a failed connection.
+func (gm *GatewayManager) transportCredentials(channelCfg config.ChannelConfig, peer config.PeerConfig, handshake *handshakeError) (credentials.TransportCredentials, error) {
+	if !channelCfg.UsesTLS() {
+		return insecure.NewCredentials(), nil
+	}
+	cfg := gm.current().config
+
+	caFile := cfg.NetworkFile(channelCfg.TLS.CACert)
+	if caFile == "" {
+		caFile = filepath.Join(cfg.Fabric.NetworkPath, "crypto-config", channelCfg.CryptoPath,
+			"peers", peer.HostAlias, "tls", "ca.crt")
+	}
+	roots, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: peer.HostAlias,
+		// Verification is done by VerifyConnection, to report its failures.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			err := verifyPeer(state, roots, peer.HostAlias, caFile)
+			handshake.set(err)
+			return err
+		},
+	}
+
+	if channelCfg.TLS.ClientCert != "" {
+		clientCert, err := loadClientCertificate(cfg.NetworkFile(channelCfg.TLS.ClientCert), cfg.NetworkFile(channelCfg.TLS.ClientKey))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &CertificateError{File: path, Problem: CertUnreadable, Err: err}
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, &CertificateError{File: path, Problem: CertUnreadable, Err: errors.New("no PEM certificate found")}
+	}
+	return pool, nil
+}
+
+// loadClientCertificate loads the mutual TLS key pair and checks that the key
+// belongs to the certificate and that the certificate is currently valid, so
+// either problem is reported as such rather than as a peer rejecting the
+// handshake.
+func loadClientCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, &CertificateError{File: certFile, Problem: CertUnreadable, Err: err}
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, &CertificateError{File: keyFile, Problem: CertUnreadable, Err: err}
+	}
+
+	var chain [][]byte
+	for rest := certPEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			chain = append(chain, block.Bytes)
+		}
+	}
+	if len(chain) == 0 {
+		return tls.Certificate{}, &CertificateError{File: certFile, Problem: CertUnreadable, Err: errors.New("no PEM certificate found")}
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return tls.Certificate{}, &CertificateError{File: certFile, Problem: CertUnreadable, Err: err}
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return tls.Certificate{}, &CertificateError{File: keyFile, Problem: CertUnreadable, Err: err}
+	}
+	public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(leaf.PublicKey) {
+		return tls.Certificate{}, &CertificateError{
+			File:    keyFile,
+			Problem: CertKeyMismatch,
+			Subject: leaf.Subject.String(),
+			Err:     fmt.Errorf("private key does not match the public key of %s", certFile),
+		}
+	}
+
+	if err := checkValidity(certFile, leaf, time.Now()); err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: chain, PrivateKey: key, Leaf: leaf}, nil
+}
+
+// parsePrivateKey reads the first private key in PEM data, in PKCS #8, SEC 1
+// or PKCS #1 form.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	for rest := data; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			return nil, errors.New("no PEM private key found")
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unsupported private key type %T", key)
+			}
+			return signer, nil
+		}
+		if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unsupported %s block", block.Type)
+	}
+}
+
+func checkValidity(file string, cert *x509.Certificate, now time.Time) error {
+	problem := ""
+	switch {
+	case now.Before(cert.NotBefore):
+		problem = CertNotYetValid
+	case now.After(cert.NotAfter):
+		problem = CertExpired
+	default:
+		return nil
+	}
+	return &CertificateError{
+		File:      file,
+		Problem:   problem,
+		Subject:   cert.Subject.String(),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+}
+
+// verifyPeer does the verification crypto/tls would, and classifies its
+// failure. The chain is checked before the name, so a certificate from the
+// wrong CA is not reported as a name mismatch.
+func verifyPeer(state tls.ConnectionState, roots *x509.CertPool, serverName, caFile string) error {
+	if len(state.PeerCertificates) == 0 {
+		return &CertificateError{File: caFile, Peer: serverName, Problem: CertInvalid, Err: errors.New("peer presented no certificate")}
+	}
+	leaf := state.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err == nil {
+		err = leaf.VerifyHostname(serverName)
+	}
+	if err == nil {
+		return nil
+	}
+
+	certErr := &CertificateError{
+		File:      caFile,
+		Peer:      serverName,
+		Problem:   CertInvalid,
+		Subject:   leaf.Subject.String(),
+		NotBefore: leaf.NotBefore,
+		NotAfter:  leaf.NotAfter,
+		Err:       err,
+	}
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var unknown x509.UnknownAuthorityError
+	switch {
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		certErr.Problem = CertExpired
+		if time.Now().Before(invalid.Cert.NotBefore) {
+			certErr.Problem = CertNotYetValid
+		}
+		certErr.Subject = invalid.Cert.Subject.String()
+		certErr.NotBefore, certErr.NotAfter = invalid.Cert.NotBefore, invalid.Cert.NotAfter
+	case errors.As(err, &hostname):
+		certErr.Problem = CertNameMismatch
+		certErr.ExpectedName = hostname.Host
+		certErr.Names = certificateNames(hostname.Certificate)
+	case errors.As(err, &unknown):
+		certErr.Problem = CertUnknownAuthority
+	}
+	return certErr
+}
+
+func certificateNames(cert *x509.Certificate) []string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// handshakeError keeps the last peer certificate failure of a connection.
+type handshakeError struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (h *handshakeError) set(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.err = err
+}
+
+func (h *handshakeError) get() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
@@ -0,0 +1,238 @@
+package fabric
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates signed by a freshly generated key.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+var testSerial int64
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newTestCA returns a self-signed root CA.
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key := newTestKey(t)
+	tmpl := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	return &testCA{cert: signCertificate(t, tmpl, &key.PublicKey, tmpl, key), key: key}
+}
+
+func signCertificate(t *testing.T, tmpl *x509.Certificate, public *ecdsa.PublicKey, parent *x509.Certificate, signer *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+	testSerial++
+	tmpl.SerialNumber = big.NewInt(testSerial)
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, public, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// intermediate returns a CA signed by ca.
+func (ca *testCA) intermediate(t *testing.T, name string) *testCA {
+	t.Helper()
+	key := newTestKey(t)
+	tmpl := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	return &testCA{cert: signCertificate(t, tmpl, &key.PublicKey, ca.cert, ca.key), key: key}
+}
+
+// issue returns a TLS certificate for dnsName valid from notBefore to notAfter.
+func (ca *testCA) issue(t *testing.T, dnsName string, notBefore, notAfter time.Time) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key := newTestKey(t)
+	tmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsName},
+		DNSNames:    []string{dnsName},
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	return signCertificate(t, tmpl, &key.PublicKey, ca.cert, ca.key), key
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func TestVerifyPeer(t *testing.T) {
+	root := newTestCA(t, "tlsca.union.gov.br")
+	intermediate := root.intermediate(t, "intermediate.union.gov.br")
+	now := time.Now()
+
+	valid, _ := intermediate.issue(t, "peer0.union.gov.br", now.Add(-time.Hour), now.Add(time.Hour))
+	expired, _ := root.issue(t, "peer0.union.gov.br", now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	future, _ := root.issue(t, "peer0.union.gov.br", now.Add(time.Hour), now.Add(48*time.Hour))
+	foreign, _ := newTestCA(t, "tlsca.evil.example").issue(t, "peer0.union.gov.br", now.Add(-time.Hour), now.Add(time.Hour))
+	renamed, _ := root.issue(t, "peer1.union.gov.br", now.Add(-time.Hour), now.Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		chain   []*x509.Certificate
+		problem string
+	}{
+		{name: "valid chain", chain: []*x509.Certificate{valid, intermediate.cert}},
+		{name: "missing intermediate", chain: []*x509.Certificate{valid}, problem: CertUnknownAuthority},
+		{name: "expired", chain: []*x509.Certificate{expired}, problem: CertExpired},
+		{name: "not yet valid", chain: []*x509.Certificate{future}, problem: CertNotYetValid},
+		{name: "wrong CA", chain: []*x509.Certificate{foreign}, problem: CertUnknownAuthority},
+		{name: "SAN mismatch", chain: []*x509.Certificate{renamed}, problem: CertNameMismatch},
+		{name: "no certificate", problem: CertInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyPeer(tls.ConnectionState{PeerCertificates: tt.chain}, root.pool(), "peer0.union.gov.br", "ca.crt")
+			if tt.problem == "" {
+				if err != nil {
+					t.Fatalf("verifyPeer: %v", err)
+				}
+				return
+			}
+			var certErr *CertificateError
+			if !errors.As(err, &certErr) {
+				t.Fatalf("verifyPeer = %v, want a CertificateError", err)
+			}
+			if certErr.Problem != tt.problem || certErr.Peer != "peer0.union.gov.br" || certErr.File != "ca.crt" {
+				t.Errorf("verifyPeer = %+v, want problem %s", certErr, tt.problem)
+			}
+		})
+	}
+
+	err := verifyPeer(tls.ConnectionState{PeerCertificates: []*x509.Certificate{renamed}}, root.pool(), "peer0.union.gov.br", "ca.crt")
+	var certErr *CertificateError
+	if !errors.As(err, &certErr) {
+		t.Fatalf("verifyPeer = %v, want a CertificateError", err)
+	}
+	if certErr.ExpectedName != "peer0.union.gov.br" || len(certErr.Names) != 1 || certErr.Names[0] != "peer1.union.gov.br" {
+		t.Errorf("name mismatch = expected %q, names %v, want peer0 against [peer1]", certErr.ExpectedName, certErr.Names)
+	}
+}
+
+// writePEM writes blocks of the given type to a file in dir.
+func writePEM(t *testing.T, dir, name, blockType string, blocks ...[]byte) string {
+	t.Helper()
+	var data []byte
+	for _, b := range blocks {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b})...)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, "tlsca.union.gov.br")
+	now := time.Now()
+
+	cert, key := ca.issue(t, "backend.union.gov.br", now.Add(-time.Hour), now.Add(time.Hour))
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := x509.MarshalPKCS8PrivateKey(newTestKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, expiredKey := ca.issue(t, "backend.union.gov.br", now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	expiredPKCS8, err := x509.MarshalPKCS8PrivateKey(expiredKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := writePEM(t, dir, "client.crt", "CERTIFICATE", cert.Raw, ca.cert.Raw)
+	keyFile := writePEM(t, dir, "client.key", "PRIVATE KEY", pkcs8)
+	sec1File := writePEM(t, dir, "client-ec.key", "EC PRIVATE KEY", sec1)
+	otherFile := writePEM(t, dir, "other.key", "PRIVATE KEY", other)
+	expiredFile := writePEM(t, dir, "expired.crt", "CERTIFICATE", expired.Raw)
+	expiredKeyFile := writePEM(t, dir, "expired.key", "PRIVATE KEY", expiredPKCS8)
+	garbageFile := writePEM(t, dir, "garbage.key", "PRIVATE KEY", []byte("not a key"))
+	missing := filepath.Join(dir, "missing.key")
+
+	for _, file := range []string{keyFile, sec1File} {
+		pair, err := loadClientCertificate(certFile, file)
+		if err != nil {
+			t.Fatalf("%s: %v", filepath.Base(file), err)
+		}
+		if len(pair.Certificate) != 2 || pair.Leaf == nil || !pair.Leaf.Equal(cert) {
+			t.Errorf("%s: loaded %d certificates with leaf %v, want the chain of 2", filepath.Base(file), len(pair.Certificate), pair.Leaf)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		file     string
+		problem  string
+	}{
+		{name: "key of another certificate", certFile: certFile, keyFile: otherFile, file: otherFile, problem: CertKeyMismatch},
+		{name: "expired", certFile: expiredFile, keyFile: expiredKeyFile, file: expiredFile, problem: CertExpired},
+		{name: "missing key", certFile: certFile, keyFile: missing, file: missing, problem: CertUnreadable},
+		{name: "malformed key", certFile: certFile, keyFile: garbageFile, file: garbageFile, problem: CertUnreadable},
+		{name: "key given as the certificate", certFile: keyFile, keyFile: keyFile, file: keyFile, problem: CertUnreadable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadClientCertificate(tt.certFile, tt.keyFile)
+			var certErr *CertificateError
+			if !errors.As(err, &certErr) {
+				t.Fatalf("loadClientCertificate = %v, want a CertificateError", err)
+			}
+			if certErr.Problem != tt.problem || certErr.File != tt.file {
+				t.Errorf("loadClientCertificate = %s in %s, want %s in %s", certErr.Problem, certErr.File, tt.problem, tt.file)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -69,29 +71,209 @@ type Document struct {
 }
 
 type QueryFilter struct {
-	OrganizationID  string         `json:"organizationId,omitempty"`
-	DocumentTypeID  string         `json:"documentTypeId,omitempty"`
-	Status          DocumentStatus `json:"status,omitempty"`
-	FromDate        string         `json:"fromDate,omitempty"`
-	ToDate          string         `json:"toDate,omitempty"`
-	MinAmount       float64        `json:"minAmount,omitempty"`
-	MaxAmount       float64        `json:"maxAmount,omitempty"`
-	HasLinkedDoc    *bool          `json:"hasLinkedDoc,omitempty"`
-	LinkedDirection string         `json:"linkedDirection,omitempty"`
-	PageSize        int            `json:"pageSize,omitempty"`
-	Bookmark        string         `json:"bookmark,omitempty"`
+	OrganizationID  string           `json:"organizationId,omitempty"`
+	DocumentTypeID  string           `json:"documentTypeId,omitempty"`
+	Status          DocumentStatus   `json:"status,omitempty"`
+	FromDate        string           `json:"fromDate,omitempty"`
+	ToDate          string           `json:"toDate,omitempty"`
+	MinAmount       float64          `json:"minAmount,omitempty"`
+	MaxAmount       float64          `json:"maxAmount,omitempty"`
+	HasLinkedDoc    *bool            `json:"hasLinkedDoc,omitempty"`
+	LinkedDirection string           `json:"linkedDirection,omitempty"`
+	SortBy          string           `json:"sortBy,omitempty"`
+	SortOrder       string           `json:"sortOrder,omitempty"`
+	CountMode       string           `json:"countMode,omitempty"`
+	Conditions      []FieldCondition `json:"conditions,omitempty"`
+	PageSize        int              `json:"pageSize,omitempty"`
+	Bookmark        string           `json:"bookmark,omitempty"`
 }
 
+// FieldCondition filters on a document field or a declared key inside Data
+// ("data.<key>"). Only whitelisted fields and operators are accepted.
+type FieldCondition struct {
+	Field  string        `json:"field"`
+	Op     string        `json:"op"`
+	Value  interface{}   `json:"value,omitempty"`
+	Values []interface{} `json:"values,omitempty"`
+}
+
+// QueryResult is a page of documents. Total is set only when the filter asks
+// for a count; the contract API does not allow a *int to mark it absent.
+// TotalEstimated is set when an estimated count stopped at
+// estimatedCountLimit matches, so Total is a lower bound.
 type QueryResult struct {
-	Documents []*Document `json:"documents"`
-	Bookmark  string      `json:"bookmark,omitempty"`
-	Total     int         `json:"total"`
+	Documents      []*Document `json:"documents"`
+	Bookmark       string      `json:"bookmark,omitempty" metadata:",optional"`
+	Count          int         `json:"count"`
+	Total          int         `json:"total" metadata:",optional"`
+	TotalEstimated bool        `json:"totalEstimated,omitempty" metadata:",optional"`
+
+	counted bool
+}
+
+// MarshalJSON leaves total out unless it was counted, so that a count of zero
+// is still reported.
+func (r QueryResult) MarshalJSON() ([]byte, error) {
+	type fields QueryResult
+	out := struct {
+		fields
+		Total *int `json:"total,omitempty"`
+	}{fields: fields(r)}
+	if r.counted {
+		out.Total = &r.Total
+	}
+	return json.Marshal(out)
+}
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	CountExact     = "exact"
+	CountEstimated = "estimated"
+
+	OpEq     = "eq"
+	OpIn     = "in"
+	OpGt     = "gt"
+	OpGte    = "gte"
+	OpLt     = "lt"
+	OpLte    = "lte"
+	OpExists = "exists"
+
+	DataFieldPrefix = "data."
+
+	maxConditions = 20
+	maxInValues   = 100
+
+	// estimatedCountLimit caps how many matches an estimated count will read.
+	// When the cap is reached the total is reported as a lower bound.
+	estimatedCountLimit = 1000
+)
+
+// sortIndexes maps each sortable field to the CouchDB index (design doc, name)
+// declared under META-INF/statedb/couchdb/indexes that serves it.
+var sortIndexes = map[string][2]string{
+	"createdAt": {"indexCreatedAtDoc", "indexCreatedAt"},
+	"updatedAt": {"indexUpdatedAtDoc", "indexUpdatedAt"},
+	"amount":    {"indexAmountDoc", "indexAmount"},
+	"title":     {"indexTitleDoc", "indexTitle"},
+}
+
+// conditionFields are the top-level document fields a condition may target.
+var conditionFields = map[string]bool{
+	"title":           true,
+	"description":     true,
+	"amount":          true,
+	"currency":        true,
+	"status":          true,
+	"organizationId":  true,
+	"documentTypeId":  true,
+	"contentHash":     true,
+	"linkedDocId":     true,
+	"linkedChannel":   true,
+	"linkedDocHash":   true,
+	"linkedDirection": true,
+	"correctedByDoc":  true,
+	"createdAt":       true,
+	"createdBy":       true,
+	"updatedAt":       true,
+	"updatedBy":       true,
+}
+
+// conditionOperators maps condition operators to CouchDB selector operators.
+var conditionOperators = map[string]string{
+	OpEq:     "$eq",
+	OpIn:     "$in",
+	OpGt:     "$gt",
+	OpGte:    "$gte",
+	OpLt:     "$lt",
+	OpLte:    "$lte",
+	OpExists: "$exists",
+}
+
+var dataKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+
+// Chaincode events emitted by write transactions.
+const (
+	EventDocumentTypeRegistered  = "DocumentTypeRegistered"
+	EventDocumentTypeDeactivated = "DocumentTypeDeactivated"
+	EventDocumentCreated         = "DocumentCreated"
+	EventDocumentInvalidated     = "DocumentInvalidated"
+	EventDocumentLinkUpdated     = "DocumentLinkUpdated"
+
+	// TransientRequestID is the transient data key under which the API passes
+	// the ID of the HTTP request that submitted the transaction.
+	TransientRequestID = "request_id"
+)
+
+// ChaincodeEvent is the payload of every chaincode event.
+type ChaincodeEvent struct {
+	ID        string `json:"id"`
+	TxID      string `json:"txId"`
+	RequestID string `json:"requestId,omitempty"`
 }
 
 func now() string {
 	return time.Now().UTC().Format(time.RFC3339)
 }
 
+// =============================================================================
+// Errors
+// =============================================================================
+
+// ErrorCode values are part of the contract's API: clients branch on them, so
+// existing codes must not be renamed.
+type ErrorCode string
+
+const (
+	ErrNotFound            ErrorCode = "NOT_FOUND"
+	ErrAlreadyExists       ErrorCode = "ALREADY_EXISTS"
+	ErrValidationFailed    ErrorCode = "VALIDATION_FAILED"
+	ErrInvalidDocumentType ErrorCode = "INVALID_DOCUMENT_TYPE"
+	ErrInvalidQuery        ErrorCode = "INVALID_QUERY"
+	ErrInvalidState        ErrorCode = "INVALID_STATE"
+	ErrPermissionDenied    ErrorCode = "PERMISSION_DENIED"
+	ErrInternal            ErrorCode = "INTERNAL_ERROR"
+)
+
+// ContractError is returned by transactions. Its Error() is the JSON payload,
+// which the peer passes through as the chaincode response message.
+type ContractError struct {
+	Code    ErrorCode         `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func (e *ContractError) Error() string {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return string(e.Code) + ": " + e.Message
+	}
+	return string(data)
+}
+
+func (e *ContractError) with(key, value string) *ContractError {
+	if e.Fields == nil {
+		e.Fields = make(map[string]string)
+	}
+	e.Fields[key] = value
+	return e
+}
+
+func newError(code ErrorCode, format string, args ...interface{}) *ContractError {
+	return &ContractError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func notFoundError(resource, id string) *ContractError {
+	return newError(ErrNotFound, "%s %s not found", resource, id).
+		with("resource", resource).
+		with("id", id)
+}
+
+func internalError(format string, args ...interface{}) *ContractError {
+	return newError(ErrInternal, format, args...)
+}
+
 // =============================================================================
 // Document Type Management
 // =============================================================================
@@ -109,7 +291,7 @@ func (s *SpendingContract) RegisterDocumentType(ctx contractapi.TransactionConte
 			return err
 		}
 		if existing.IsActive {
-			return fmt.Errorf("document type %s already exists", id)
+			return newError(ErrAlreadyExists, "document type %s already exists", id).with("resource", "documentType").with("id", id)
 		}
 
 		orgID, err := s.getClientOrg(ctx)
@@ -117,25 +299,28 @@ func (s *SpendingContract) RegisterDocumentType(ctx contractapi.TransactionConte
 			return err
 		}
 		if existing.OrganizationID != orgID {
-			return fmt.Errorf("only the owning organization can reactivate a document type")
+			return newError(ErrPermissionDenied, "only the owning organization can reactivate a document type").with("id", id)
 		}
 
 		var requiredFields, optionalFields []string
 		if err := json.Unmarshal([]byte(requiredFieldsJSON), &requiredFields); err != nil {
-			return fmt.Errorf("invalid required fields JSON: %v", err)
+			return newError(ErrValidationFailed, "invalid required fields JSON: %v", err).with("field", "requiredFields")
 		}
 		if err := json.Unmarshal([]byte(optionalFieldsJSON), &optionalFields); err != nil {
-			return fmt.Errorf("invalid optional fields JSON: %v", err)
+			return newError(ErrValidationFailed, "invalid optional fields JSON: %v", err).with("field", "optionalFields")
 		}
 
 		if !equalStringSlices(existing.RequiredFields, requiredFields) ||
 			!equalStringSlices(existing.OptionalFields, optionalFields) ||
 			existing.Name != name || existing.Description != description {
-			return fmt.Errorf("cannot reactivate document type %s: schema does not match existing definition", id)
+			return newError(ErrInvalidState, "cannot reactivate document type %s: schema does not match existing definition", id).with("id", id)
 		}
 
 		existing.IsActive = true
-		return s.putDocumentType(ctx, existing)
+		if err := s.putDocumentType(ctx, existing); err != nil {
+			return err
+		}
+		return s.emitEvent(ctx, EventDocumentTypeRegistered, id)
 	}
 
 	clientID, err := s.getClientIdentity(ctx)
@@ -150,10 +335,10 @@ func (s *SpendingContract) RegisterDocumentType(ctx contractapi.TransactionConte
 
 	var requiredFields, optionalFields []string
 	if err := json.Unmarshal([]byte(requiredFieldsJSON), &requiredFields); err != nil {
-		return fmt.Errorf("invalid required fields JSON: %v", err)
+		return newError(ErrValidationFailed, "invalid required fields JSON: %v", err).with("field", "requiredFields")
 	}
 	if err := json.Unmarshal([]byte(optionalFieldsJSON), &optionalFields); err != nil {
-		return fmt.Errorf("invalid optional fields JSON: %v", err)
+		return newError(ErrValidationFailed, "invalid optional fields JSON: %v", err).with("field", "optionalFields")
 	}
 
 	docType := &DocumentType{
@@ -168,26 +353,29 @@ func (s *SpendingContract) RegisterDocumentType(ctx contractapi.TransactionConte
 		IsActive:       true,
 	}
 
-	return s.putDocumentType(ctx, docType)
+	if err := s.putDocumentType(ctx, docType); err != nil {
+		return err
+	}
+	return s.emitEvent(ctx, EventDocumentTypeRegistered, id)
 }
 
 func (s *SpendingContract) GetDocumentType(ctx contractapi.TransactionContextInterface, id string) (*DocumentType, error) {
 	key, err := ctx.GetStub().CreateCompositeKey(TypePrefix, []string{id})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create composite key: %v", err)
+		return nil, internalError("failed to create composite key: %v", err)
 	}
 
 	data, err := ctx.GetStub().GetState(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read state: %v", err)
+		return nil, internalError("failed to read state: %v", err)
 	}
 	if data == nil {
-		return nil, fmt.Errorf("document type %s not found", id)
+		return nil, notFoundError("document type", id)
 	}
 
 	var docType DocumentType
 	if err := json.Unmarshal(data, &docType); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal document type: %v", err)
+		return nil, internalError("failed to unmarshal document type: %v", err)
 	}
 
 	return &docType, nil
@@ -196,7 +384,7 @@ func (s *SpendingContract) GetDocumentType(ctx contractapi.TransactionContextInt
 func (s *SpendingContract) ListDocumentTypes(ctx contractapi.TransactionContextInterface, orgID string) ([]*DocumentType, error) {
 	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(TypePrefix, []string{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get state: %v", err)
+		return nil, internalError("failed to get state: %v", err)
 	}
 	defer iterator.Close()
 
@@ -204,12 +392,12 @@ func (s *SpendingContract) ListDocumentTypes(ctx contractapi.TransactionContextI
 	for iterator.HasNext() {
 		result, err := iterator.Next()
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate: %v", err)
+			return nil, internalError("failed to iterate: %v", err)
 		}
 
 		var docType DocumentType
 		if err := json.Unmarshal(result.Value, &docType); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal: %v", err)
+			return nil, internalError("failed to unmarshal: %v", err)
 		}
 
 		if orgID == "" || docType.OrganizationID == orgID {
@@ -231,11 +419,14 @@ func (s *SpendingContract) DeactivateDocumentType(ctx contractapi.TransactionCon
 		return err
 	}
 	if docType.OrganizationID != orgID {
-		return fmt.Errorf("only the owning organization can deactivate a document type")
+		return newError(ErrPermissionDenied, "only the owning organization can deactivate a document type").with("id", id)
 	}
 
 	docType.IsActive = false
-	return s.putDocumentType(ctx, docType)
+	if err := s.putDocumentType(ctx, docType); err != nil {
+		return err
+	}
+	return s.emitEvent(ctx, EventDocumentTypeDeactivated, id)
 }
 
 // =============================================================================
@@ -252,15 +443,15 @@ func (s *SpendingContract) CreateDocument(ctx contractapi.TransactionContextInte
 		return err
 	}
 	if exists {
-		return fmt.Errorf("document %s already exists", id)
+		return newError(ErrAlreadyExists, "document %s already exists", id).with("resource", "document").with("id", id)
 	}
 
 	docType, err := s.GetDocumentType(ctx, documentTypeID)
 	if err != nil {
-		return fmt.Errorf("document type not found: %v", err)
+		return newError(ErrInvalidDocumentType, "document type %s not found", documentTypeID).with("documentTypeId", documentTypeID)
 	}
 	if !docType.IsActive {
-		return fmt.Errorf("document type %s is not active", documentTypeID)
+		return newError(ErrInvalidDocumentType, "document type %s is not active", documentTypeID).with("documentTypeId", documentTypeID)
 	}
 
 	clientID, err := s.getClientIdentity(ctx)
@@ -274,7 +465,7 @@ func (s *SpendingContract) CreateDocument(ctx contractapi.TransactionContextInte
 
 	var data map[string]interface{}
 	if err := json.Unmarshal([]byte(dataJSON), &data); err != nil {
-		return fmt.Errorf("invalid data JSON: %v", err)
+		return newError(ErrValidationFailed, "invalid data JSON: %v", err).with("field", "data")
 	}
 
 	if err := s.validateRequiredFields(docType.RequiredFields, data); err != nil {
@@ -315,7 +506,10 @@ func (s *SpendingContract) CreateDocument(ctx contractapi.TransactionContextInte
 		History:   []string{txID},
 	}
 
-	return s.putDocument(ctx, doc)
+	if err := s.putDocument(ctx, doc); err != nil {
+		return err
+	}
+	return s.emitEvent(ctx, EventDocumentCreated, id)
 }
 
 func (s *SpendingContract) CreateSimpleDocument(ctx contractapi.TransactionContextInterface,
@@ -327,20 +521,20 @@ func (s *SpendingContract) CreateSimpleDocument(ctx contractapi.TransactionConte
 func (s *SpendingContract) GetDocument(ctx contractapi.TransactionContextInterface, id string) (*Document, error) {
 	key, err := ctx.GetStub().CreateCompositeKey(DocPrefix, []string{id})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create composite key: %v", err)
+		return nil, internalError("failed to create composite key: %v", err)
 	}
 
 	data, err := ctx.GetStub().GetState(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read state: %v", err)
+		return nil, internalError("failed to read state: %v", err)
 	}
 	if data == nil {
-		return nil, fmt.Errorf("document %s not found", id)
+		return nil, notFoundError("document", id)
 	}
 
 	var doc Document
 	if err := json.Unmarshal(data, &doc); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal document: %v", err)
+		return nil, internalError("failed to unmarshal document: %v", err)
 	}
 
 	if doc.Data == nil {
@@ -356,7 +550,13 @@ func (s *SpendingContract) GetDocument(ctx contractapi.TransactionContextInterfa
 func (s *SpendingContract) QueryDocuments(ctx contractapi.TransactionContextInterface, filterJSON string) (*QueryResult, error) {
 	var filter QueryFilter
 	if err := json.Unmarshal([]byte(filterJSON), &filter); err != nil {
-		return nil, fmt.Errorf("invalid filter JSON: %v", err)
+		return nil, newError(ErrInvalidQuery, "invalid filter JSON: %v", err)
+	}
+	if err := validateQueryOptions(filter); err != nil {
+		return nil, err
+	}
+	if err := s.validateConditions(ctx, filter); err != nil {
+		return nil, err
 	}
 
 	queryString := s.buildQuery(filter)
@@ -368,7 +568,7 @@ func (s *SpendingContract) QueryDocuments(ctx contractapi.TransactionContextInte
 
 	resultsIterator, meta, err := ctx.GetStub().GetQueryResultWithPagination(queryString, int32(pageSize), filter.Bookmark)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %v", err)
+		return nil, internalError("failed to execute query: %v", err)
 	}
 	defer resultsIterator.Close()
 
@@ -376,12 +576,12 @@ func (s *SpendingContract) QueryDocuments(ctx contractapi.TransactionContextInte
 	for resultsIterator.HasNext() {
 		result, err := resultsIterator.Next()
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate: %v", err)
+			return nil, internalError("failed to iterate: %v", err)
 		}
 
 		var doc Document
 		if err := json.Unmarshal(result.Value, &doc); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal: %v", err)
+			return nil, internalError("failed to unmarshal: %v", err)
 		}
 		if doc.Data == nil {
 			doc.Data = map[string]interface{}{}
@@ -392,11 +592,58 @@ func (s *SpendingContract) QueryDocuments(ctx contractapi.TransactionContextInte
 		documents = append(documents, &doc)
 	}
 
-	return &QueryResult{
+	queryResult := &QueryResult{
 		Documents: documents,
 		Bookmark:  meta.GetBookmark(),
-		Total:     len(documents),
-	}, nil
+		Count:     len(documents),
+	}
+
+	if filter.CountMode != "" {
+		total, estimated, err := s.countDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		queryResult.Total = total
+		queryResult.TotalEstimated = estimated
+		queryResult.counted = true
+	}
+
+	return queryResult, nil
+}
+
+// countDocuments counts every document matching the filter, ignoring paging.
+// Exact counts are still bounded by the peer's totalQueryLimit.
+func (s *SpendingContract) countDocuments(ctx contractapi.TransactionContextInterface, filter QueryFilter) (int, bool, error) {
+	countQuery, _ := json.Marshal(map[string]interface{}{
+		"selector": s.buildSelector(filter),
+	})
+
+	if filter.CountMode == CountEstimated {
+		iterator, meta, err := ctx.GetStub().GetQueryResultWithPagination(string(countQuery), estimatedCountLimit, "")
+		if err != nil {
+			return 0, false, internalError("failed to execute count query: %v", err)
+		}
+		defer iterator.Close()
+
+		fetched := int(meta.GetFetchedRecordsCount())
+		return fetched, fetched >= estimatedCountLimit, nil
+	}
+
+	iterator, err := ctx.GetStub().GetQueryResult(string(countQuery))
+	if err != nil {
+		return 0, false, internalError("failed to execute count query: %v", err)
+	}
+	defer iterator.Close()
+
+	total := 0
+	for iterator.HasNext() {
+		if _, err := iterator.Next(); err != nil {
+			return 0, false, internalError("failed to iterate: %v", err)
+		}
+		total++
+	}
+
+	return total, false, nil
 }
 
 func (s *SpendingContract) InvalidateDocument(ctx contractapi.TransactionContextInterface,
@@ -408,7 +655,7 @@ func (s *SpendingContract) InvalidateDocument(ctx contractapi.TransactionContext
 	}
 
 	if doc.Status == StatusInvalidated {
-		return fmt.Errorf("document %s is already invalidated", id)
+		return newError(ErrInvalidState, "document %s is already invalidated", id).with("id", id).with("status", string(doc.Status))
 	}
 
 	orgID, err := s.getClientOrg(ctx)
@@ -416,7 +663,7 @@ func (s *SpendingContract) InvalidateDocument(ctx contractapi.TransactionContext
 		return err
 	}
 	if doc.OrganizationID != orgID {
-		return fmt.Errorf("only the creating organization can invalidate a document")
+		return newError(ErrPermissionDenied, "only the creating organization can invalidate a document").with("id", id)
 	}
 
 	if correctionDocID != "" {
@@ -425,7 +672,7 @@ func (s *SpendingContract) InvalidateDocument(ctx contractapi.TransactionContext
 			return err
 		}
 		if !exists {
-			return fmt.Errorf("correction document %s not found", correctionDocID)
+			return notFoundError("correction document", correctionDocID).with("field", "correctionDocId")
 		}
 	}
 
@@ -444,7 +691,10 @@ func (s *SpendingContract) InvalidateDocument(ctx contractapi.TransactionContext
 	doc.UpdatedBy = clientID
 	doc.History = append(doc.History, txID)
 
-	return s.putDocument(ctx, doc)
+	if err := s.putDocument(ctx, doc); err != nil {
+		return err
+	}
+	return s.emitEvent(ctx, EventDocumentInvalidated, id)
 }
 
 func (s *SpendingContract) UpdateDocumentLink(ctx contractapi.TransactionContextInterface,
@@ -460,7 +710,7 @@ func (s *SpendingContract) UpdateDocumentLink(ctx contractapi.TransactionContext
 		return err
 	}
 	if doc.OrganizationID != orgID {
-		return fmt.Errorf("only the creating organization can update document links")
+		return newError(ErrPermissionDenied, "only the creating organization can update document links").with("id", id)
 	}
 
 	clientID, err := s.getClientIdentity(ctx)
@@ -476,18 +726,21 @@ func (s *SpendingContract) UpdateDocumentLink(ctx contractapi.TransactionContext
 	doc.UpdatedBy = clientID
 	doc.History = append(doc.History, txID)
 
-	return s.putDocument(ctx, doc)
+	if err := s.putDocument(ctx, doc); err != nil {
+		return err
+	}
+	return s.emitEvent(ctx, EventDocumentLinkUpdated, id)
 }
 
 func (s *SpendingContract) GetDocumentHistory(ctx contractapi.TransactionContextInterface, id string) ([]map[string]interface{}, error) {
 	key, err := ctx.GetStub().CreateCompositeKey(DocPrefix, []string{id})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create composite key: %v", err)
+		return nil, internalError("failed to create composite key: %v", err)
 	}
 
 	iterator, err := ctx.GetStub().GetHistoryForKey(key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get history: %v", err)
+		return nil, internalError("failed to get history: %v", err)
 	}
 	defer iterator.Close()
 
@@ -495,12 +748,12 @@ func (s *SpendingContract) GetDocumentHistory(ctx contractapi.TransactionContext
 	for iterator.HasNext() {
 		result, err := iterator.Next()
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate: %v", err)
+			return nil, internalError("failed to iterate: %v", err)
 		}
 
 		var doc Document
 		if err := json.Unmarshal(result.Value, &doc); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal: %v", err)
+			return nil, internalError("failed to unmarshal: %v", err)
 		}
 
 		entry := map[string]interface{}{
@@ -546,12 +799,12 @@ func (s *SpendingContract) documentTypeExists(ctx contractapi.TransactionContext
 func (s *SpendingContract) putDocument(ctx contractapi.TransactionContextInterface, doc *Document) error {
 	key, err := ctx.GetStub().CreateCompositeKey(DocPrefix, []string{doc.ID})
 	if err != nil {
-		return fmt.Errorf("failed to create composite key: %v", err)
+		return internalError("failed to create composite key: %v", err)
 	}
 
 	data, err := json.Marshal(doc)
 	if err != nil {
-		return fmt.Errorf("failed to marshal document: %v", err)
+		return internalError("failed to marshal document: %v", err)
 	}
 
 	return ctx.GetStub().PutState(key, data)
@@ -560,21 +813,43 @@ func (s *SpendingContract) putDocument(ctx contractapi.TransactionContextInterfa
 func (s *SpendingContract) putDocumentType(ctx contractapi.TransactionContextInterface, docType *DocumentType) error {
 	key, err := ctx.GetStub().CreateCompositeKey(TypePrefix, []string{docType.ID})
 	if err != nil {
-		return fmt.Errorf("failed to create composite key: %v", err)
+		return internalError("failed to create composite key: %v", err)
 	}
 
 	data, err := json.Marshal(docType)
 	if err != nil {
-		return fmt.Errorf("failed to marshal document type: %v", err)
+		return internalError("failed to marshal document type: %v", err)
 	}
 
 	return ctx.GetStub().PutState(key, data)
 }
 
+// emitEvent sets the transaction's chaincode event. A transaction carries a
+// single event, so every write transaction emits one, last. The API passes
+// its request ID as transient data; echoing it in the event ties the
+// transaction to the HTTP request that submitted it.
+func (s *SpendingContract) emitEvent(ctx contractapi.TransactionContextInterface, name string, id string) error {
+	transient, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return internalError("failed to read transient data: %v", err)
+	}
+
+	payload, err := json.Marshal(ChaincodeEvent{
+		ID:        id,
+		TxID:      ctx.GetStub().GetTxID(),
+		RequestID: string(transient[TransientRequestID]),
+	})
+	if err != nil {
+		return internalError("failed to marshal event: %v", err)
+	}
+
+	return ctx.GetStub().SetEvent(name, payload)
+}
+
 func (s *SpendingContract) getClientIdentity(ctx contractapi.TransactionContextInterface) (string, error) {
 	id, err := ctx.GetClientIdentity().GetID()
 	if err != nil {
-		return "", fmt.Errorf("failed to get client identity: %v", err)
+		return "", internalError("failed to get client identity: %v", err)
 	}
 	return id, nil
 }
@@ -582,7 +857,7 @@ func (s *SpendingContract) getClientIdentity(ctx contractapi.TransactionContextI
 func (s *SpendingContract) getClientOrg(ctx contractapi.TransactionContextInterface) (string, error) {
 	mspID, err := ctx.GetClientIdentity().GetMSPID()
 	if err != nil {
-		return "", fmt.Errorf("failed to get MSP ID: %v", err)
+		return "", internalError("failed to get MSP ID: %v", err)
 	}
 	return mspID, nil
 }
@@ -591,7 +866,7 @@ func (s *SpendingContract) getClientOrg(ctx contractapi.TransactionContextInterf
 func (s *SpendingContract) validateRequiredFields(required []string, data map[string]interface{}) error {
 	for _, field := range required {
 		if _, ok := data[field]; !ok {
-			return fmt.Errorf("missing required field: %s", field)
+			return newError(ErrValidationFailed, "missing required field: %s", field).with("field", field)
 		}
 	}
 	return nil
@@ -603,7 +878,148 @@ func (s *SpendingContract) calculateHash(data map[string]interface{}) string {
 	return hex.EncodeToString(hash[:])
 }
 
+func validateQueryOptions(filter QueryFilter) error {
+	if filter.SortBy != "" {
+		if _, ok := sortIndexes[filter.SortBy]; !ok {
+			return newError(ErrInvalidQuery, "invalid sort field: %s", filter.SortBy).with("field", "sortBy")
+		}
+	}
+	if filter.SortOrder != "" && filter.SortOrder != SortAsc && filter.SortOrder != SortDesc {
+		return newError(ErrInvalidQuery, "invalid sort order: %s", filter.SortOrder).with("field", "sortOrder")
+	}
+	if filter.CountMode != "" && filter.CountMode != CountExact && filter.CountMode != CountEstimated {
+		return newError(ErrInvalidQuery, "invalid count mode: %s", filter.CountMode).with("field", "countMode")
+	}
+	return nil
+}
+
+// validateConditions rejects conditions on undeclared fields, unknown operators
+// and non-scalar values, so a filter can never inject its own selector syntax.
+// Data keys must be declared by the filtered document type, or by any type on
+// the channel when no type filter is given.
+func (s *SpendingContract) validateConditions(ctx contractapi.TransactionContextInterface, filter QueryFilter) error {
+	if len(filter.Conditions) == 0 {
+		return nil
+	}
+	if len(filter.Conditions) > maxConditions {
+		return newError(ErrInvalidQuery, "invalid filter: at most %d conditions are allowed", maxConditions).with("field", "conditions")
+	}
+
+	var declared map[string]bool
+	for _, cond := range filter.Conditions {
+		if _, ok := conditionOperators[cond.Op]; !ok {
+			return newError(ErrInvalidQuery, "invalid condition operator %q on %s", cond.Op, cond.Field).with("field", cond.Field)
+		}
+
+		if strings.HasPrefix(cond.Field, DataFieldPrefix) {
+			key := strings.TrimPrefix(cond.Field, DataFieldPrefix)
+			if !dataKeyPattern.MatchString(key) {
+				return newError(ErrInvalidQuery, "invalid condition field: %s", cond.Field).with("field", cond.Field)
+			}
+			if declared == nil {
+				fields, err := s.declaredDataFields(ctx, filter.DocumentTypeID)
+				if err != nil {
+					return err
+				}
+				declared = fields
+			}
+			if !declared[key] {
+				return newError(ErrInvalidQuery, "invalid condition field: %s is not declared by any document type", cond.Field).with("field", cond.Field)
+			}
+		} else if !conditionFields[cond.Field] {
+			return newError(ErrInvalidQuery, "invalid condition field: %s", cond.Field).with("field", cond.Field)
+		}
+
+		switch cond.Op {
+		case OpIn:
+			if len(cond.Values) == 0 || len(cond.Values) > maxInValues {
+				return newError(ErrInvalidQuery, "invalid condition on %s: in requires 1 to %d values", cond.Field, maxInValues).with("field", cond.Field)
+			}
+			for _, v := range cond.Values {
+				if !isScalar(v) {
+					return newError(ErrInvalidQuery, "invalid condition value on %s: only scalar values are allowed", cond.Field).with("field", cond.Field)
+				}
+			}
+		case OpExists:
+			if _, ok := cond.Value.(bool); !ok {
+				return newError(ErrInvalidQuery, "invalid condition on %s: exists requires a boolean value", cond.Field).with("field", cond.Field)
+			}
+		default:
+			if cond.Value == nil || !isScalar(cond.Value) {
+				return newError(ErrInvalidQuery, "invalid condition value on %s: only scalar values are allowed", cond.Field).with("field", cond.Field)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SpendingContract) declaredDataFields(ctx contractapi.TransactionContextInterface, documentTypeID string) (map[string]bool, error) {
+	var types []*DocumentType
+	if documentTypeID != "" {
+		docType, err := s.GetDocumentType(ctx, documentTypeID)
+		if err != nil {
+			return nil, err
+		}
+		types = []*DocumentType{docType}
+	} else {
+		all, err := s.ListDocumentTypes(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		types = all
+	}
+
+	fields := make(map[string]bool)
+	for _, docType := range types {
+		for _, f := range docType.RequiredFields {
+			fields[f] = true
+		}
+		for _, f := range docType.OptionalFields {
+			fields[f] = true
+		}
+	}
+	return fields, nil
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *SpendingContract) buildQuery(filter QueryFilter) string {
+	selector := s.buildSelector(filter)
+
+	sortField := filter.SortBy
+	if sortField == "" {
+		sortField = "createdAt"
+	}
+	sortOrder := filter.SortOrder
+	if sortOrder == "" {
+		sortOrder = SortDesc
+	}
+
+	// CouchDB only sorts on indexed fields that appear in the selector
+	if _, ok := selector[sortField]; !ok {
+		selector[sortField] = map[string]interface{}{"$gt": nil}
+	}
+
+	index := sortIndexes[sortField]
+	query := map[string]interface{}{
+		"selector":  selector,
+		"sort":      []map[string]string{{sortField: sortOrder}},
+		"use_index": []string{"_design/" + index[0], index[1]},
+	}
+
+	queryJSON, _ := json.Marshal(query)
+	return string(queryJSON)
+}
+
+func (s *SpendingContract) buildSelector(filter QueryFilter) map[string]interface{} {
 	selector := make(map[string]interface{})
 
 	if filter.DocumentTypeID != "" {
@@ -651,13 +1067,21 @@ func (s *SpendingContract) buildQuery(filter QueryFilter) string {
 		selector["createdAt"] = dateFilter
 	}
 
-	query := map[string]interface{}{
-		"selector": selector,
-		"sort":     []map[string]string{{"createdAt": "desc"}},
+	if len(filter.Conditions) > 0 {
+		clauses := make([]map[string]interface{}, 0, len(filter.Conditions))
+		for _, cond := range filter.Conditions {
+			value := cond.Value
+			if cond.Op == OpIn {
+				value = cond.Values
+			}
+			clauses = append(clauses, map[string]interface{}{
+				cond.Field: map[string]interface{}{conditionOperators[cond.Op]: value},
+			})
+		}
+		selector["$and"] = clauses
 	}
 
-	queryJSON, _ := json.Marshal(query)
-	return string(queryJSON)
+	return selector
 }
 
 func equalStringSlices(a, b []string) bool {
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// queryStub is a MockStub that also answers CouchDB rich queries, which
+// MockStub does not support: every query matches the same documents. It
+// records the queries run.
+type queryStub struct {
+	*shimtest.MockStub
+	matches int
+	queries []stubQuery
+}
+
+type stubQuery struct {
+	query    string
+	pageSize int32 // 0 for an unpaginated query
+}
+
+func (s *queryStub) documents(limit int) []*queryresult.KV {
+	var kvs []*queryresult.KV
+	for i := 0; i < s.matches && (limit <= 0 || i < limit); i++ {
+		id := fmt.Sprintf("doc-%04d", i)
+		value, _ := json.Marshal(Document{ID: id, DocumentTypeID: "federal-expense", Status: StatusActive})
+		kvs = append(kvs, &queryresult.KV{Key: id, Value: value})
+	}
+	return kvs
+}
+
+func (s *queryStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	s.queries = append(s.queries, stubQuery{query: query})
+	return &kvIterator{kvs: s.documents(0)}, nil
+}
+
+func (s *queryStub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	s.queries = append(s.queries, stubQuery{query: query, pageSize: pageSize})
+	kvs := s.documents(int(pageSize))
+	return &kvIterator{kvs: kvs}, &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(kvs)), Bookmark: "next"}, nil
+}
+
+type kvIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (it *kvIterator) HasNext() bool { return len(it.kvs) > 0 }
+func (it *kvIterator) Close() error  { return nil }
+
+func (it *kvIterator) Next() (*queryresult.KV, error) {
+	kv := it.kvs[0]
+	it.kvs = it.kvs[1:]
+	return kv, nil
+}
+
+// newQueryContext returns a transaction context on a stub whose queries match
+// matches documents, with the federal-expense type registered.
+func newQueryContext(t *testing.T, matches int) (*contractapi.TransactionContext, *queryStub) {
+	t.Helper()
+	stub := &queryStub{MockStub: shimtest.NewMockStub("spending", nil), matches: matches}
+	ctx := &contractapi.TransactionContext{}
+	ctx.SetStub(stub)
+
+	docType, _ := json.Marshal(DocumentType{
+		ID: "federal-expense", OrganizationID: "UnionMSP", Name: "Federal Expense",
+		RequiredFields: []string{"category", "vendor"},
+		OptionalFields: []string{"invoiceNumber"},
+		IsActive:       true,
+	})
+	key, _ := stub.CreateCompositeKey(TypePrefix, []string{"federal-expense"})
+	stub.MockTransactionStart("seed")
+	if err := stub.PutState(key, docType); err != nil {
+		t.Fatal(err)
+	}
+	stub.MockTransactionEnd("seed")
+	return ctx, stub
+}
+
+// decodeQuery parses a CouchDB query built by the contract.
+func decodeQuery(t *testing.T, query string) map[string]interface{} {
+	t.Helper()
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(query), &decoded); err != nil {
+		t.Fatalf("query %s: %v", query, err)
+	}
+	return decoded
+}
+
+// wantContractError checks that err is a ContractError with the given code.
+func wantContractError(t *testing.T, err error, code ErrorCode) {
+	t.Helper()
+	var contractErr *ContractError
+	if !errors.As(err, &contractErr) || contractErr.Code != code {
+		t.Errorf("error = %v, want a ContractError %s", err, code)
+	}
+}
+
+func TestBuildQuerySortsOnAnIndexedFieldInTheSelector(t *testing.T) {
+	s := &SpendingContract{}
+	tests := []struct {
+		name      string
+		filter    QueryFilter
+		sort      []interface{}
+		index     []interface{}
+		selectors map[string]interface{}
+	}{
+		{
+			name:   "defaults",
+			filter: QueryFilter{},
+			sort:   []interface{}{map[string]interface{}{"createdAt": "desc"}},
+			index:  []interface{}{"_design/indexCreatedAtDoc", "indexCreatedAt"},
+			selectors: map[string]interface{}{
+				"documentTypeId": map[string]interface{}{"$ne": ""},
+				"createdAt":      map[string]interface{}{"$gt": nil},
+			},
+		},
+		{
+			name:   "sort by amount",
+			filter: QueryFilter{DocumentTypeID: "federal-expense", SortBy: "amount", SortOrder: SortAsc},
+			sort:   []interface{}{map[string]interface{}{"amount": "asc"}},
+			index:  []interface{}{"_design/indexAmountDoc", "indexAmount"},
+			selectors: map[string]interface{}{
+				"documentTypeId": "federal-expense",
+				"amount":         map[string]interface{}{"$gt": nil},
+			},
+		},
+		{
+			name:   "sort field already filtered",
+			filter: QueryFilter{MinAmount: 100, SortBy: "amount"},
+			sort:   []interface{}{map[string]interface{}{"amount": "desc"}},
+			index:  []interface{}{"_design/indexAmountDoc", "indexAmount"},
+			selectors: map[string]interface{}{
+				"amount": map[string]interface{}{"$gte": 100.0},
+			},
+		},
+		{
+			name:   "sort by title",
+			filter: QueryFilter{Status: StatusActive, SortBy: "title"},
+			sort:   []interface{}{map[string]interface{}{"title": "desc"}},
+			index:  []interface{}{"_design/indexTitleDoc", "indexTitle"},
+			selectors: map[string]interface{}{
+				"status": "ACTIVE",
+				"title":  map[string]interface{}{"$gt": nil},
+			},
+		},
+		{
+			name: "conditions",
+			filter: QueryFilter{Conditions: []FieldCondition{
+				{Field: "data.vendor", Op: OpEq, Value: "Acme"},
+				{Field: "currency", Op: OpIn, Values: []interface{}{"BRL", "USD"}},
+			}},
+			sort:  []interface{}{map[string]interface{}{"createdAt": "desc"}},
+			index: []interface{}{"_design/indexCreatedAtDoc", "indexCreatedAt"},
+			selectors: map[string]interface{}{
+				"$and": []interface{}{
+					map[string]interface{}{"data.vendor": map[string]interface{}{"$eq": "Acme"}},
+					map[string]interface{}{"currency": map[string]interface{}{"$in": []interface{}{"BRL", "USD"}}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := decodeQuery(t, s.buildQuery(tt.filter))
+			if !reflect.DeepEqual(query["sort"], tt.sort) {
+				t.Errorf("sort = %v, want %v", query["sort"], tt.sort)
+			}
+			if !reflect.DeepEqual(query["use_index"], tt.index) {
+				t.Errorf("use_index = %v, want %v", query["use_index"], tt.index)
+			}
+			selector, _ := query["selector"].(map[string]interface{})
+			for field, want := range tt.selectors {
+				if !reflect.DeepEqual(selector[field], want) {
+					t.Errorf("selector[%s] = %v, want %v", field, selector[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryDocumentsCountModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		countMode string
+		matches   int
+		total     *int
+		estimated bool
+	}{
+		{name: "no count", matches: 30},
+		{name: "exact", countMode: CountExact, matches: 1500, total: intPtr(1500)},
+		{name: "exact none", countMode: CountExact, matches: 0, total: intPtr(0)},
+		{name: "estimated under the cap", countMode: CountEstimated, matches: 30, total: intPtr(30)},
+		{name: "estimated at the cap", countMode: CountEstimated, matches: 1500, total: intPtr(estimatedCountLimit), estimated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stub := newQueryContext(t, tt.matches)
+			filter, _ := json.Marshal(QueryFilter{DocumentTypeID: "federal-expense", CountMode: tt.countMode, PageSize: 10})
+
+			result, err := (&SpendingContract{}).QueryDocuments(ctx, string(filter))
+			if err != nil {
+				t.Fatalf("query: %v", err)
+			}
+			if result.Count != min(10, tt.matches) || result.Bookmark != "next" {
+				t.Errorf("page = %d documents, bookmark %q", result.Count, result.Bookmark)
+			}
+
+			var out struct {
+				Total          *int `json:"total"`
+				TotalEstimated bool `json:"totalEstimated"`
+			}
+			data, _ := json.Marshal(result)
+			json.Unmarshal(data, &out)
+			if !reflect.DeepEqual(out.Total, tt.total) || out.TotalEstimated != tt.estimated {
+				t.Errorf("total = %v, estimated = %v; want %v, %v", deref(out.Total), out.TotalEstimated, deref(tt.total), tt.estimated)
+			}
+
+			// The page query sorts on an index; a count needs only the selector.
+			wantQueries := 1
+			if tt.countMode != "" {
+				wantQueries = 2
+			}
+			if len(stub.queries) != wantQueries {
+				t.Fatalf("queries = %d, want %d", len(stub.queries), wantQueries)
+			}
+			if page := decodeQuery(t, stub.queries[0].query); page["sort"] == nil || stub.queries[0].pageSize != 10 {
+				t.Errorf("page query = %s with page size %d", stub.queries[0].query, stub.queries[0].pageSize)
+			}
+			if tt.countMode == "" {
+				return
+			}
+			count := stub.queries[1]
+			if query := decodeQuery(t, count.query); query["sort"] != nil || query["use_index"] != nil || query["selector"] == nil {
+				t.Errorf("count query = %s, want the selector only", count.query)
+			}
+			wantPageSize := int32(0)
+			if tt.countMode == CountEstimated {
+				wantPageSize = estimatedCountLimit
+			}
+			if count.pageSize != wantPageSize {
+				t.Errorf("count query page size = %d, want %d", count.pageSize, wantPageSize)
+			}
+		})
+	}
+}
+
+func TestQueryDocumentsRejectsInvalidOptions(t *testing.T) {
+	ctx, stub := newQueryContext(t, 1)
+	for _, filter := range []string{
+		`{"countMode": "approximate"}`,
+		`{"sortBy": "vendor"}`,
+		`{"sortOrder": "up"}`,
+		`{"pageSize": "ten"}`,
+	} {
+		_, err := (&SpendingContract{}).QueryDocuments(ctx, filter)
+		wantContractError(t, err, ErrInvalidQuery)
+	}
+	if len(stub.queries) != 0 {
+		t.Errorf("invalid filters ran %d queries", len(stub.queries))
+	}
+}
+
+func TestQueryDocumentsRejectsSelectorInjection(t *testing.T) {
+	tests := []struct {
+		name       string
+		typeFilter string
+		condition  string
+		field      string
+	}{
+		{"where clause", "federal-expense", `{"field": "$where", "op": "eq", "value": "1"}`, "$where"},
+		{"where operator", "federal-expense", `{"field": "data.vendor", "op": "$where", "value": "1"}`, "data.vendor"},
+		{"regex on a declared field", "federal-expense", `{"field": "data.vendor", "op": "$regex", "value": ".*"}`, "data.vendor"},
+		{"regex on an undeclared field", "federal-expense", `{"field": "data.secret", "op": "regex", "value": ".*"}`, "data.secret"},
+		{"undeclared field", "federal-expense", `{"field": "data.secret", "op": "eq", "value": "x"}`, "data.secret"},
+		{"undeclared on every type", "", `{"field": "data.secret", "op": "eq", "value": "x"}`, "data.secret"},
+		{"and as a field", "federal-expense", `{"field": "$and", "op": "eq", "value": "x"}`, "$and"},
+		{"or nested in a value", "federal-expense", `{"field": "data.vendor", "op": "eq", "value": {"$or": [{"data.vendor": "Acme"}, {"amount": {"$gt": 0}}]}}`, "data.vendor"},
+		{"operator nested in a value", "federal-expense", `{"field": "data.vendor", "op": "eq", "value": {"$regex": ".*"}}`, "data.vendor"},
+		{"operator nested in in values", "federal-expense", `{"field": "data.vendor", "op": "in", "values": ["Acme", {"$ne": ""}]}`, "data.vendor"},
+		{"array value", "federal-expense", `{"field": "amount", "op": "gt", "value": [1, 2]}`, "amount"},
+		{"exists without a boolean", "federal-expense", `{"field": "data.vendor", "op": "exists", "value": {"$ne": null}}`, "data.vendor"},
+		{"top-level data key", "federal-expense", `{"field": "vendor", "op": "eq", "value": "Acme"}`, "vendor"},
+		{"data itself", "federal-expense", `{"field": "data", "op": "exists", "value": true}`, "data"},
+		{"data in another case", "federal-expense", `{"field": "Data.vendor", "op": "eq", "value": "Acme"}`, "Data.vendor"},
+		{"nested data key", "federal-expense", `{"field": "data.vendor.name", "op": "eq", "value": "Acme"}`, "data.vendor.name"},
+		{"operator as a data key", "federal-expense", `{"field": "data.$where", "op": "eq", "value": "1"}`, "data.$where"},
+		{"unlisted document field", "federal-expense", `{"field": "history", "op": "eq", "value": "x"}`, "history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stub := newQueryContext(t, 1)
+			filter := fmt.Sprintf(`{"documentTypeId": %q, "conditions": [%s]}`, tt.typeFilter, tt.condition)
+
+			_, err := (&SpendingContract{}).QueryDocuments(ctx, filter)
+			wantContractError(t, err, ErrInvalidQuery)
+			var contractErr *ContractError
+			if errors.As(err, &contractErr) && contractErr.Fields["field"] != tt.field {
+				t.Errorf("error field = %q, want %q", contractErr.Fields["field"], tt.field)
+			}
+			if len(stub.queries) != 0 {
+				t.Errorf("a rejected filter ran %d queries", len(stub.queries))
+			}
+		})
+	}
+}
+
+func TestQueryDocumentsAcceptsDeclaredConditions(t *testing.T) {
+	for _, typeFilter := range []string{"federal-expense", ""} {
+		ctx, stub := newQueryContext(t, 1)
+		filter := fmt.Sprintf(`{"documentTypeId": %q, "conditions": [
+			{"field": "data.vendor", "op": "in", "values": ["Acme", "Globex"]},
+			{"field": "data.invoiceNumber", "op": "exists", "value": true},
+			{"field": "amount", "op": "gte", "value": 10}
+		]}`, typeFilter)
+
+		if _, err := (&SpendingContract{}).QueryDocuments(ctx, filter); err != nil {
+			t.Errorf("type %q: %v", typeFilter, err)
+		}
+		if len(stub.queries) != 1 {
+			t.Errorf("type %q: queries = %d, want 1", typeFilter, len(stub.queries))
+		}
+	}
+}
+
+func TestQueryDocumentsLimitsConditions(t *testing.T) {
+	ctx, _ := newQueryContext(t, 1)
+	conditions := make([]FieldCondition, maxConditions+1)
+	for i := range conditions {
+		conditions[i] = FieldCondition{Field: "amount", Op: OpGt, Value: float64(i)}
+	}
+	filter, _ := json.Marshal(QueryFilter{Conditions: conditions})
+
+	_, err := (&SpendingContract{}).QueryDocuments(ctx, string(filter))
+	wantContractError(t, err, ErrInvalidQuery)
+}
+
+func TestQueryDocumentsOnAnUnknownTypeIsNotFound(t *testing.T) {
+	ctx, _ := newQueryContext(t, 1)
+	_, err := (&SpendingContract{}).QueryDocuments(ctx, `{"documentTypeId": "missing", "conditions": [{"field": "data.vendor", "op": "eq", "value": "Acme"}]}`)
+	wantContractError(t, err, ErrNotFound)
+}
+
+func intPtr(n int) *int { return &n }
+
+func deref(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
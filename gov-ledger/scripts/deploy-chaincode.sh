@@ -206,6 +206,30 @@ deploy_all() {
     log_info "=========================================="
 }
 
+derive_indexes() {
+    local channel=$1
+    local type_id=$2
+    local api=${3:-http://localhost:3000/api}
+    local index_dir="$CHAINCODE_DIR/META-INF/statedb/couchdb/indexes"
+
+    log_step "Deriving CouchDB indexes for $type_id on $channel"
+
+    local indexes
+    indexes=$(curl -sf "$api/$channel/document-types/$type_id/indexes") || {
+        log_error "Could not fetch index definitions from $api"
+        exit 1
+    }
+
+    echo "$indexes" | jq -c '.[]' | while read -r index; do
+        local name
+        name=$(echo "$index" | jq -r '.name')
+        echo "$index" | jq '.' > "$index_dir/$name.json"
+        log_info "Wrote $index_dir/$name.json"
+    done
+
+    log_warn "Repackage and upgrade the chaincode (bump CC_SEQUENCE) to apply new indexes"
+}
+
 case "${1:-}" in
     package)
         export FABRIC_CFG_PATH="$NETWORK_DIR"
@@ -220,13 +244,21 @@ case "${1:-}" in
         test_chaincode "state-channel" "State" "9051" "state.gov.br"
         test_chaincode "region-channel" "Region" "11051" "region.gov.br"
         ;;
+    indexes)
+        if [ -z "${2:-}" ] || [ -z "${3:-}" ]; then
+            echo "Usage: $0 indexes <channel> <documentTypeId> [apiUrl]"
+            exit 1
+        fi
+        derive_indexes "$2" "$3" "${4:-}"
+        ;;
     *)
-        echo "Usage: $0 {package|deploy|test}"
+        echo "Usage: $0 {package|deploy|test|indexes}"
         echo ""
         echo "Commands:"
         echo "  package - Package the chaincode"
         echo "  deploy  - Full deployment to all channels"
         echo "  test    - Test chaincode on all channels"
+        echo "  indexes - Derive data-field indexes from a document type"
         exit 1
         ;;
 esac
\ No newline at end of file
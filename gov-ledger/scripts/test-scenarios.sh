@@ -1,6 +1,9 @@
 #!/bin/bash
 
 # Government Spending Blockchain - Test Scenarios
+#
+# The same scenarios, with assertions, run as a Go test: see "End-to-End Tests"
+# in backend/DOCKER.md.
 
 set -e
 
@@ -724,17 +727,17 @@ internal_spending() {
     log_success "Utility expense recorded on union channel"
 
     log_info "Step 6: Query all expenses on union channel (includes contractors)"
-    curl -s "$UNION_API/$UNION_CHANNEL/documents?documentTypeId=federal-expense" | \
+    curl -s "$UNION_API/$UNION_CHANNEL/documents?documentTypeId=federal-expense&count=exact" | \
         jq '{total, documents: [.documents[] | {id, title, amount, vendor: .data.vendor}]}'
 
     log_info "Step 7: Query high-value expenses across all channels (> R$ 200K)"
     echo "Union channel (> R$ 200K):"
-    curl -s "$UNION_API/$UNION_CHANNEL/documents?minAmount=200000" | \
+    curl -s "$UNION_API/$UNION_CHANNEL/documents?minAmount=200000&sortBy=amount&count=exact" | \
         jq '{count: .total, documents: [.documents[] | {title, amount}]}'
 
     echo ""
     echo "State channel (> R$ 200K):"
-    curl -s "$STATE_API/$STATE_CHANNEL/documents?minAmount=200000" | \
+    curl -s "$STATE_API/$STATE_CHANNEL/documents?minAmount=200000&sortBy=amount&count=exact" | \
         jq '{count: .total, documents: [.documents[] | {title, amount}]}'
 
     log_info "Step 8: Compare anchored vs non-anchored documents"